@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/api"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+	u "github.com/felipeantoniob/conjugador-bot/internal/utils"
+)
+
+const (
+	errAPIServer = "api server failed"
+	msgAPI       = "serving the HTTP API"
+)
+
+// runAPI serves the HTTP API from the same verb data as the bot, without connecting to Discord.
+func runAPI(args []string) error {
+	flags := newFlagSet("api")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.load()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	sigCh := notifyShutdown()
+	lc := u.NewLifecycle(shutdownTimeout)
+	if err := openVerbsDB(ctx, lc, cfg.VerbsDBPath); err != nil {
+		return errors.Join(err, lc.Stop(ctx))
+	}
+	verbs, err := newVerbRepository(ctx, cfg.VerbStore, cfg.VerbCacheSize)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errVerbRepository, err), lc.Stop(ctx))
+	}
+	infinitives, err := loadInfinitiveIndex(ctx)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errInfinitiveIndex, err), lc.Stop(ctx))
+	}
+
+	server := api.NewServer(cfg.APIAddr, metrics.NewRepository(verbs), infinitives, newSearcher(ctx))
+	lc.Append(u.Hook{
+		Name:    "api server",
+		Timeout: inFlightTimeout,
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", cfg.APIAddr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error(errAPIServer, "error", err)
+				}
+			}()
+			slog.Info(msgAPI, "addr", cfg.APIAddr)
+			return nil
+		},
+		OnStop: server.Shutdown,
+	})
+
+	return lc.Run(ctx, sigCh)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+	u "github.com/felipeantoniob/conjugador-bot/internal/utils"
+)
+
+const (
+	errCheckFailed   = "database check failed"
+	errAppDBPending  = "app database is at schema version %d, %d migrations are pending"
+	msgVerbsDB       = "verbs.db: %s\n"
+	msgVerbsDBOK     = "  schema and %d infinitives: ok\n"
+	msgIntegrity     = "  integrity: %d problems, listed by 'verbsctl validate'\n"
+	msgSearchIndex   = "  search index: %s\n"
+	msgAppDB         = "app database: %s\n"
+	msgAppDBMissing  = "  not created yet; it is created on the first run"
+	msgAppDBVersion  = "  schema version %d of %d\n"
+	embeddedVerbsDB  = "embedded copy, checksum ok"
+	searchIndexReady = "ok"
+)
+
+func runCheckDB(args []string) error {
+	flags := newFlagSet("check-db")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.load()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := errors.Join(checkVerbsDB(ctx, cfg.VerbsDBPath), checkAppDB(ctx, cfg.AppDBPath)); err != nil {
+		return fmt.Errorf("%s: %w", errCheckFailed, err)
+	}
+	return nil
+}
+
+// checkVerbsDB checks the checksum and schema of verbs.db, as the bot does at startup, and reports integrity problems
+// and whether /search can use its index.
+func checkVerbsDB(ctx context.Context, path string) (err error) {
+	if path == "" {
+		fmt.Printf(msgVerbsDB, embeddedVerbsDB)
+	} else {
+		fmt.Printf(msgVerbsDB, path)
+	}
+
+	lc := u.NewLifecycle(shutdownTimeout)
+	defer func() { err = errors.Join(err, lc.Stop(ctx)) }()
+	if err := openVerbsDB(ctx, lc, path); err != nil {
+		return err
+	}
+
+	sqlDB, err := db.GetDB()
+	if err != nil {
+		return err
+	}
+	q := db.New(sqlDB)
+	problems, err := db.ValidateIntegrity(ctx, q)
+	if err != nil {
+		return err
+	}
+	infinitives, err := q.ListInfinitives(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(msgVerbsDBOK, len(infinitives))
+
+	// Gaps such as the missing forms of impersonal verbs are answered as not found rather than breaking the bot, so
+	// they are reported without failing the check.
+	if len(problems) > 0 {
+		fmt.Printf(msgIntegrity, len(problems))
+	}
+
+	// An index /search cannot use disables the command rather than the bot, so it is reported without failing.
+	if err := db.CheckSearchIndex(ctx, sqlDB); err != nil {
+		fmt.Printf(msgSearchIndex, err)
+	} else {
+		fmt.Printf(msgSearchIndex, searchIndexReady)
+	}
+	return nil
+}
+
+// checkAppDB reports how far the app database is migrated, without creating or migrating it.
+func checkAppDB(ctx context.Context, path string) error {
+	fmt.Printf(msgAppDB, path)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		fmt.Println(msgAppDBMissing)
+		return nil
+	}
+
+	sqlDB, err := sql.Open("sqlite3", db.ReadOnlyDSN(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", errAppDBInit, err)
+	}
+	defer sqlDB.Close()
+
+	version, err := db.SchemaVersion(ctx, sqlDB)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errAppDBInit, err)
+	}
+	migrations, err := store.Migrations()
+	if err != nil {
+		return err
+	}
+	latest := 0
+	for _, m := range migrations {
+		latest = max(latest, m.Version)
+	}
+	fmt.Printf(msgAppDBVersion, version, latest)
+	if version < latest {
+		return fmt.Errorf(errAppDBPending, version, latest-version)
+	}
+	return nil
+}
@@ -1,71 +1,450 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/felipeantoniob/conjugador-bot/internal/config"
 	"github.com/felipeantoniob/conjugador-bot/internal/db"
 	"github.com/felipeantoniob/conjugador-bot/internal/discord"
 	"github.com/felipeantoniob/conjugador-bot/internal/env"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+	"github.com/felipeantoniob/conjugador-bot/internal/tenseinfo"
 	u "github.com/felipeantoniob/conjugador-bot/internal/utils"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 const (
+	binaryName = "conjugador-bot"
+
+	errUnknownCommand   = "unknown command %q"
 	errEnvLoad          = "error loading env variables"
 	errBotInit          = "Error initializing bot"
-	errDiscordWSOpen    = "Error opening websocket connection to Discord"
 	errRegisterCommands = "failed to register commands"
 	errDBInit           = "failed to initialize database"
-	errDBClose          = "error closing database: %v"
-	errRetrieveEnvVars  = "failed to retrieve environment variables"
+	errDBResolve        = "failed to locate verb database"
+	errDBSchema         = "verb database does not match the expected schema"
+	errDBCleanup        = "error removing temporary verb database"
+	errDBClose          = "error closing database"
+	errAppDBInit        = "failed to initialize app database"
+	errAppDBClose       = "error closing app database"
+	errLogger           = "failed to configure logging"
+	errMetricsServer    = "metrics server failed"
+	errInFlight         = "interactions still in flight at shutdown"
+	errVerbRepository   = "failed to load verb data"
+	errInfinitiveIndex  = "failed to index infinitives"
+	errTenseGuides      = "invalid tense guides"
+	errSearchDisabled   = "/search is disabled"
+	errUnknownVerbStore = "unknown verb store %q, expected memory or sqlite"
 
 	msgBotRunning = "Bot is now running. Press CTRL-C to exit."
+
+	msgConfig     = "loaded configuration"
+	msgCacheStats = "verb cache stats"
+	msgMetrics    = "serving metrics and health checks"
+
+	// shutdownTimeout bounds the whole shutdown; a second signal cuts it short.
+	shutdownTimeout = 15 * time.Second
+	// inFlightTimeout bounds how long shutdown waits for interactions in flight, which have their own deadline.
+	inFlightTimeout = 5 * time.Second
+	// metricsShutdownTimeout bounds how long in-flight scrapes may delay shutdown.
+	metricsShutdownTimeout = 5 * time.Second
 )
 
+// command is a subcommand of the bot binary.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"run":                 {"Connect to Discord and answer commands (the default)", runBot},
+	"api":                 {"Serve the verb data as a JSON HTTP API instead of connecting to Discord", runAPI},
+	"telegram":            {"Answer a Telegram bot by long polling or on a webhook instead of connecting to Discord", runTelegram},
+	"matrix":              {"Answer commands sent to a Matrix user in the rooms it is in instead of connecting to Discord", runMatrix},
+	"register-commands":   {"Create or update the slash commands in the configured guilds", runRegisterCommands},
+	"unregister-commands": {"Delete every slash command of the bot in the configured guilds", runUnregisterCommands},
+	"check-db":            {"Check the verb and app databases and exit", runCheckDB},
+	"version":             {"Print the version of the binary", runVersion},
+}
+
 func main() {
-	if err := run(); err != nil {
-		log.Fatalf("%v", err)
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// run runs the subcommand named by the first argument. Without one, or when the arguments start with a flag, the bot
+// runs, as it did before it had subcommands.
+func run(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "--help" {
+		return runBot(args)
+	}
+	if args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage()
+		return nil
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage()
+		return fmt.Errorf(errUnknownCommand, args[0])
+	}
+	return cmd.run(args[1:])
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [command] [flags]\n\nCommands:\n", binaryName)
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", binaryName)
+}
+
+// botFlags are the flags every subcommand that reads the configuration shares.
+type botFlags struct {
+	fs      *flag.FlagSet
+	config  *config.Flags
+	envFile *string
+}
+
+// newFlagSet creates the flag set of a subcommand with the configuration flags and -env-file.
+func newFlagSet(name string) *botFlags {
+	fs := flag.NewFlagSet(binaryName+" "+name, flag.ContinueOnError)
+	return &botFlags{
+		fs:      fs,
+		config:  config.RegisterFlags(fs),
+		envFile: fs.String("env-file", env.DefaultEnvFile, "file of environment variables to load instead of .env and .env.local; must exist when given"),
+	}
+}
+
+// load loads the env file and the configuration, validated against require, and installs the configured logger.
+func (f *botFlags) load(require ...config.Requirement) (config.Config, error) {
+	if err := f.loadEnvFile(); err != nil {
+		return config.Config{}, err
+	}
+
+	cfg, err := config.Load(f.config, os.LookupEnv, require...)
+	if err != nil {
+		return cfg, err
 	}
+
+	logger, err := logging.New(os.Stderr, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", errLogger, err)
+	}
+	slog.SetDefault(logger)
+	slog.Debug(msgConfig, "config", cfg)
+	return cfg, nil
 }
 
-func run() error {
-	if err := env.LoadEnv(&env.GodotenvLoader{}); err != nil {
+func (f *botFlags) loadEnvFile() error {
+	given := false
+	f.fs.Visit(func(fl *flag.Flag) { given = given || fl.Name == "env-file" })
+	if !given {
+		// .env, then the .env.local older setups use.
+		if err := env.LoadEnv(&env.GodotenvLoader{}); err != nil {
+			return fmt.Errorf("%s: %w", errEnvLoad, err)
+		}
+		return nil
+	}
+	if _, err := os.Stat(*f.envFile); err != nil {
 		return fmt.Errorf("%s: %w", errEnvLoad, err)
 	}
+	if err := env.LoadEnvFromFile(*f.envFile, &env.GodotenvLoader{}); err != nil {
+		return fmt.Errorf("%s: %w", errEnvLoad, err)
+	}
+	return nil
+}
+
+func runBot(args []string) error {
+	flags := newFlagSet("run")
+	printConfig := flags.fs.Bool("print-config", false, "print the configuration, with secrets redacted, and exit")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *printConfig {
+		if err := flags.loadEnvFile(); err != nil {
+			return err
+		}
+		cfg, err := config.Load(flags.config, os.LookupEnv, config.Discord)
+		fmt.Print(cfg)
+		return err
+	}
 
-	botToken, guildID, err := env.GetRequiredEnvVars()
+	cfg, err := flags.load(config.Discord)
 	if err != nil {
-		return fmt.Errorf("%s: %w", errRetrieveEnvVars, err)
+		return err
 	}
 
-	if err := db.InitDB("sqlite3", "./internal/db/verbs.db"); err != nil {
-		return fmt.Errorf("%s: %w", errDBInit, err)
+	// ctx is cancelled when shutdown begins, which cancels the work of interactions in flight.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := notifyShutdown()
+	lc := u.NewLifecycle(shutdownTimeout)
+	if err := setup(ctx, cancel, lc, cfg); err != nil {
+		return errors.Join(err, lc.Stop(context.Background()))
+	}
+	if err := lc.Start(ctx); err != nil {
+		return err
+	}
+
+	slog.Info(msgBotRunning)
+	return lc.Run(ctx, sigCh)
+}
+
+// notifyShutdown catches SIGINT and SIGTERM from now on, for the channel to be passed to Lifecycle.Run. Called before a
+// mode sets up, it keeps a signal during startup from killing the process before the hooks registered so far, such as
+// removing the temporary verbs.db, have run: lc.Run stops them as soon as startup is done.
+func notifyShutdown() chan os.Signal {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	return sigCh
+}
+
+// setup opens every component of the bot, registering each with lc as soon as it exists so that it is stopped, in
+// reverse order, both at shutdown and when a later step fails. cancel cancels ctx, the context interactions derive from.
+func setup(ctx context.Context, cancel context.CancelFunc, lc *u.Lifecycle, cfg config.Config) error {
+	if err := openVerbsDB(ctx, lc, cfg.VerbsDBPath); err != nil {
+		return err
+	}
+
+	verbs, err := newVerbRepository(ctx, cfg.VerbStore, cfg.VerbCacheSize)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errVerbRepository, err)
+	}
+	if cached, ok := verbs.(*db.CachedRepository); ok {
+		lc.Append(u.Hook{Name: "verb cache", OnStop: func(ctx context.Context) error {
+			logCacheStats(cached)
+			return nil
+		}})
 	}
-	defer closeDatabase()
 
-	session, err := discord.CreateSession(&discord.DefaultSessionFactory{}, botToken)
+	infinitives, err := loadInfinitiveIndex(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errInfinitiveIndex, err)
+	}
+
+	guides, err := loadTenseGuides(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errTenseGuides, err)
+	}
+
+	appDB, err := store.Open(ctx, cfg.AppDBPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errAppDBInit, err)
+	}
+	lc.Append(u.Hook{Name: "app database", OnStop: func(ctx context.Context) error {
+		if err := appDB.Close(); err != nil {
+			return fmt.Errorf("%s: %w", errAppDBClose, err)
+		}
+		return nil
+	}})
+
+	session, err := discord.CreateSession(&discord.DefaultSessionFactory{}, cfg.BotToken)
 	if err != nil {
 		return fmt.Errorf("%s: %w", errBotInit, err)
 	}
-	defer discord.CloseSession(session)
+	lc.Append(u.Hook{Name: "Discord session", OnStop: func(ctx context.Context) error {
+		return session.Close()
+	}})
+
+	gateway := discord.TrackGateway(session)
+	if cfg.MetricsAddr != "" {
+		lc.Append(metricsServerHook(cfg.MetricsAddr, appDB, gateway))
+	}
 
-	if err := discord.SetupCommands(session, guildID, discord.CommandRegistry); err != nil {
+	handlers := discord.NewHandlers(metrics.NewRepository(verbs), infinitives, discord.HandlerOptions{
+		Search:   newSearcher(ctx),
+		Practice: store.NewPracticeStore(appDB),
+		Lists:    store.NewListStore(appDB),
+		Guides:   guides,
+	})
+	commands := discord.NewCommandRegistry(handlers)
+	router := discord.NewRouter(ctx, commands, discord.NewComponentRegistry(handlers))
+	if err := discord.SetupCommands(session, cfg.GuildIDs, commands, router); err != nil {
 		return fmt.Errorf("%s: %w", errRegisterCommands, err)
 	}
+	// Stopped first: interactions in flight are cancelled and get to send their responses before the session and
+	// databases close.
+	lc.Append(u.Hook{Name: "interactions", Timeout: inFlightTimeout, OnStop: func(ctx context.Context) error {
+		cancel()
+		if err := router.Wait(ctx); err != nil {
+			return fmt.Errorf("%s: %w", errInFlight, err)
+		}
+		return nil
+	}})
 
-	fmt.Println(msgBotRunning)
+	return nil
+}
 
-	sigCh := make(chan os.Signal, 1)
-	sig := u.WaitForShutdown(sigCh)
-	fmt.Printf("Received shutdown signal: %v\n", sig)
+// openVerbsDB opens the verbs.db at path, or the embedded copy when path is empty, checks its schema and registers
+// closing it with lc.
+func openVerbsDB(ctx context.Context, lc *u.Lifecycle, path string) error {
+	verbsDBFile, removeVerbsDBFile, err := db.ResolveVerbsDB(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errDBResolve, err)
+	}
+	lc.Append(u.Hook{Name: "temporary verbs.db", OnStop: func(ctx context.Context) error {
+		if err := removeVerbsDBFile(); err != nil {
+			return fmt.Errorf("%s: %w", errDBCleanup, err)
+		}
+		return nil
+	}})
 
+	if err := db.InitDB("sqlite3", db.ReadOnlyDSN(verbsDBFile)); err != nil {
+		return fmt.Errorf("%s: %w", errDBInit, err)
+	}
+	lc.Append(u.Hook{Name: "verbs.db", OnStop: func(ctx context.Context) error {
+		if err := db.CloseDB(); err != nil {
+			return fmt.Errorf("%s: %w", errDBClose, err)
+		}
+		return nil
+	}})
+
+	if err := verifyDatabaseSchema(ctx); err != nil {
+		return fmt.Errorf("%s: %w", errDBSchema, err)
+	}
 	return nil
 }
 
-func closeDatabase() {
-	if err := db.CloseDB(); err != nil {
-		log.Printf(errDBClose, err)
+// newVerbRepository returns the verb repository selected by kind. The memory repository loads all of verbs.db at
+// startup; the sqlite repository queries it on lookups that miss its cache of the cacheSize most recently used tables.
+func newVerbRepository(ctx context.Context, kind string, cacheSize int) (db.VerbRepository, error) {
+	sqlDB, err := db.GetDB()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case config.VerbStoreMemory:
+		return db.LoadMemoryRepository(ctx, db.New(sqlDB))
+	case config.VerbStoreSQLite:
+		if cacheSize <= 0 {
+			return db.NewSQLiteRepository(sqlDB), nil
+		}
+		return db.NewCachedRepository(db.NewSQLiteRepository(sqlDB), cacheSize), nil
+	default:
+		return nil, fmt.Errorf(errUnknownVerbStore, kind)
+	}
+}
+
+func loadInfinitiveIndex(ctx context.Context) (*db.InfinitiveIndex, error) {
+	sqlDB, err := db.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	return db.LoadInfinitiveIndex(ctx, db.New(sqlDB))
+}
+
+// loadTenseGuides loads the embedded tense guides, naming each tense in English as verbs.db does. It fails when a tense
+// choice is a mood and tense verbs.db does not conjugate.
+func loadTenseGuides(ctx context.Context) (*tenseinfo.Library, error) {
+	sqlDB, err := db.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.ListMoodTenses(ctx, sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	moodTenses := make(map[spanish.TenseMood]string, len(rows))
+	for mt, english := range rows {
+		moodTenses[spanish.TenseMood{Mood: mt.Mood, Tense: mt.Tense}] = english
+	}
+	return tenseinfo.Load(spanish.TenseMoodChoices, moodTenses)
+}
+
+// newSearcher returns the full-text searcher over verbs.db, or nil when its search index cannot be used.
+func newSearcher(ctx context.Context) db.Searcher {
+	sqlDB, err := db.GetDB()
+	if err != nil {
+		slog.Warn(errSearchDisabled, "error", err)
+		return nil
+	}
+	if err := db.CheckSearchIndex(ctx, sqlDB); err != nil {
+		slog.Warn(errSearchDisabled, "error", err)
+		return nil
 	}
+	return db.NewFTSSearcher(sqlDB)
+}
+
+func verifyDatabaseSchema(ctx context.Context) error {
+	sqlDB, err := db.GetDB()
+	if err != nil {
+		return err
+	}
+	return db.VerifySchema(ctx, sqlDB)
+}
+
+// metricsServerHook serves /metrics, /healthz and /readyz on addr in the background once started. The health checks
+// ping both databases; readiness also requires the gateway session to be connected.
+func metricsServerHook(addr string, appDB *sql.DB, gateway *discord.GatewayState) u.Hook {
+	health := []metrics.Check{
+		{Name: "verbs_db", Check: func(ctx context.Context) error {
+			sqlDB, err := db.GetDB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		{Name: "app_db", Check: appDB.PingContext},
+	}
+	ready := []metrics.Check{{Name: "gateway", Check: gateway.Check}}
+	server := metrics.NewServer(addr, health, ready)
+
+	return u.Hook{
+		Name:    "metrics server",
+		Timeout: metricsShutdownTimeout,
+		OnStart: func(ctx context.Context) error {
+			// Listening before returning surfaces an address already in use as a startup error.
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error(errMetricsServer, "error", err)
+				}
+			}()
+			slog.Info(msgMetrics, "addr", addr)
+			return nil
+		},
+		OnStop: server.Shutdown,
+	}
+}
+
+// logCacheStats reports how well the verb cache did.
+func logCacheStats(cached *db.CachedRepository) {
+	stats := cached.Stats()
+	slog.Info(msgCacheStats,
+		slog.Group("table", "hits", stats.Verb.Hits, "misses", stats.Verb.Misses),
+		slog.Group("full_table", "hits", stats.Verbs.Hits, "misses", stats.Verbs.Misses),
+	)
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/config"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/matrix"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+	u "github.com/felipeantoniob/conjugador-bot/internal/utils"
+)
+
+const (
+	errMatrixAuth    = "failed to reach the Matrix homeserver"
+	errMatrixReceive = "stopped answering Matrix messages"
+
+	msgMatrixSyncing = "answering Matrix messages"
+)
+
+// runMatrix answers the commands sent to a Matrix user, in the rooms it is in, from the same verb data as the Discord
+// bot.
+func runMatrix(args []string) error {
+	flags := newFlagSet("matrix")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.load(config.Matrix)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	sigCh := notifyShutdown()
+	lc := u.NewLifecycle(shutdownTimeout)
+	if err := openVerbsDB(ctx, lc, cfg.VerbsDBPath); err != nil {
+		return errors.Join(err, lc.Stop(ctx))
+	}
+	verbs, err := newVerbRepository(ctx, cfg.VerbStore, cfg.VerbCacheSize)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errVerbRepository, err), lc.Stop(ctx))
+	}
+	infinitives, err := loadInfinitiveIndex(ctx)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errInfinitiveIndex, err), lc.Stop(ctx))
+	}
+
+	client := matrix.NewClient(cfg.MatrixHomeserver, cfg.MatrixAccessToken)
+	userID, err := client.Whoami(ctx)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errMatrixAuth, err), lc.Stop(ctx))
+	}
+	service := core.NewService(metrics.NewRepository(verbs), infinitives)
+	bot := core.NewChatBot(service, matrix.NewAdapter(client, cfg.MatrixCommandPrefix))
+	lc.Append(matrixHook(bot, userID))
+	return lc.Run(ctx, sigCh)
+}
+
+// matrixHook runs bot once started. Stopping it stops receiving and waits for the message being answered.
+func matrixHook(bot *core.ChatBot, userID string) u.Hook {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	return u.Hook{
+		Name:    "Matrix sync",
+		Timeout: inFlightTimeout,
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				if err := bot.Run(ctx); err != nil {
+					slog.Error(errMatrixReceive, "error", err)
+				}
+			}()
+			slog.Info(msgMatrixSyncing, "user_id", userID)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	}
+}
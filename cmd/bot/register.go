@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/config"
+	"github.com/felipeantoniob/conjugador-bot/internal/discord"
+	u "github.com/felipeantoniob/conjugador-bot/internal/utils"
+)
+
+const (
+	msgWouldRegister   = "would register %s in guild %s\n"
+	msgRegistered      = "registered %d commands in %d guilds\n"
+	msgWouldUnregister = "would delete %s (%s) from guild %s\n"
+	msgUnregistered    = "deleted %s (%s) from guild %s\n"
+)
+
+func runRegisterCommands(args []string) error {
+	flags := newFlagSet("register-commands")
+	dryRun := flags.fs.Bool("dry-run", false, "print the commands that would be registered without contacting Discord")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.load(config.Discord)
+	if err != nil {
+		return err
+	}
+
+	// /search is only registered when verbs.db has a search index, as at startup, and the tense guides are checked as
+	// they are there.
+	ctx := context.Background()
+	lc := u.NewLifecycle(shutdownTimeout)
+	if err := openVerbsDB(ctx, lc, cfg.VerbsDBPath); err != nil {
+		return errors.Join(err, lc.Stop(ctx))
+	}
+	guides, err := loadTenseGuides(ctx)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errTenseGuides, err), lc.Stop(ctx))
+	}
+	commands := discord.NewCommandRegistry(discord.NewHandlers(nil, nil, discord.HandlerOptions{Search: newSearcher(ctx), Guides: guides}))
+	if err := lc.Stop(ctx); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		for _, guildID := range cfg.GuildIDs {
+			for _, m := range commands {
+				fmt.Printf(msgWouldRegister, commandLabel(m.Command), guildID)
+			}
+		}
+		return nil
+	}
+
+	registrar, appID, err := discord.NewCommandRegistrar(cfg.BotToken)
+	if err != nil {
+		return err
+	}
+	if err := discord.RegisterCommands(registrar, appID, cfg.GuildIDs, commands); err != nil {
+		return fmt.Errorf("%s: %w", errRegisterCommands, err)
+	}
+	fmt.Printf(msgRegistered, len(commands), len(cfg.GuildIDs))
+	return nil
+}
+
+func runUnregisterCommands(args []string) error {
+	flags := newFlagSet("unregister-commands")
+	dryRun := flags.fs.Bool("dry-run", false, "list the commands that would be deleted without deleting them")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.load(config.Discord)
+	if err != nil {
+		return err
+	}
+
+	registrar, appID, err := discord.NewCommandRegistrar(cfg.BotToken)
+	if err != nil {
+		return err
+	}
+	removed, err := discord.UnregisterCommands(registrar, appID, cfg.GuildIDs, *dryRun)
+	format := msgUnregistered
+	if *dryRun {
+		format = msgWouldUnregister
+	}
+	for _, c := range removed {
+		fmt.Printf(format, commandLabel(c.Command), c.Command.ID, c.GuildID)
+	}
+	return err
+}
+
+// commandLabel names cmd as users find it: "/conjugate" for a slash command, "Apps → Analyze verbs" for a message or
+// user command.
+func commandLabel(cmd *discordgo.ApplicationCommand) string {
+	if cmd.Type == discordgo.MessageApplicationCommand || cmd.Type == discordgo.UserApplicationCommand {
+		return "Apps → " + cmd.Name
+	}
+	return "/" + cmd.Name
+}
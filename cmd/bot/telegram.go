@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/config"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+	"github.com/felipeantoniob/conjugador-bot/internal/telegram"
+	u "github.com/felipeantoniob/conjugador-bot/internal/utils"
+)
+
+const (
+	errTelegramAuth     = "failed to reach the Telegram bot"
+	errTelegramCommands = "failed to set the Telegram bot commands"
+	errTelegramWebhook  = "webhook server failed"
+	errTelegramInFlight = "Telegram updates still in flight at shutdown"
+
+	msgTelegramPolling = "answering Telegram updates by long polling"
+	msgTelegramWebhook = "answering Telegram updates on a webhook"
+)
+
+// runTelegram answers the updates of a Telegram bot from the same verb data as the Discord bot, by long polling or, when
+// TELEGRAM_WEBHOOK_URL is set, on a webhook.
+func runTelegram(args []string) error {
+	flags := newFlagSet("telegram")
+	if err := flags.fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := flags.load(config.Telegram)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	sigCh := notifyShutdown()
+	lc := u.NewLifecycle(shutdownTimeout)
+	if err := openVerbsDB(ctx, lc, cfg.VerbsDBPath); err != nil {
+		return errors.Join(err, lc.Stop(ctx))
+	}
+	verbs, err := newVerbRepository(ctx, cfg.VerbStore, cfg.VerbCacheSize)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errVerbRepository, err), lc.Stop(ctx))
+	}
+	infinitives, err := loadInfinitiveIndex(ctx)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errInfinitiveIndex, err), lc.Stop(ctx))
+	}
+
+	client := telegram.NewClient(cfg.TelegramAPIURL, cfg.TelegramToken)
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", errTelegramAuth, err), lc.Stop(ctx))
+	}
+	if err := client.SetMyCommands(ctx, telegram.Commands); err != nil {
+		slog.Warn(errTelegramCommands, "error", err)
+	}
+	bot := telegram.NewBot(client, metrics.NewRepository(verbs), infinitives, me.Username)
+
+	if cfg.TelegramWebhookURL == "" {
+		lc.Append(pollingHook(client, bot))
+	} else {
+		lc.Append(webhookHook(client, bot, cfg))
+	}
+	return lc.Run(ctx, sigCh)
+}
+
+// pollingHook polls for updates once started. Stopping it stops polling and waits for the updates being answered.
+func pollingHook(client *telegram.Client, bot *telegram.Bot) u.Hook {
+	poller := telegram.NewPoller(client, bot)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	return u.Hook{
+		Name:    "Telegram polling",
+		Timeout: inFlightTimeout,
+		OnStart: func(startCtx context.Context) error {
+			// getUpdates fails while a webhook is set, e.g. by an earlier run in webhook mode.
+			if err := client.DeleteWebhook(startCtx); err != nil {
+				return err
+			}
+			go func() {
+				poller.Run(ctx)
+				close(done)
+			}()
+			slog.Info(msgTelegramPolling)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+			if err := poller.Wait(stopCtx); err != nil {
+				return fmt.Errorf("%s: %w", errTelegramInFlight, err)
+			}
+			return nil
+		},
+	}
+}
+
+// webhookHook serves the webhook on TELEGRAM_WEBHOOK_ADDR, at the path of TELEGRAM_WEBHOOK_URL, and points Telegram at
+// it once started. The webhook stays set when stopped, so updates queue up until the next start.
+func webhookHook(client *telegram.Client, bot *telegram.Bot, cfg config.Config) u.Hook {
+	path := "/"
+	if webhookURL, err := url.Parse(cfg.TelegramWebhookURL); err == nil && webhookURL.Path != "" {
+		path = webhookURL.Path
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, telegram.WebhookHandler(bot, cfg.TelegramWebhookSecret))
+	server := &http.Server{Addr: cfg.TelegramWebhookAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	return u.Hook{
+		Name:    "Telegram webhook",
+		Timeout: inFlightTimeout,
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", cfg.TelegramWebhookAddr)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error(errTelegramWebhook, "error", err)
+				}
+			}()
+			err = client.SetWebhook(ctx, telegram.SetWebhookParams{URL: cfg.TelegramWebhookURL, SecretToken: cfg.TelegramWebhookSecret})
+			if err != nil {
+				return errors.Join(err, server.Close())
+			}
+			slog.Info(msgTelegramWebhook, "addr", cfg.TelegramWebhookAddr, "path", path)
+			return nil
+		},
+		// Shutdown waits for the requests in flight, so updates being answered are answered.
+		OnStop: server.Shutdown,
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+func runVersion(args []string) error {
+	fs := newFlagSet("version").fs
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	revision, goVersion := "unknown", "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		goVersion = info.GoVersion
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				revision = s.Value
+			}
+		}
+	}
+	fmt.Printf("%s %s (revision %s, %s)\n", binaryName, version, revision, goVersion)
+	return nil
+}
@@ -0,0 +1,199 @@
+// Command conjugar prints conjugation tables from verbs.db in the terminal and quizzes conjugations in an interactive
+// practice mode, without Discord or a network connection.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	errUsage         = "expected one infinitive"
+	errUnknownTense  = "unknown tense %q; run 'conjugar -tenses' for the list"
+	errAmbiguousVerb = "%q could be %s"
+	errVerbNotFound  = "no verb %q in verbs.db"
+	errOutputFormat  = "-json and -csv cannot be combined"
+	errDBResolve     = "failed to locate verb database"
+	errDBInit        = "failed to initialize database"
+	errDBSchema      = "verb database does not match the expected schema"
+	errLookup        = "lookup failed"
+
+	// verbsDBEnv names the environment variable the bot also reads verbs.db's path from.
+	verbsDBEnv = "VERBS_DB_PATH"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "conjugar: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) > 0 && args[0] == "practice" {
+		return runPractice(args[1:])
+	}
+	return runLookup(args)
+}
+
+// tenseFlag collects the tenses given by repeated -tense flags, by their names in spanish.TenseMoodChoices.
+type tenseFlag []spanish.TenseMood
+
+func (f *tenseFlag) String() string {
+	names := make([]string, len(*f))
+	for i, tm := range *f {
+		names[i] = spanish.TenseMoodName(tm)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (f *tenseFlag) Set(name string) error {
+	tm, ok := spanish.LookupTenseMood(name)
+	if !ok {
+		return fmt.Errorf(errUnknownTense, name)
+	}
+	*f = append(*f, tm)
+	return nil
+}
+
+// newFlagSet creates the flag set of a mode with the shared -verbs-db and -tense flags.
+func newFlagSet(name string, tenses *tenseFlag) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	verbsDB := fs.String("verbs-db", os.Getenv(verbsDBEnv), "path to an external verbs.db; the embedded copy is used when empty")
+	fs.Var(tenses, "tense", "tense to show, by its /conjugate name such as \"Present subjunctive\"; repeat for several (default all)")
+	return fs, verbsDB
+}
+
+func runLookup(args []string) error {
+	var tenses tenseFlag
+	fs, verbsDB := newFlagSet("conjugar", &tenses)
+	asJSON := fs.Bool("json", false, "print the conjugations as JSON")
+	asCSV := fs.Bool("csv", false, "print the conjugations as CSV")
+	noColor := fs.Bool("no-color", false, "do not highlight irregular forms; also disabled by NO_COLOR or when not writing to a terminal")
+	listTenses := fs.Bool("tenses", false, "list the tense names and exit")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  conjugar [flags] <infinitive>\n  conjugar practice [flags]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *listTenses {
+		for _, choice := range spanish.TenseMoodChoices {
+			fmt.Printf("%s\t%s %s\n", choice.Name, choice.Value.Mood, choice.Value.Tense)
+		}
+		return nil
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New(errUsage)
+	}
+	if *asJSON && *asCSV {
+		return errors.New(errOutputFormat)
+	}
+
+	ctx := context.Background()
+	verbs, closeDB, err := openVerbsDB(ctx, *verbsDB)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	table, err := lookup(ctx, verbs.service, fs.Arg(0), tenses)
+	if err != nil {
+		return err
+	}
+	switch {
+	case *asJSON:
+		return writeJSON(os.Stdout, table)
+	case *asCSV:
+		return writeCSV(os.Stdout, table)
+	default:
+		return writeTable(os.Stdout, table, !*noColor && colorSupported(os.Stdout))
+	}
+}
+
+// verbSource is what both modes look verbs up in.
+type verbSource struct {
+	repo    db.VerbRepository
+	service *core.Service
+	all     []string
+}
+
+// lookupError explains a failed lookup of the verb the user typed as input.
+func lookupError(input string, err error) error {
+	var ambiguous *db.AmbiguousInfinitiveError
+	switch {
+	case errors.As(err, &ambiguous):
+		return fmt.Errorf(errAmbiguousVerb, input, strings.Join(ambiguous.Candidates, " or "))
+	case errors.Is(err, core.ErrUnknownVerb):
+		return fmt.Errorf(errVerbNotFound, input)
+	}
+	return fmt.Errorf("%s: %w", errLookup, err)
+}
+
+// openVerbsDB opens the verbs.db at path, or the embedded copy when path is empty, and checks its schema. The returned
+// function closes it.
+func openVerbsDB(ctx context.Context, path string) (verbSource, func(), error) {
+	file, remove, err := db.ResolveVerbsDB(path)
+	if err != nil {
+		return verbSource{}, nil, fmt.Errorf("%s: %w", errDBResolve, err)
+	}
+	if err := db.InitDB("sqlite3", db.ReadOnlyDSN(file)); err != nil {
+		remove()
+		return verbSource{}, nil, fmt.Errorf("%s: %w", errDBInit, err)
+	}
+	closeDB := func() {
+		db.CloseDB()
+		remove()
+	}
+
+	sqlDB, err := db.GetDB()
+	if err == nil {
+		err = db.VerifySchema(ctx, sqlDB)
+	}
+	if err != nil {
+		closeDB()
+		return verbSource{}, nil, fmt.Errorf("%s: %w", errDBSchema, err)
+	}
+
+	q := db.New(sqlDB)
+	rows, err := q.ListInfinitives(ctx)
+	if err != nil {
+		closeDB()
+		return verbSource{}, nil, fmt.Errorf("%s: %w", errDBInit, err)
+	}
+	levels, err := db.ListVerbLevels(ctx, sqlDB)
+	if err != nil {
+		closeDB()
+		return verbSource{}, nil, fmt.Errorf("%s: %w", errDBInit, err)
+	}
+	repo := db.NewSQLiteRepository(sqlDB)
+	index := db.NewInfinitiveIndex(rows).WithLevels(levels)
+	source := verbSource{repo: repo, service: core.NewService(repo, index)}
+	for _, row := range rows {
+		source.all = append(source.all, row.Infinitive)
+	}
+	return source, closeDB, nil
+}
+
+// colorSupported reports whether f is a terminal and NO_COLOR, https://no-color.org, is not set.
+func colorSupported(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+const (
+	errNoUser  = "-app-db needs -user, the Discord user ID to record the answers for"
+	errNoAppDB = "-list needs -app-db, the bot's app database the list is in"
+	errAppDB   = "failed to open app database"
+	errNoList  = "no verb list %q; give its share code, or the name of one of your lists"
+	errList    = "failed to read verb list"
+	errLevel   = "unknown level %q; use one of %s"
+	errNoLevel = "none of the selected verbs is ranked at level %s or easier"
+	errRecord  = "failed to record answer"
+
+	cmdQuit = "quit"
+	cmdSkip = "skip"
+
+	msgPracticeIntro = "Type the form asked for. An empty line or 'skip' shows the answer, 'quit' or Ctrl-D ends the session.\n"
+	msgPrompt        = "\n%s · %s · %s\n> "
+	msgCorrect       = "Correct!\n"
+	msgAccents       = "Almost: mind the accents. It is %s.\n"
+	msgWrong         = "No, it is %s.\n"
+	msgAnswer        = "It is %s.\n"
+	msgScore         = "\n%d of %d correct.\n"
+)
+
+func runPractice(args []string) error {
+	var tenses tenseFlag
+	fs, verbsDB := newFlagSet("conjugar practice", &tenses)
+	var verbList verbFlag
+	fs.Var(&verbList, "verb", "infinitive to practice; repeat for several (default all)")
+	rounds := fs.Int("rounds", 0, "number of questions to ask; 0 asks until you quit")
+	appDB := fs.String("app-db", "", "path to the bot's app database to record the answers in, for Study profile")
+	user := fs.String("user", "", "Discord user ID to record the answers for; needed with -app-db")
+	listRef := fs.String("list", "", "share code of a verb list, or name of one of your lists, to practice; needs -app-db")
+	level := fs.String("level", "", "practice only verbs of this CEFR level (A1 to C2) or an easier one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	maxLevel := strings.ToUpper(*level)
+	if maxLevel != "" && !slices.Contains(db.CEFRLevels, maxLevel) {
+		return fmt.Errorf(errLevel, *level, strings.Join(db.CEFRLevels, ", "))
+	}
+	if *appDB != "" && *user == "" {
+		return errors.New(errNoUser)
+	}
+	if *listRef != "" && *appDB == "" {
+		return errors.New(errNoAppDB)
+	}
+
+	ctx := context.Background()
+	verbs, closeDB, err := openVerbsDB(ctx, *verbsDB)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	var appSQL *sql.DB
+	if *appDB != "" {
+		appSQL, err = store.Open(ctx, *appDB)
+		if err != nil {
+			return fmt.Errorf("%s: %w", errAppDB, err)
+		}
+		defer appSQL.Close()
+	}
+
+	infinitives := verbs.all
+	if len(verbList) > 0 || *listRef != "" {
+		infinitives = nil
+		for _, input := range verbList {
+			infinitive, err := verbs.service.Resolve(input)
+			if err != nil {
+				return lookupError(input, err)
+			}
+			infinitives = append(infinitives, infinitive)
+		}
+	}
+	if *listRef != "" {
+		list, err := readList(ctx, store.NewListStore(appSQL), *user, *listRef)
+		if err != nil {
+			return err
+		}
+		infinitives = append(infinitives, list.Infinitives...)
+	}
+	if maxLevel != "" {
+		infinitives = verbs.service.UpToLevel(infinitives, maxLevel)
+		if len(infinitives) == 0 {
+			return fmt.Errorf(errNoLevel, maxLevel)
+		}
+	}
+	selected := []spanish.TenseMood(tenses)
+	if len(selected) == 0 {
+		for _, choice := range spanish.TenseMoodChoices {
+			selected = append(selected, choice.Value)
+		}
+	}
+
+	p := &practice{service: verbs.service, infinitives: infinitives, tenses: selected, rand: rand.IntN}
+	if appSQL != nil {
+		p.results, p.user = store.NewPracticeStore(appSQL), *user
+	}
+	return p.run(ctx, os.Stdin, os.Stdout, *rounds)
+}
+
+// readList finds the verb list named ref among the personal lists of user, or else the list with the share code ref.
+func readList(ctx context.Context, lists *store.ListStore, user, ref string) (store.VerbList, error) {
+	list, err := lists.Get(ctx, store.ListOwner{Scope: store.ScopeUser, ID: user}, ref)
+	if errors.Is(err, store.ErrListNotFound) {
+		list, err = lists.ByCode(ctx, ref)
+	}
+	if errors.Is(err, store.ErrListNotFound) {
+		return store.VerbList{}, fmt.Errorf(errNoList, ref)
+	}
+	if err != nil {
+		return store.VerbList{}, fmt.Errorf("%s: %w", errList, err)
+	}
+	return list, nil
+}
+
+// verbFlag collects the infinitives given by repeated -verb flags.
+type verbFlag []string
+
+func (f *verbFlag) String() string { return strings.Join(*f, ", ") }
+
+func (f *verbFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+// practice quizzes random forms of infinitives in tenses.
+type practice struct {
+	service     *core.Service
+	infinitives []string
+	tenses      []spanish.TenseMood
+	// rand returns a random number in [0, n).
+	rand func(n int) int
+	// results records every answer for user unless it is nil.
+	results resultRecorder
+	user    string
+}
+
+// resultRecorder records answered practice questions, as store.PracticeStore does.
+type resultRecorder interface {
+	Record(ctx context.Context, result store.PracticeResult) error
+}
+
+// run asks questions read from in and written to out until rounds questions were asked, the user quits or in ends,
+// then prints the score. rounds of 0 asks until the user quits.
+func (p *practice) run(ctx context.Context, in io.Reader, out io.Writer, rounds int) error {
+	fmt.Fprint(out, msgPracticeIntro)
+	scanner := bufio.NewScanner(in)
+	asked, correct := 0, 0
+	defer func() {
+		if asked > 0 {
+			fmt.Fprintf(out, msgScore, correct, asked)
+		}
+	}()
+
+	for rounds == 0 || asked < rounds {
+		q, err := p.service.Question(ctx, p.infinitives, p.tenses, p.rand)
+		if errors.Is(err, core.ErrNoQuestion) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", errLookup, err)
+		}
+		fmt.Fprintf(out, msgPrompt, q.Infinitive, spanish.TenseMoodName(q.Tense), q.Label)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		answer := spanish.Normalize(scanner.Text())
+		right := false
+		switch {
+		case answer == cmdQuit:
+			return nil
+		case answer == "" || answer == cmdSkip:
+			fmt.Fprintf(out, msgAnswer, q.Answer)
+		default:
+			switch q.Grade(answer) {
+			case core.GradeCorrect:
+				right = true
+				fmt.Fprint(out, msgCorrect)
+			case core.GradeAccents:
+				fmt.Fprintf(out, msgAccents, q.Answer)
+			default:
+				fmt.Fprintf(out, msgWrong, q.Answer)
+			}
+		}
+		asked++
+		if right {
+			correct++
+		}
+		if err := p.record(ctx, q, right); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record saves the answer to q in p.results, if set. A skipped question counts as a wrong answer, as in the score.
+func (p *practice) record(ctx context.Context, q core.Question, correct bool) error {
+	if p.results == nil {
+		return nil
+	}
+	err := p.results.Record(ctx, store.PracticeResult{
+		UserID:     p.user,
+		Infinitive: q.Infinitive,
+		Mood:       q.Tense.Mood,
+		Tense:      q.Tense.Tense,
+		Person:     q.Person,
+		Correct:    correct,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", errRecord, err)
+	}
+	return nil
+}
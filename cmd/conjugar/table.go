@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/api"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	colorIrregular = "\x1b[1;33m"
+	colorReset     = "\x1b[0m"
+
+	msgLegend = "Irregular forms are highlighted."
+)
+
+// conjugationTable is what a lookup prints: the conjugations of one infinitive, in the order of
+// spanish.TenseMoodChoices.
+type conjugationTable struct {
+	Infinitive     string         `json:"infinitive"`
+	Gerund         string         `json:"gerund,omitempty"`
+	PastParticiple string         `json:"past_participle,omitempty"`
+	Tenses         []tenseSection `json:"tenses"`
+}
+
+// tenseSection is one mood and tense of a conjugationTable. Irregular lists the persons, as db.Persons keys, whose form
+// differs from the one a regular verb would have.
+type tenseSection struct {
+	Name string `json:"name"`
+	api.Verb
+	Irregular []string `json:"irregular,omitempty"`
+}
+
+// forms returns the form of each person in db.Persons order.
+func (s tenseSection) forms() [6]string {
+	return [6]string{s.Form1s, s.Form2s, s.Form3s, s.Form1p, s.Form2p, s.Form3p}
+}
+
+func (s tenseSection) irregular(person string) bool {
+	for _, p := range s.Irregular {
+		if p == person {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup collects the conjugations of input in the selected tenses.
+func lookup(ctx context.Context, service *core.Service, input string, tenses tenseFlag) (conjugationTable, error) {
+	result, err := service.Table(ctx, input, tenses)
+	if err != nil {
+		return conjugationTable{}, lookupError(input, err)
+	}
+
+	table := conjugationTable{Infinitive: result.Infinitive, Gerund: result.Gerund, PastParticiple: result.PastParticiple}
+	for _, c := range result.Conjugations {
+		table.Tenses = append(table.Tenses, newTenseSection(c))
+	}
+	return table, nil
+}
+
+func newTenseSection(c core.ConjugationResult) tenseSection {
+	s := tenseSection{
+		Name: c.TenseName,
+		Verb: api.Verb{Infinitive: c.Infinitive, Mood: c.Mood, Tense: c.Tense, VerbEnglish: c.English},
+	}
+	forms := map[string]*string{
+		db.Person1s: &s.Form1s, db.Person2s: &s.Form2s, db.Person3s: &s.Form3s,
+		db.Person1p: &s.Form1p, db.Person2p: &s.Form2p, db.Person3p: &s.Form3p,
+	}
+	for _, f := range c.Forms {
+		*forms[f.Person] = f.Form
+		if f.Irregular {
+			s.Irregular = append(s.Irregular, f.Person)
+		}
+	}
+	return s
+}
+
+func writeJSON(w io.Writer, table conjugationTable) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(table)
+}
+
+// writeCSV writes one record per mood and tense, with the columns of the verbs table of verbs.db.
+func writeCSV(w io.Writer, table conjugationTable) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"infinitive", "mood", "tense", "verb_english", "form_1s", "form_2s", "form_3s", "form_1p", "form_2p", "form_3p"})
+	for _, s := range table.Tenses {
+		forms := s.forms()
+		out.Write(append([]string{s.Infinitive, s.Mood, s.Tense, s.VerbEnglish}, forms[:]...))
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// writeTable prints one block per tense with the persons and forms in aligned columns, highlighting irregular forms
+// when color is set.
+func writeTable(w io.Writer, table conjugationTable, color bool) error {
+	var b strings.Builder
+	b.WriteString(table.Infinitive + "\n")
+	if table.Gerund != "" {
+		fmt.Fprintf(&b, "  %-*s  %s\n", labelWidth, "gerund", table.Gerund)
+	}
+	if table.PastParticiple != "" {
+		fmt.Fprintf(&b, "  %-*s  %s\n", labelWidth, "past participle", table.PastParticiple)
+	}
+
+	for _, s := range table.Tenses {
+		b.WriteString("\n" + s.Name)
+		if s.Name != s.Mood+" "+s.Tense {
+			fmt.Fprintf(&b, " (%s %s)", s.Mood, s.Tense)
+		}
+		if s.VerbEnglish != "" {
+			fmt.Fprintf(&b, " – %s", s.VerbEnglish)
+		}
+		b.WriteByte('\n')
+
+		labels := core.PersonLabels(s.Mood)
+		for i, form := range s.forms() {
+			if labels[i] == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "  %-*s  ", labelWidth, labels[i])
+			if color && s.irregular(db.Persons[i]) {
+				form = colorIrregular + form + colorReset
+			}
+			b.WriteString(form + "\n")
+		}
+	}
+	if color {
+		b.WriteString("\n" + colorIrregular + msgLegend + colorReset + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelWidth is the width of the widest label, in characters as fmt counts them for padding.
+var labelWidth = func() int {
+	width := utf8.RuneCountInString("past participle")
+	for _, label := range core.PersonLabels("") {
+		width = max(width, utf8.RuneCountInString(label))
+	}
+	return width
+}()
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	checksumSuffix = ".sha256"
+
+	msgChecksumWritten = "Wrote %s\n"
+)
+
+func runChecksum(args []string) error {
+	fs, dbPath := newFlagSet("checksum")
+	write := fs.Bool("write", false, "write the checksum next to the database, where the bot checks the embedded copy against it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*dbPath)
+	if err != nil {
+		return err
+	}
+	sum := db.Checksum(data)
+
+	if !*write {
+		fmt.Println(sum)
+		return nil
+	}
+
+	path := *dbPath + checksumSuffix
+	if err := os.WriteFile(path, []byte(sum+"\n"), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf(msgChecksumWritten, path)
+	return nil
+}
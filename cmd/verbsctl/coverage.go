@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+func runCoverage(args []string) error {
+	fs, dbPath := newFlagSet("coverage")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sqlDB, err := openDB(*dbPath, false)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	c, err := db.ComputeCoverage(context.Background(), db.New(sqlDB))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "infinitives\t%d\t(%d with all %d tables)\n", c.Infinitives, c.CompleteInfinitives, len(db.ExpectedMoodTenses))
+	fmt.Fprintf(w, "verbs\t%d\n", c.Verbs)
+	fmt.Fprintf(w, "moods\t%d\n", c.Moods)
+	fmt.Fprintf(w, "tenses\t%d\n", c.Tenses)
+	fmt.Fprintf(w, "gerunds\t%d\n", c.Gerunds)
+	fmt.Fprintf(w, "past participles\t%d\n", c.Pastparticiples)
+	fmt.Fprintf(w, "examples\t%d\n", c.Examples)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "MOOD\tTENSE\tVERBS\tCOVERAGE\tEXAMPLES")
+	for _, mt := range c.ByMoodTense {
+		pct := 0.0
+		if c.Infinitives > 0 {
+			pct = 100 * float64(mt.Verbs) / float64(c.Infinitives)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.1f%%\t%d\n", mt.Mood, mt.Tense, mt.Verbs, pct, mt.Examples)
+	}
+	return w.Flush()
+}
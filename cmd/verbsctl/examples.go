@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	examplesUsage = "Usage: verbsctl examples import -file examples.tsv [-db verbs.db] [-dry-run]\n"
+
+	errExamplesCommand  = "expected 'examples import'"
+	errMissingExamples  = "no examples file given, use -file"
+	errOpenExamplesFile = "error opening examples file"
+	errParseExamples    = "examples file has invalid lines"
+	errInvalidExamples  = "examples failed validation"
+	errImportExamples   = "error importing examples"
+	msgExamplesChecked  = "%d examples validated.\n"
+	msgExamplesImported = "Imported %d new examples (%d already present).\n"
+)
+
+// runExamples runs the examples subcommands, of which there is only import for now.
+func runExamples(args []string) error {
+	if len(args) == 0 || args[0] != "import" {
+		fmt.Fprint(os.Stderr, examplesUsage)
+		return errors.New(errExamplesCommand)
+	}
+	return runExamplesImport(args[1:])
+}
+
+// runExamplesImport validates example sentences from a TSV file against the verbs table and imports them into the
+// examples table.
+func runExamplesImport(args []string) error {
+	fs, dbPath := newFlagSet("examples import")
+	filePath := fs.String("file", "", "TSV file with infinitive, mood, tense, person, sentence and English columns")
+	dryRun := fs.Bool("dry-run", false, "validate the file without importing it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *filePath == "" {
+		fs.Usage()
+		return errors.New(errMissingExamples)
+	}
+
+	f, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errOpenExamplesFile, err)
+	}
+	defer f.Close()
+
+	lines, err := db.ParseExamplesTSV(f)
+	if err != nil {
+		return fmt.Errorf("%s:\n%w", errParseExamples, err)
+	}
+
+	sqlDB, err := openDB(*dbPath, !*dryRun)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	if err := db.ValidateExamples(ctx, db.New(sqlDB), lines); err != nil {
+		return fmt.Errorf("%s:\n%w", errInvalidExamples, err)
+	}
+	fmt.Printf(msgExamplesChecked, len(lines))
+	if *dryRun {
+		return nil
+	}
+
+	added, err := db.ImportExamples(ctx, sqlDB, lines)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errImportExamples, err)
+	}
+	fmt.Printf(msgExamplesImported, added, len(lines)-added)
+	if added > 0 {
+		fmt.Println(msgUpdateChecksum)
+	}
+	return nil
+}
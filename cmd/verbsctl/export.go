@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	errUnknownFormat = "unknown format %q, expected csv or json"
+	errExport        = "error exporting %s"
+	msgExported      = "Wrote %d rows to %s\n"
+)
+
+func runExport(args []string) error {
+	fs, dbPath := newFlagSet("export")
+	format := fs.String("format", formatCSV, "output format: csv or json")
+	outDir := fs.String("out", ".", "directory to write one file per table into")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != formatCSV && *format != formatJSON {
+		return fmt.Errorf(errUnknownFormat, *format)
+	}
+
+	sqlDB, err := openDB(*dbPath, false)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	tables, err := db.ExportTables(context.Background(), db.New(sqlDB))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		path := filepath.Join(*outDir, table.Name+"."+*format)
+		if err := writeTable(path, *format, table); err != nil {
+			return fmt.Errorf(errExport+": %w", table.Name, err)
+		}
+		fmt.Printf(msgExported, len(table.Rows), path)
+	}
+	return nil
+}
+
+func writeTable(path, format string, table db.TableData) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch format {
+	case formatJSON:
+		err = writeTableJSON(f, table)
+	default:
+		err = writeTableCSV(f, table)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeTableCSV writes a header row followed by the table rows. NULL values are written as empty fields.
+func writeTableCSV(f *os.File, table db.TableData) error {
+	w := csv.NewWriter(f)
+	if err := w.Write(table.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i, value := range row {
+			record[i] = db.NullStringToString(value)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeTableJSON writes the table as an array of objects keyed by column name. NULL values are written as null.
+func writeTableJSON(f *os.File, table db.TableData) error {
+	objects := make([]map[string]*string, len(table.Rows))
+	for i, row := range table.Rows {
+		obj := make(map[string]*string, len(table.Columns))
+		for j, value := range row {
+			if value.Valid {
+				s := value.String
+				obj[table.Columns[j]] = &s
+			} else {
+				obj[table.Columns[j]] = nil
+			}
+		}
+		objects[i] = obj
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(objects)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	errMissingImportFile = "no CSV file given, use -file"
+	errOpenImportFile    = "error opening CSV file"
+	errImport            = "error importing CSV"
+	msgImportedRows      = "Imported %d rows for %d infinitives.\n"
+	msgUpdateChecksum    = "Run 'verbsctl checksum -write' to update the checksum of the embedded database."
+)
+
+func runImport(args []string) error {
+	fs, dbPath := newFlagSet("import")
+	filePath := fs.String("file", "", "CSV file in the jehle_verb_database format")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *filePath == "" {
+		fs.Usage()
+		return errors.New(errMissingImportFile)
+	}
+
+	f, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errOpenImportFile, err)
+	}
+	defer f.Close()
+
+	sqlDB, err := openDB(*dbPath, true)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	result, err := db.ImportJehleCSV(context.Background(), sqlDB, f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errImport, err)
+	}
+	fmt.Printf(msgImportedRows, result.Rows, result.Infinitives)
+	fmt.Println(msgUpdateChecksum)
+	return nil
+}
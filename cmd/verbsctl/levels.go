@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	errMissingLevelsFile = "no levels file given, use -file"
+	errOpenLevelsFile    = "error opening levels file"
+	errParseLevelsFile   = "levels file has invalid lines"
+	errUnknownLevelVerbs = "levels file names infinitives missing from verbs.db"
+	errImportLevels      = "error importing levels"
+	msgUnknownLevelVerbs = "Skipping infinitives missing from verbs.db:\n%v\n"
+	msgLevelsValidated   = "%d levels validated, %d infinitives skipped.\n"
+	msgLevelsImported    = "Imported %d levels.\n"
+)
+
+func runLevels(args []string) error {
+	fs, dbPath := newFlagSet("levels")
+	filePath := fs.String("file", "", "TSV file with rank, infinitive and CEFR level (A1-C2) columns")
+	dryRun := fs.Bool("dry-run", false, "validate the file without importing it")
+	strict := fs.Bool("strict", false, "fail instead of skipping infinitives missing from verbs.db")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *filePath == "" {
+		fs.Usage()
+		return errors.New(errMissingLevelsFile)
+	}
+
+	f, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errOpenLevelsFile, err)
+	}
+	defer f.Close()
+
+	lines, err := db.ParseLevelsTSV(f)
+	if err != nil {
+		return fmt.Errorf("%s:\n%w", errParseLevelsFile, err)
+	}
+
+	sqlDB, err := openDB(*dbPath, !*dryRun)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	ctx := context.Background()
+	known, unknown, err := db.CheckLevelInfinitives(ctx, db.New(sqlDB), lines)
+	if err != nil {
+		return err
+	}
+	if unknown != nil {
+		if *strict {
+			return fmt.Errorf("%s:\n%w", errUnknownLevelVerbs, unknown)
+		}
+		fmt.Fprintf(os.Stderr, msgUnknownLevelVerbs, unknown)
+	}
+	fmt.Printf(msgLevelsValidated, len(known), len(lines)-len(known))
+
+	if *dryRun {
+		return nil
+	}
+	if err := db.ImportLevels(ctx, sqlDB, known); err != nil {
+		return fmt.Errorf("%s: %w", errImportLevels, err)
+	}
+	fmt.Printf(msgLevelsImported, len(known))
+	fmt.Println(msgUpdateChecksum)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	defaultDBPath = "./internal/db/verbs.db"
+
+	errUnknownCommand = "unknown command %q"
+	errDBOpen         = "error opening database"
+)
+
+// command is a verbsctl subcommand.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"export":       {"Export every table to CSV or JSON files", runExport},
+	"import":       {"Import conjugations from a jehle_verb_database CSV", runImport},
+	"levels":       {"Import frequency ranks and CEFR levels from a TSV file", runLevels},
+	"examples":     {"Import example sentences from a TSV file (examples import)", runExamples},
+	"validate":     {"Check the verb data for missing rows, dangling references and empty forms", runValidate},
+	"coverage":     {"Print row counts and per mood/tense coverage", runCoverage},
+	"checksum":     {"Print or write the checksum the bot verifies the embedded verbs.db against", runChecksum},
+	"search-index": {"Rebuild the full-text search index; needs a build with -tags sqlite_fts5", runSearchIndex},
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage()
+		return nil
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage()
+		return fmt.Errorf(errUnknownCommand, args[0])
+	}
+	return cmd.run(args[1:])
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: verbsctl <command> [flags]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun 'verbsctl <command> -h' for the flags of a command.\n")
+}
+
+// newFlagSet creates the flag set of a subcommand with the shared -db flag.
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("verbsctl "+name, flag.ContinueOnError)
+	dbPath := fs.String("db", defaultDBPath, "path to the verbs database")
+	return fs, dbPath
+}
+
+// openDB opens the verbs database, read-only unless writable is set.
+func openDB(path string, writable bool) (*sql.DB, error) {
+	dsn := path
+	if !writable {
+		dsn = "file:" + path + "?mode=ro"
+	}
+	sqlDB, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errDBOpen, err)
+	}
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("%s: %w", errDBOpen, err)
+	}
+	return sqlDB, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const msgSearchIndexed = "Indexed %d infinitives for search.\n"
+
+func runSearchIndex(args []string) error {
+	fs, dbPath := newFlagSet("search-index")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sqlDB, err := openDB(*dbPath, true)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	n, err := db.BuildSearchIndex(context.Background(), sqlDB)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(msgSearchIndexed, n)
+	return nil
+}
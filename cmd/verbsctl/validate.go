@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	errValidationFailed = "found %d problems"
+	msgValid            = "No problems found."
+)
+
+func runValidate(args []string) error {
+	fs, dbPath := newFlagSet("validate")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sqlDB, err := openDB(*dbPath, false)
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	problems, err := db.ValidateIntegrity(context.Background(), db.New(sqlDB))
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf(errValidationFailed, len(problems))
+	}
+
+	fmt.Println(msgValid)
+	return nil
+}
@@ -0,0 +1,330 @@
+// Package api serves the verb reference data over HTTP as JSON, from the same repository, infinitive index and
+// searcher as the Discord handlers.
+package api
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+
+	// requestTimeout bounds the lookups of one request.
+	requestTimeout = 5 * time.Second
+
+	errVerbNotFound      = "verb not found"
+	errFormNotFound      = "no verb has this form"
+	errConjugationAbsent = "no conjugation for this mood and tense"
+	errUnknownMoodTense  = "unknown mood and tense"
+	errAmbiguousVerb     = "more than one verb matches"
+	errMissingQuery      = "the q parameter is required"
+	errMissingForm       = "the form parameter is required"
+	errBadPaging         = "limit and offset must be whole numbers"
+	errSearchUnavailable = "search is unavailable"
+	errLookup            = "lookup failed"
+	errTimeout           = "lookup timed out"
+	msgRequest           = "api request"
+)
+
+// openAPIDocument describes the endpoints of NewHandler.
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// Verb is the JSON form of db.Verb. Forms missing from the data, such as the first person of impersonal verbs, are
+// empty strings.
+type Verb struct {
+	Infinitive  string `json:"infinitive"`
+	Mood        string `json:"mood"`
+	Tense       string `json:"tense"`
+	VerbEnglish string `json:"verb_english"`
+	Form1s      string `json:"form_1s"`
+	Form2s      string `json:"form_2s"`
+	Form3s      string `json:"form_3s"`
+	Form1p      string `json:"form_1p"`
+	Form2p      string `json:"form_2p"`
+	Form3p      string `json:"form_3p"`
+}
+
+// NewVerb converts a row of verbs.db to its JSON form.
+func NewVerb(v db.Verb) Verb {
+	return Verb{
+		Infinitive:  v.Infinitive,
+		Mood:        v.Mood,
+		Tense:       v.Tense,
+		VerbEnglish: db.NullStringToString(v.VerbEnglish),
+		Form1s:      db.NullStringToString(v.Form1s),
+		Form2s:      db.NullStringToString(v.Form2s),
+		Form3s:      db.NullStringToString(v.Form3s),
+		Form1p:      db.NullStringToString(v.Form1p),
+		Form2p:      db.NullStringToString(v.Form2p),
+		Form3p:      db.NullStringToString(v.Form3p),
+	}
+}
+
+// Conjugations is every mood and tense of an infinitive.
+type Conjugations struct {
+	Infinitive     string `json:"infinitive"`
+	Gerund         string `json:"gerund,omitempty"`
+	PastParticiple string `json:"past_participle,omitempty"`
+	Verbs          []Verb `json:"verbs"`
+}
+
+// SearchResults is one page of search results.
+type SearchResults struct {
+	Query   string         `json:"query"`
+	Total   int            `json:"total"`
+	Results []SearchResult `json:"results"`
+}
+
+// SearchResult is an infinitive matching a search, with its matched words surrounded by ** in Snippet.
+type SearchResult struct {
+	Infinitive        string `json:"infinitive"`
+	InfinitiveEnglish string `json:"infinitive_english"`
+	Snippet           string `json:"snippet"`
+}
+
+// FormMatch is a verb row a conjugated form appears in, and the person it appears as.
+type FormMatch struct {
+	Person string `json:"person"`
+	Verb   Verb   `json:"verb"`
+}
+
+// Identification lists every verb row a conjugated form appears in.
+type Identification struct {
+	Form    string      `json:"form"`
+	Matches []FormMatch `json:"matches"`
+}
+
+// Error is the body of every error response. Candidates lists the verbs an ambiguous infinitive could mean.
+type Error struct {
+	Error      string   `json:"error"`
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// server holds the dependencies of the handlers.
+type server struct {
+	verbs       db.VerbRepository
+	infinitives *db.InfinitiveIndex
+	search      db.Searcher
+}
+
+// NewHandler serves the API:
+//
+//   - GET /verbs/{infinitive} – every conjugation of a verb
+//   - GET /verbs/{infinitive}/{mood}/{tense} – one conjugation
+//   - GET /search?q=&limit=&offset= – full-text search, when search is not nil
+//   - GET /identify?form= – the verbs a conjugated form belongs to
+//   - GET /openapi.json – the OpenAPI document of these endpoints
+//
+// Infinitives are resolved like in the Discord commands, ignoring case and accents. Responses are JSON, or plain text
+// when the Accept header prefers it, and carry an ETag.
+func NewHandler(verbs db.VerbRepository, infinitives *db.InfinitiveIndex, search db.Searcher) http.Handler {
+	s := &server{verbs: verbs, infinitives: infinitives, search: search}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /verbs/{infinitive}", s.handleVerbs)
+	mux.HandleFunc("GET /verbs/{infinitive}/{mood}/{tense}", s.handleVerb)
+	mux.HandleFunc("GET /search", s.handleSearch)
+	mux.HandleFunc("GET /identify", s.handleIdentify)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeBody(w, r, http.StatusOK, "application/json", openAPIDocument)
+	})
+	return withRequestContext(mux)
+}
+
+// NewServer creates the HTTP server of NewHandler on addr.
+func NewServer(addr string, verbs db.VerbRepository, infinitives *db.InfinitiveIndex, search db.Searcher) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           NewHandler(verbs, infinitives, search),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// withRequestContext bounds every request by requestTimeout, tags it with a request ID and logs it once handled.
+func withRequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		logger := slog.Default().With("method", r.Method, "path", r.URL.Path)
+		ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+		defer cancel()
+		ctx = logging.WithLogger(logging.WithRequestID(ctx, logging.NewRequestID()), logger)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		logger.InfoContext(ctx, msgRequest, "status", rec.status, "latency", time.Since(start))
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (s *server) handleVerbs(w http.ResponseWriter, r *http.Request) {
+	infinitive, ok := s.resolve(w, r)
+	if !ok {
+		return
+	}
+
+	verbs, err := s.verbs.GetVerbs(r.Context(), infinitive)
+	if err != nil {
+		writeLookupError(w, r, err, errVerbNotFound)
+		return
+	}
+	c := Conjugations{Infinitive: infinitive, Verbs: make([]Verb, len(verbs))}
+	for i, v := range verbs {
+		c.Verbs[i] = NewVerb(v)
+	}
+	if g, err := s.verbs.GetGerund(r.Context(), infinitive); err == nil {
+		c.Gerund = g.Gerund
+	}
+	if p, err := s.verbs.GetPastparticiple(r.Context(), infinitive); err == nil {
+		c.PastParticiple = p.Pastparticiple
+	}
+	write(w, r, http.StatusOK, c)
+}
+
+func (s *server) handleVerb(w http.ResponseWriter, r *http.Request) {
+	infinitive, ok := s.resolve(w, r)
+	if !ok {
+		return
+	}
+
+	tm, ok := spanish.FindTenseMood(r.PathValue("mood"), r.PathValue("tense"))
+	if !ok {
+		write(w, r, http.StatusNotFound, Error{Error: errUnknownMoodTense})
+		return
+	}
+
+	v, err := s.verbs.GetVerb(r.Context(), infinitive, tm.Mood, tm.Tense)
+	if err != nil {
+		writeLookupError(w, r, err, errConjugationAbsent)
+		return
+	}
+	write(w, r, http.StatusOK, NewVerb(v))
+}
+
+func (s *server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	if s.search == nil {
+		write(w, r, http.StatusServiceUnavailable, Error{Error: errSearchUnavailable})
+		return
+	}
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		write(w, r, http.StatusBadRequest, Error{Error: errMissingQuery})
+		return
+	}
+	limit, offset, ok := paging(r)
+	if !ok {
+		write(w, r, http.StatusBadRequest, Error{Error: errBadPaging})
+		return
+	}
+
+	page, err := s.search.Search(r.Context(), query, limit, offset)
+	switch {
+	case errors.Is(err, db.ErrEmptySearch):
+		write(w, r, http.StatusBadRequest, Error{Error: err.Error()})
+		return
+	case errors.Is(err, db.ErrSearchUnavailable):
+		write(w, r, http.StatusServiceUnavailable, Error{Error: errSearchUnavailable})
+		return
+	case err != nil:
+		writeLookupError(w, r, err, "")
+		return
+	}
+
+	results := SearchResults{Query: query, Total: page.Total, Results: make([]SearchResult, len(page.Results))}
+	for i, res := range page.Results {
+		results.Results[i] = SearchResult{
+			Infinitive:        res.Infinitive,
+			InfinitiveEnglish: db.NullStringToString(res.InfinitiveEnglish),
+			Snippet:           res.Snippet,
+		}
+	}
+	write(w, r, http.StatusOK, results)
+}
+
+func (s *server) handleIdentify(w http.ResponseWriter, r *http.Request) {
+	form := r.URL.Query().Get("form")
+	if form == "" {
+		write(w, r, http.StatusBadRequest, Error{Error: errMissingForm})
+		return
+	}
+
+	matches, err := s.verbs.FindForm(r.Context(), form)
+	if err != nil {
+		writeLookupError(w, r, err, errFormNotFound)
+		return
+	}
+	id := Identification{Form: form, Matches: make([]FormMatch, len(matches))}
+	for i, m := range matches {
+		id.Matches[i] = FormMatch{Person: m.Person, Verb: NewVerb(m.Verb)}
+	}
+	write(w, r, http.StatusOK, id)
+}
+
+// resolve returns the infinitive the path refers to, answering 404 when there is none and 409 with the candidates
+// when it is ambiguous.
+func (s *server) resolve(w http.ResponseWriter, r *http.Request) (string, bool) {
+	infinitive, err := s.infinitives.Resolve(r.PathValue("infinitive"))
+	if err == nil {
+		return infinitive, true
+	}
+
+	var ambiguous *db.AmbiguousInfinitiveError
+	if errors.As(err, &ambiguous) {
+		write(w, r, http.StatusConflict, Error{Error: errAmbiguousVerb, Candidates: ambiguous.Candidates})
+	} else {
+		write(w, r, http.StatusNotFound, Error{Error: errVerbNotFound})
+	}
+	return "", false
+}
+
+// writeLookupError answers a failed lookup: 404 with notFound for db.ErrNotFound, 504 when the request ran out of
+// time and 500 otherwise.
+func writeLookupError(w http.ResponseWriter, r *http.Request, err error, notFound string) {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		write(w, r, http.StatusNotFound, Error{Error: notFound})
+	case errors.Is(err, context.DeadlineExceeded):
+		write(w, r, http.StatusGatewayTimeout, Error{Error: errTimeout})
+	default:
+		logging.FromContext(r.Context()).ErrorContext(r.Context(), errLookup, "error", err)
+		write(w, r, http.StatusInternalServerError, Error{Error: errLookup})
+	}
+}
+
+// paging reads the limit and offset query parameters, defaulting to the first defaultSearchLimit results.
+func paging(r *http.Request) (limit, offset int, ok bool) {
+	limit, offset = defaultSearchLimit, 0
+	var err error
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			return 0, 0, false
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, false
+		}
+	}
+	return min(limit, maxSearchLimit), offset, true
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+func str(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+type stubSearcher struct{}
+
+func (stubSearcher) Search(ctx context.Context, query string, limit, offset int) (db.SearchPage, error) {
+	if strings.TrimSpace(query) == "-" {
+		return db.SearchPage{}, db.ErrEmptySearch
+	}
+	return db.SearchPage{
+		Results: []db.SearchResult{{Infinitive: "hablar", InfinitiveEnglish: str("to speak"), Snippet: "**habla**"}},
+		Total:   1,
+	}, nil
+}
+
+func newTestServer(t *testing.T, search db.Searcher) *httptest.Server {
+	t.Helper()
+	infinitives := []db.Infinitive{{Infinitive: "hablar"}, {Infinitive: "sonar"}, {Infinitive: "soñar"}}
+	verbs := db.NewMemoryRepository(db.MemoryData{
+		Infinitives: infinitives,
+		Verbs: []db.Verb{
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", VerbEnglish: str("I speak"),
+				Form1s: str("hablo"), Form2s: str("hablas"), Form3s: str("habla"),
+				Form1p: str("hablamos"), Form2p: str("habláis"), Form3p: str("hablan")},
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Pretérito",
+				Form1s: str("hablé"), Form3s: str("habló")},
+		},
+		Gerunds:         []db.Gerund{{Infinitive: "hablar", Gerund: "hablando"}},
+		Pastparticiples: []db.Pastparticiple{{Infinitive: "hablar", Pastparticiple: "hablado"}},
+	})
+	server := httptest.NewServer(NewHandler(verbs, db.NewInfinitiveIndex(infinitives), search))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, url string, header map[string]string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequest(%q) returned an error: %v", url, err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s returned an error: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestEndpoints(t *testing.T) {
+	server := newTestServer(t, stubSearcher{})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"verbs", "/verbs/HABLAR", http.StatusOK, `"gerund":"hablando","past_participle":"hablado","verbs":[{"infinitive":"hablar","mood":"Indicativo","tense":"Presente"`},
+		{"verbs unknown", "/verbs/blorp", http.StatusNotFound, `{"error":"verb not found"}`},
+		{"verbs ambiguous", "/verbs/sonar%CC%81", http.StatusConflict, `"candidates":["sonar","soñar"]`},
+		{"verb", "/verbs/hablar/Indicativo/Presente", http.StatusOK, `"verb_english":"I speak","form_1s":"hablo","form_2s":"hablas"`},
+		{"verb missing forms", "/verbs/hablar/Indicativo/Pret%C3%A9rito", http.StatusOK, `"form_2s":"","form_3s":"habló"`},
+		{"verb unknown tense", "/verbs/hablar/Indicativo/Futuro", http.StatusNotFound, `no conjugation for this mood and tense`},
+		{"verb folded mood and tense", "/verbs/hablar/indicativo/preterito", http.StatusOK, `"tense":"Pretérito"`},
+		{"verb uppercase mood", "/verbs/hablar/INDICATIVO/presente", http.StatusOK, `"form_1s":"hablo"`},
+		{"verb unknown mood", "/verbs/hablar/Indicative/Present", http.StatusNotFound, `{"error":"unknown mood and tense"}`},
+		{"search", "/search?q=habla", http.StatusOK, `{"query":"habla","total":1,"results":[{"infinitive":"hablar","infinitive_english":"to speak","snippet":"**habla**"}]}`},
+		{"search empty", "/search?q=-", http.StatusBadRequest, db.ErrEmptySearch.Error()},
+		{"search missing query", "/search", http.StatusBadRequest, errMissingQuery},
+		{"search bad paging", "/search?q=habla&limit=x", http.StatusBadRequest, errBadPaging},
+		{"identify", "/identify?form=habla", http.StatusOK, `{"form":"habla","matches":[{"person":"3s","verb":{"infinitive":"hablar"`},
+		{"identify unknown", "/identify?form=blorp", http.StatusNotFound, errFormNotFound},
+		{"identify missing form", "/identify", http.StatusBadRequest, errMissingForm},
+		{"openapi", "/openapi.json", http.StatusOK, `"openapi": "3.1.0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, server.URL+tt.path, nil)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GET %s status = %d, want %d", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("GET %s body = %s, want it to contain %s", tt.path, body, tt.wantBody)
+			}
+			if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, mediaJSON) {
+				t.Errorf("GET %s Content-Type = %q, want JSON", tt.path, got)
+			}
+			if !json.Valid([]byte(body)) {
+				t.Errorf("GET %s body is not valid JSON: %s", tt.path, body)
+			}
+		})
+	}
+}
+
+func TestSearchUnavailable(t *testing.T) {
+	server := newTestServer(t, nil)
+	if resp, _ := get(t, server.URL+"/search?q=habla", nil); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GET /search status = %d without a searcher, want 503", resp.StatusCode)
+	}
+}
+
+func TestContentNegotiation(t *testing.T) {
+	server := newTestServer(t, nil)
+	url := server.URL + "/verbs/hablar/Indicativo/Presente"
+
+	tests := []struct {
+		accept     string
+		wantStatus int
+		wantType   string
+	}{
+		{"", http.StatusOK, mediaJSON},
+		{"*/*", http.StatusOK, mediaJSON},
+		{"text/plain", http.StatusOK, mediaText},
+		{"application/json;q=0.5, text/plain", http.StatusOK, mediaText},
+		{"text/html, application/json;q=0.9", http.StatusOK, mediaJSON},
+		{"text/html", http.StatusNotAcceptable, mediaJSON},
+		{"text/plain;q=0", http.StatusNotAcceptable, mediaJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			resp, body := get(t, url, map[string]string{"Accept": tt.accept})
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Accept %q: status = %d, want %d", tt.accept, resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, tt.wantType) {
+				t.Errorf("Accept %q: Content-Type = %q, want %s", tt.accept, got, tt.wantType)
+			}
+			if tt.wantType == mediaText && !strings.Contains(body, "1s\thablo\n") {
+				t.Errorf("Accept %q: body = %q, want a plain text table", tt.accept, body)
+			}
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	server := newTestServer(t, nil)
+	url := server.URL + "/verbs/hablar"
+
+	resp, _ := get(t, url, nil)
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag on a successful response")
+	}
+
+	if resp, body := get(t, url, map[string]string{"If-None-Match": etag}); resp.StatusCode != http.StatusNotModified || body != "" {
+		t.Errorf("GET with a current ETag: status = %d, body = %q, want 304 and no body", resp.StatusCode, body)
+	}
+	if resp, _ := get(t, url, map[string]string{"If-None-Match": `"stale", W/` + etag}); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("GET with a weak current ETag in a list: status = %d, want 304", resp.StatusCode)
+	}
+	if resp, _ := get(t, url, map[string]string{"If-None-Match": `"stale"`}); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET with a stale ETag: status = %d, want 200", resp.StatusCode)
+	}
+
+	// Each representation has its own ETag.
+	if resp, _ := get(t, url, map[string]string{"Accept": mediaText}); resp.Header.Get("ETag") == etag {
+		t.Error("Expected the plain text representation to have a different ETag")
+	}
+	if resp, _ := get(t, server.URL+"/verbs/blorp", nil); resp.Header.Get("ETag") != "" {
+		t.Error("Expected no ETag on an error response")
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	mediaJSON = "application/json"
+	mediaText = "text/plain"
+
+	errNotAcceptable = "responses are available as application/json or text/plain"
+)
+
+// texter is a response with a plain text form.
+type texter interface {
+	text() string
+}
+
+// write answers with v in the media type the Accept header prefers, JSON unless plain text is preferred, or 406 when
+// neither is acceptable.
+func write(w http.ResponseWriter, r *http.Request, status int, v texter) {
+	media, ok := negotiate(r.Header.Get("Accept"))
+	if !ok {
+		status, v, media = http.StatusNotAcceptable, Error{Error: errNotAcceptable}, mediaJSON
+	}
+
+	var body []byte
+	if media == mediaText {
+		body = []byte(v.text())
+	} else {
+		var err error
+		if body, err = json.Marshal(v); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		body = append(body, '\n')
+	}
+	writeBody(w, r, status, media, body)
+}
+
+// writeBody sends body with an ETag derived from it, or 304 Not Modified when the request's If-None-Match already
+// has it. Only successful responses carry an ETag.
+func writeBody(w http.ResponseWriter, r *http.Request, status int, media string, body []byte) {
+	h := w.Header()
+	h.Set("Content-Type", media+"; charset=utf-8")
+	h.Add("Vary", "Accept")
+
+	if status == http.StatusOK {
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		h.Set("ETag", etag)
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing weakly as RFC 9110 requires.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiate picks the media type to answer an Accept header with: JSON when the header is empty or JSON is
+// preferred, plain text when it is preferred, and nothing when neither is acceptable.
+func negotiate(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return mediaJSON, true
+	}
+
+	type offer struct {
+		media string
+		q     float64
+		order int
+	}
+	var offers []offer
+	for i, part := range strings.Split(accept, ",") {
+		media, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		switch media {
+		case mediaJSON, "application/*", "*/*":
+			offers = append(offers, offer{mediaJSON, q, i})
+		case mediaText, "text/*":
+			offers = append(offers, offer{mediaText, q, i})
+		}
+	}
+	if len(offers) == 0 {
+		return "", false
+	}
+	sort.SliceStable(offers, func(i, j int) bool { return offers[i].q > offers[j].q })
+	return offers[0].media, true
+}
+
+func (v Verb) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s – %s %s", v.Infinitive, v.Mood, v.Tense)
+	if v.VerbEnglish != "" {
+		fmt.Fprintf(&b, " (%s)", v.VerbEnglish)
+	}
+	b.WriteByte('\n')
+	forms := []struct{ person, form string }{
+		{"1s", v.Form1s}, {"2s", v.Form2s}, {"3s", v.Form3s},
+		{"1p", v.Form1p}, {"2p", v.Form2p}, {"3p", v.Form3p},
+	}
+	for _, f := range forms {
+		fmt.Fprintf(&b, "%s\t%s\n", f.person, f.form)
+	}
+	return b.String()
+}
+
+func (c Conjugations) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", c.Infinitive)
+	if c.Gerund != "" {
+		fmt.Fprintf(&b, "gerund\t%s\n", c.Gerund)
+	}
+	if c.PastParticiple != "" {
+		fmt.Fprintf(&b, "past participle\t%s\n", c.PastParticiple)
+	}
+	for _, v := range c.Verbs {
+		b.WriteByte('\n')
+		b.WriteString(v.text())
+	}
+	return b.String()
+}
+
+func (s SearchResults) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d results for %q\n", s.Total, s.Query)
+	for _, r := range s.Results {
+		fmt.Fprintf(&b, "%s\t%s\t%s\n", r.Infinitive, r.InfinitiveEnglish, r.Snippet)
+	}
+	return b.String()
+}
+
+func (id Identification) text() string {
+	var b strings.Builder
+	for _, m := range id.Matches {
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\n", m.Verb.Infinitive, m.Verb.Mood, m.Verb.Tense, m.Person)
+	}
+	return b.String()
+}
+
+func (e Error) text() string {
+	if len(e.Candidates) > 0 {
+		return e.Error + ": " + strings.Join(e.Candidates, ", ") + "\n"
+	}
+	return e.Error + "\n"
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestLRUGetAdd(t *testing.T) {
+	c := NewLRU[string, int](2)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", v, ok)
+	}
+
+	// "b" is now the least recently used entry.
+	c.Add("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) = %d, %v, want 3, true", v, ok)
+	}
+
+	c.Add("a", 10)
+	if v, _ := c.Get("a"); v != 10 {
+		t.Errorf("Get(a) after update = %d, want 10", v)
+	}
+
+	want := Stats{Hits: 3, Misses: 2, Evictions: 1, Size: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLRUMinimumCapacity(t *testing.T) {
+	c := NewLRU[int, int](0)
+	c.Add(1, 1)
+	c.Add(2, 2)
+
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", c.Len())
+	}
+}
+
+func TestLRUConcurrentUse(t *testing.T) {
+	c := NewLRU[string, int](16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa((g + i) % 32)
+				if _, ok := c.Get(key); !ok {
+					c.Add(key, i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	if stats.Hits+stats.Misses != 8000 {
+		t.Errorf("Expected 8000 lookups, got %d", stats.Hits+stats.Misses)
+	}
+	if stats.Size > 16 {
+		t.Errorf("Size = %d, want at most 16", stats.Size)
+	}
+}
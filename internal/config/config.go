@@ -0,0 +1,521 @@
+// Package config loads the settings of the bot from defaults, an optional YAML or TOML file, environment variables
+// and command-line flags, in increasing order of precedence.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileKey is the environment variable naming the config file when the -config flag is not given.
+	FileKey = "CONFIG_FILE"
+	// DefaultFile is read when neither -config nor CONFIG_FILE name a file. It may be missing.
+	DefaultFile = "config.yaml"
+
+	VerbStoreMemory = "memory"
+	VerbStoreSQLite = "sqlite"
+
+	redacted = "[redacted]"
+
+	errReadFile      = "failed to read config file %s: %w"
+	errParseFile     = "failed to parse config file %s: %w"
+	errNotScalar     = "%s on line %d must be a value or a list of values"
+	errNotTOMLValue  = "%s must be a string, number, boolean or a list of those"
+	errUnknownKey    = "unknown setting %q"
+	errInvalidSource = "%s: invalid %s %q: %v"
+	errRequired      = "%s is required"
+	errVerbStore     = "%s must be %s or %s, got %q"
+	errCacheSize     = "%s must not be negative, got %d"
+	errAddr          = "%s %q is not a host:port address: %v"
+	errWebhookURL    = "%s %q must be an https URL"
+	errWebhookSecret = "%s must be 1 to 256 letters, digits, _ or -"
+	errHomeserver    = "%s %q must be an http or https URL"
+	errPrefix        = "%s must not be empty or contain spaces"
+)
+
+// webhookSecretPattern is what the Bot API accepts as the secret token of a webhook.
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// Config holds every setting of the bot.
+type Config struct {
+	BotToken string
+	// GuildIDs are the guilds commands are registered in.
+	GuildIDs    []string
+	VerbsDBPath string
+	AppDBPath   string
+	VerbStore   string
+	// VerbCacheSize is the number of conjugation tables the sqlite verb store caches. Zero disables the cache.
+	VerbCacheSize int
+	LogLevel      string
+	LogFormat     string
+	// MetricsAddr is where metrics and health checks are served. Empty disables the server.
+	MetricsAddr string
+	// APIAddr is where the api command serves the HTTP API.
+	APIAddr string
+	// TelegramToken is the token of the Telegram bot the telegram command answers as.
+	TelegramToken string
+	// TelegramAPIURL is the Bot API server the telegram command talks to. Empty means api.telegram.org.
+	TelegramAPIURL string
+	// TelegramWebhookURL is the public URL Telegram posts updates to. Empty means long polling.
+	TelegramWebhookURL string
+	// TelegramWebhookAddr is where the telegram command listens for webhook requests.
+	TelegramWebhookAddr string
+	// TelegramWebhookSecret is sent by Telegram with every webhook request; others are rejected.
+	TelegramWebhookSecret string
+	// MatrixHomeserver is the URL of the homeserver the matrix command connects to.
+	MatrixHomeserver string
+	// MatrixAccessToken is the access token of the Matrix user the matrix command answers as.
+	MatrixAccessToken string
+	// MatrixCommandPrefix starts the commands the matrix command answers, as in "!conjugate hablar".
+	MatrixCommandPrefix string
+}
+
+// Default returns the settings used where no source sets them.
+func Default() Config {
+	return Config{
+		AppDBPath:     "./data/app.db",
+		VerbStore:     VerbStoreMemory,
+		VerbCacheSize: 1024,
+		LogLevel:      "info",
+		LogFormat:     logging.FormatText,
+		APIAddr:       "localhost:8080",
+
+		TelegramWebhookAddr: "localhost:8443",
+		MatrixCommandPrefix: "!",
+	}
+}
+
+// setting describes one field of Config: its key in the config file, its flag, its environment variable and how it is
+// read and written as text.
+type setting struct {
+	key    string
+	flag   string
+	env    string
+	usage  string
+	secret bool
+	get    func(c *Config) string
+	set    func(c *Config, value string) error
+}
+
+func stringSetting(key, env, usage string, field func(c *Config) *string) setting {
+	return setting{
+		key:   key,
+		flag:  strings.ReplaceAll(key, "_", "-"),
+		env:   env,
+		usage: usage,
+		get:   func(c *Config) string { return *field(c) },
+		set: func(c *Config, value string) error {
+			*field(c) = value
+			return nil
+		},
+	}
+}
+
+func intSetting(key, env, usage string, field func(c *Config) *int) setting {
+	return setting{
+		key:   key,
+		flag:  strings.ReplaceAll(key, "_", "-"),
+		env:   env,
+		usage: usage,
+		get:   func(c *Config) string { return strconv.Itoa(*field(c)) },
+		set: func(c *Config, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return errors.New("not a whole number")
+			}
+			*field(c) = n
+			return nil
+		},
+	}
+}
+
+// listSetting is a comma-separated list, which is also accepted as a YAML sequence.
+func listSetting(key, env, usage string, field func(c *Config) *[]string) setting {
+	return setting{
+		key:   key,
+		flag:  strings.ReplaceAll(key, "_", "-"),
+		env:   env,
+		usage: usage,
+		get:   func(c *Config) string { return strings.Join(*field(c), ",") },
+		set: func(c *Config, value string) error {
+			var items []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			*field(c) = items
+			return nil
+		},
+	}
+}
+
+func secret(s setting) setting {
+	s.secret = true
+	return s
+}
+
+func withFlag(name string, s setting) setting {
+	s.flag = name
+	return s
+}
+
+var settings = []setting{
+	secret(stringSetting("bot_token", "BOT_TOKEN", "Discord bot token",
+		func(c *Config) *string { return &c.BotToken })),
+	listSetting("guild_ids", "GUILD_ID", "comma-separated IDs of the guilds commands are registered in",
+		func(c *Config) *[]string { return &c.GuildIDs }),
+	withFlag("verbs-db", stringSetting("verbs_db_path", "VERBS_DB_PATH",
+		"path to an external verbs.db; defaults to the copy embedded in the binary",
+		func(c *Config) *string { return &c.VerbsDBPath })),
+	stringSetting("app_db_path", "APP_DB_PATH", "path to the app database, created on first start",
+		func(c *Config) *string { return &c.AppDBPath }),
+	stringSetting("verb_store", "VERB_STORE", "where verb lookups are served from: memory or sqlite",
+		func(c *Config) *string { return &c.VerbStore }),
+	intSetting("verb_cache_size", "VERB_CACHE_SIZE", "conjugation tables cached by the sqlite verb store; 0 disables the cache",
+		func(c *Config) *int { return &c.VerbCacheSize }),
+	stringSetting("log_level", logging.LevelKey, "log level: debug, info, warn or error",
+		func(c *Config) *string { return &c.LogLevel }),
+	stringSetting("log_format", logging.FormatKey, "log format: text or json",
+		func(c *Config) *string { return &c.LogFormat }),
+	stringSetting("metrics_addr", "METRICS_ADDR", "address to serve metrics and health checks on; empty disables them",
+		func(c *Config) *string { return &c.MetricsAddr }),
+	stringSetting("api_addr", "API_ADDR", "address the api command serves the HTTP API on",
+		func(c *Config) *string { return &c.APIAddr }),
+	secret(stringSetting("telegram_token", "TELEGRAM_TOKEN", "Telegram bot token",
+		func(c *Config) *string { return &c.TelegramToken })),
+	stringSetting("telegram_api_url", "TELEGRAM_API_URL", "Telegram Bot API server; defaults to api.telegram.org",
+		func(c *Config) *string { return &c.TelegramAPIURL }),
+	stringSetting("telegram_webhook_url", "TELEGRAM_WEBHOOK_URL", "public https URL Telegram posts updates to; empty uses long polling",
+		func(c *Config) *string { return &c.TelegramWebhookURL }),
+	stringSetting("telegram_webhook_addr", "TELEGRAM_WEBHOOK_ADDR", "address the telegram command receives webhook requests on",
+		func(c *Config) *string { return &c.TelegramWebhookAddr }),
+	secret(stringSetting("telegram_webhook_secret", "TELEGRAM_WEBHOOK_SECRET", "secret Telegram sends with webhook requests",
+		func(c *Config) *string { return &c.TelegramWebhookSecret })),
+	stringSetting("matrix_homeserver", "MATRIX_HOMESERVER", "URL of the Matrix homeserver, e.g. https://matrix.example.org",
+		func(c *Config) *string { return &c.MatrixHomeserver }),
+	secret(stringSetting("matrix_access_token", "MATRIX_ACCESS_TOKEN", "access token of the Matrix user",
+		func(c *Config) *string { return &c.MatrixAccessToken })),
+	stringSetting("matrix_command_prefix", "MATRIX_COMMAND_PREFIX", "prefix of the commands answered on Matrix",
+		func(c *Config) *string { return &c.MatrixCommandPrefix }),
+}
+
+// Flags are the command-line flags of every setting, plus -config naming the config file.
+type Flags struct {
+	fs     *flag.FlagSet
+	file   *string
+	values map[string]*string
+}
+
+// RegisterFlags defines the flags of every setting on fs. Load reads the ones given once fs is parsed.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{
+		fs:     fs,
+		file:   fs.String("config", "", "path to a YAML or, ending in .toml, TOML config file; defaults to $"+FileKey+" or "+DefaultFile),
+		values: make(map[string]*string, len(settings)),
+	}
+	for _, s := range settings {
+		usage := s.usage
+		if !s.secret {
+			usage += " ($" + s.env + ")"
+		} else {
+			usage += " ($" + s.env + "; prefer it to the flag, which other users can see)"
+		}
+		f.values[s.key] = fs.String(s.flag, "", usage)
+	}
+	return f
+}
+
+// Requirement is a group of settings that are only required by some commands.
+type Requirement int
+
+const (
+	// Discord requires the bot token and at least one guild ID.
+	Discord Requirement = iota
+	// Telegram requires the Telegram bot token.
+	Telegram
+	// Matrix requires the homeserver and an access token.
+	Matrix
+)
+
+// Load builds the configuration from the defaults, the config file, the environment variables looked up with
+// lookupEnv and the flags given on the command line, each overriding the ones before, and validates it against
+// require. flags may be nil when there is no command line. Every problem found is reported in a single
+// *ValidationError.
+func Load(flags *Flags, lookupEnv func(key string) (string, bool), require ...Requirement) (Config, error) {
+	cfg := Default()
+	var problems []string
+
+	path, required := configFile(flags, lookupEnv)
+	values, err := readFile(path, required)
+	if err != nil {
+		return cfg, err
+	}
+
+	for _, s := range settings {
+		if value, ok := values[s.key]; ok {
+			if err := s.set(&cfg, value); err != nil {
+				problems = append(problems, fmt.Sprintf(errInvalidSource, path, s.key, value, err))
+			}
+			delete(values, s.key)
+		}
+		if value, ok := lookupEnv(s.env); ok && value != "" {
+			if err := s.set(&cfg, value); err != nil {
+				problems = append(problems, fmt.Sprintf(errInvalidSource, "environment", s.env, value, err))
+			}
+		}
+	}
+	unknown := make([]string, 0, len(values))
+	for key := range values {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		problems = append(problems, fmt.Sprintf("%s: "+errUnknownKey, path, key))
+	}
+
+	if flags != nil {
+		flags.fs.Visit(func(f *flag.Flag) {
+			for _, s := range settings {
+				if s.flag != f.Name {
+					continue
+				}
+				value := *flags.values[s.key]
+				if err := s.set(&cfg, value); err != nil {
+					problems = append(problems, fmt.Sprintf(errInvalidSource, "flag", "-"+f.Name, value, err))
+				}
+			}
+		})
+	}
+
+	problems = append(problems, cfg.problems(require)...)
+	if len(problems) > 0 {
+		return cfg, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// configFile returns the config file to read and whether it was asked for, in which case it must exist.
+func configFile(flags *Flags, lookupEnv func(key string) (string, bool)) (string, bool) {
+	if flags != nil && *flags.file != "" {
+		return *flags.file, true
+	}
+	if path, ok := lookupEnv(FileKey); ok && path != "" {
+		return path, true
+	}
+	return DefaultFile, false
+}
+
+// readFile reads the settings of a config file as text keyed by setting, with lists joined by commas. A file ending
+// in .toml is read as TOML and any other as YAML. A missing file has no settings unless it is required.
+func readFile(path string, required bool) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !required && errors.Is(err, fs.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf(errReadFile, path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		values, err := readTOML(data)
+		if err != nil {
+			return nil, fmt.Errorf(errParseFile, path, err)
+		}
+		return values, nil
+	}
+
+	var nodes map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf(errParseFile, path, err)
+	}
+
+	values := make(map[string]string, len(nodes))
+	for key, node := range nodes {
+		switch node.Kind {
+		case yaml.ScalarNode:
+			values[key] = node.Value
+		case yaml.SequenceNode:
+			items := make([]string, 0, len(node.Content))
+			for _, item := range node.Content {
+				items = append(items, item.Value)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			return nil, fmt.Errorf(errParseFile, path, fmt.Errorf(errNotScalar, key, node.Line))
+		}
+	}
+	return values, nil
+}
+
+// readTOML reads the settings of a TOML config file as readFile does those of a YAML file. Settings are flat, so only
+// top-level strings, numbers, booleans and arrays of those are read; a table is an error.
+func readTOML(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if items, ok := value.([]any); ok {
+			texts := make([]string, len(items))
+			for i, item := range items {
+				text, ok := tomlScalar(item)
+				if !ok {
+					return nil, fmt.Errorf(errNotTOMLValue, key)
+				}
+				texts[i] = text
+			}
+			values[key] = strings.Join(texts, ",")
+			continue
+		}
+		text, ok := tomlScalar(value)
+		if !ok {
+			return nil, fmt.Errorf(errNotTOMLValue, key)
+		}
+		values[key] = text
+	}
+	return values, nil
+}
+
+// tomlScalar returns a decoded TOML string, number or boolean as text, and false for anything else.
+func tomlScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// Validate reports every problem with c, given the settings in require, in a single *ValidationError, or nil if there
+// are none.
+func (c Config) Validate(require ...Requirement) error {
+	if problems := c.problems(require); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (c Config) problems(require []Requirement) []string {
+	var problems []string
+	for _, r := range require {
+		switch r {
+		case Discord:
+			if c.BotToken == "" {
+				problems = append(problems, fmt.Sprintf(errRequired, "BOT_TOKEN"))
+			}
+			if len(c.GuildIDs) == 0 {
+				problems = append(problems, fmt.Sprintf(errRequired, "GUILD_ID"))
+			}
+		case Telegram:
+			if c.TelegramToken == "" {
+				problems = append(problems, fmt.Sprintf(errRequired, "TELEGRAM_TOKEN"))
+			}
+		case Matrix:
+			if c.MatrixHomeserver == "" {
+				problems = append(problems, fmt.Sprintf(errRequired, "MATRIX_HOMESERVER"))
+			}
+			if c.MatrixAccessToken == "" {
+				problems = append(problems, fmt.Sprintf(errRequired, "MATRIX_ACCESS_TOKEN"))
+			}
+		}
+	}
+	if c.VerbStore != VerbStoreMemory && c.VerbStore != VerbStoreSQLite {
+		problems = append(problems, fmt.Sprintf(errVerbStore, "VERB_STORE", VerbStoreMemory, VerbStoreSQLite, c.VerbStore))
+	}
+	if c.VerbCacheSize < 0 {
+		problems = append(problems, fmt.Sprintf(errCacheSize, "VERB_CACHE_SIZE", c.VerbCacheSize))
+	}
+	if _, err := logging.New(io.Discard, c.LogLevel, c.LogFormat); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if c.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			problems = append(problems, fmt.Sprintf(errAddr, "METRICS_ADDR", c.MetricsAddr, err))
+		}
+	}
+	if _, _, err := net.SplitHostPort(c.APIAddr); err != nil {
+		problems = append(problems, fmt.Sprintf(errAddr, "API_ADDR", c.APIAddr, err))
+	}
+	if c.TelegramWebhookURL != "" {
+		if u, err := url.Parse(c.TelegramWebhookURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf(errWebhookURL, "TELEGRAM_WEBHOOK_URL", c.TelegramWebhookURL))
+		}
+	}
+	if _, _, err := net.SplitHostPort(c.TelegramWebhookAddr); err != nil {
+		problems = append(problems, fmt.Sprintf(errAddr, "TELEGRAM_WEBHOOK_ADDR", c.TelegramWebhookAddr, err))
+	}
+	if c.TelegramWebhookSecret != "" && !webhookSecretPattern.MatchString(c.TelegramWebhookSecret) {
+		problems = append(problems, fmt.Sprintf(errWebhookSecret, "TELEGRAM_WEBHOOK_SECRET"))
+	}
+	if c.MatrixHomeserver != "" {
+		if u, err := url.Parse(c.MatrixHomeserver); err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf(errHomeserver, "MATRIX_HOMESERVER", c.MatrixHomeserver))
+		}
+	}
+	if c.MatrixCommandPrefix == "" || strings.ContainsFunc(c.MatrixCommandPrefix, unicode.IsSpace) {
+		problems = append(problems, fmt.Sprintf(errPrefix, "MATRIX_COMMAND_PREFIX"))
+	}
+	return problems
+}
+
+// ValidationError lists every problem found in a configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// String lists every setting as key=value, one per line, with secrets redacted.
+func (c Config) String() string {
+	var b strings.Builder
+	for _, s := range settings {
+		fmt.Fprintf(&b, "%s=%s\n", s.key, c.display(s))
+	}
+	return b.String()
+}
+
+// LogValue logs every setting with secrets redacted.
+func (c Config) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(settings))
+	for _, s := range settings {
+		attrs = append(attrs, slog.String(s.key, c.display(s)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (c Config) display(s setting) string {
+	value := s.get(&c)
+	if s.secret && value != "" {
+		return redacted
+	}
+	return value
+}
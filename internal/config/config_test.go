@@ -0,0 +1,280 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// envMap is a lookupEnv over a fixed set of variables.
+func envMap(vars map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+bot_token: file-token
+guild_ids: [file-guild]
+verb_store: sqlite
+verb_cache_size: 64
+log_level: debug
+`)
+	env := map[string]string{
+		FileKey:           path,
+		"GUILD_ID":        "env-guild, other-guild",
+		"VERB_CACHE_SIZE": "128",
+		"LOG_LEVEL":       "",
+	}
+
+	fs := flag.NewFlagSet("bot", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	if err := fs.Parse([]string{"-verb-cache-size", "256", "-verbs-db", "/data/verbs.db"}); err != nil {
+		t.Fatalf("Parse() returned an error: %v", err)
+	}
+
+	cfg, err := Load(flags, envMap(env), Discord)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+
+	want := Default()
+	want.BotToken = "file-token"
+	want.GuildIDs = []string{"env-guild", "other-guild"}
+	want.VerbStore = VerbStoreSQLite
+	want.VerbCacheSize = 256
+	want.LogLevel = "debug"
+	want.VerbsDBPath = "/data/verbs.db"
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir() returned an error: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	cfg, err := Load(nil, envMap(map[string]string{}))
+	if err != nil {
+		t.Fatalf("Load() returned an error without %s: %v", DefaultFile, err)
+	}
+	if cfg.AppDBPath != Default().AppDBPath {
+		t.Errorf("AppDBPath = %q, want the default %q", cfg.AppDBPath, Default().AppDBPath)
+	}
+
+	_, err = Load(nil, envMap(map[string]string{FileKey: filepath.Join(dir, "missing.yaml")}))
+	if err == nil || !strings.Contains(err.Error(), "failed to read config file") {
+		t.Errorf("Load() error = %v, want a missing file named in %s to fail", err, FileKey)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, `
+verb_store: postgres
+verb_cache_sise: 10
+`)
+	env := map[string]string{
+		FileKey:           path,
+		"VERB_CACHE_SIZE": "lots",
+		"LOG_FORMAT":      "xml",
+		"METRICS_ADDR":    "9090",
+	}
+
+	_, err := Load(nil, envMap(env), Discord)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Load() error = %v, want a *ValidationError", err)
+	}
+
+	want := []string{
+		`environment: invalid VERB_CACHE_SIZE "lots": not a whole number`,
+		`unknown setting "verb_cache_sise"`,
+		"BOT_TOKEN is required",
+		"GUILD_ID is required",
+		`VERB_STORE must be memory or sqlite, got "postgres"`,
+		`unknown log format "xml"`,
+		`METRICS_ADDR "9090" is not a host:port address`,
+	}
+	if len(verr.Problems) != len(want) {
+		t.Errorf("Load() found %d problems, want %d: %v", len(verr.Problems), len(want), verr.Problems)
+	}
+	for _, problem := range want {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("Load() error = %v, want it to contain %q", err, problem)
+		}
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	cfg := Default()
+	cfg.BotToken = "super-secret-token"
+	cfg.GuildIDs = []string{"1234", "5678"}
+
+	s := cfg.String()
+	if strings.Contains(s, "super-secret-token") {
+		t.Errorf("String() = %q, leaks the bot token", s)
+	}
+	for _, want := range []string{"bot_token=" + redacted, "guild_ids=1234,5678", "verb_cache_size=1024"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("String() = %q, want it to contain %q", s, want)
+		}
+	}
+
+	if got := cfg.LogValue().String(); strings.Contains(got, "super-secret-token") {
+		t.Errorf("LogValue() = %q, leaks the bot token", got)
+	}
+
+	// An unset secret is shown as unset rather than hidden.
+	if s := Default().String(); !strings.Contains(s, "bot_token=\n") {
+		t.Errorf("String() = %q, want an empty bot_token", s)
+	}
+}
+
+func TestTelegramSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantProblem string
+	}{
+		{"token required", map[string]string{}, "TELEGRAM_TOKEN is required"},
+		{"polling", map[string]string{"TELEGRAM_TOKEN": "123:abc"}, ""},
+		{"webhook", map[string]string{"TELEGRAM_TOKEN": "123:abc", "TELEGRAM_WEBHOOK_URL": "https://bot.example.com/telegram",
+			"TELEGRAM_WEBHOOK_SECRET": "s3cret_-"}, ""},
+		{"plain http webhook", map[string]string{"TELEGRAM_TOKEN": "123:abc", "TELEGRAM_WEBHOOK_URL": "http://bot.example.com/telegram"},
+			`TELEGRAM_WEBHOOK_URL "http://bot.example.com/telegram" must be an https URL`},
+		{"bad secret", map[string]string{"TELEGRAM_TOKEN": "123:abc", "TELEGRAM_WEBHOOK_SECRET": "not secret!"},
+			"TELEGRAM_WEBHOOK_SECRET must be 1 to 256 letters, digits, _ or -"},
+		{"bad address", map[string]string{"TELEGRAM_TOKEN": "123:abc", "TELEGRAM_WEBHOOK_ADDR": "8443"},
+			`TELEGRAM_WEBHOOK_ADDR "8443" is not a host:port address`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.env[FileKey] = writeFile(t, "")
+			_, err := Load(nil, envMap(tt.env), Telegram)
+			if tt.wantProblem == "" {
+				if err != nil {
+					t.Errorf("Load() returned an error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantProblem) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantProblem)
+			}
+		})
+	}
+}
+
+func TestMatrixSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantProblem string
+	}{
+		{"required", map[string]string{}, "MATRIX_HOMESERVER is required"},
+		{"token required", map[string]string{"MATRIX_HOMESERVER": "https://matrix.example.org"}, "MATRIX_ACCESS_TOKEN is required"},
+		{"valid", map[string]string{"MATRIX_HOMESERVER": "https://matrix.example.org", "MATRIX_ACCESS_TOKEN": "syt_abc",
+			"MATRIX_COMMAND_PREFIX": "?"}, ""},
+		{"not a URL", map[string]string{"MATRIX_HOMESERVER": "matrix.example.org", "MATRIX_ACCESS_TOKEN": "syt_abc"},
+			`MATRIX_HOMESERVER "matrix.example.org" must be an http or https URL`},
+		{"spaced prefix", map[string]string{"MATRIX_HOMESERVER": "https://matrix.example.org", "MATRIX_ACCESS_TOKEN": "syt_abc",
+			"MATRIX_COMMAND_PREFIX": "hey bot"}, "MATRIX_COMMAND_PREFIX must not be empty or contain spaces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.env[FileKey] = writeFile(t, "")
+			_, err := Load(nil, envMap(tt.env), Matrix)
+			if tt.wantProblem == "" {
+				if err != nil {
+					t.Errorf("Load() returned an error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantProblem) {
+				t.Errorf("Load() error = %v, want it to contain %q", err, tt.wantProblem)
+			}
+		})
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `# Discord
+bot_token = "toml-token" # inline comment
+guild_ids = [
+  "g1",
+  'g2', # literal string
+]
+verb_cache_size = 2_048
+matrix_command_prefix = "#"
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load(nil, envMap(map[string]string{FileKey: path}), Discord)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+	if cfg.BotToken != "toml-token" || !reflect.DeepEqual(cfg.GuildIDs, []string{"g1", "g2"}) ||
+		cfg.VerbCacheSize != 2048 || cfg.MatrixCommandPrefix != "#" {
+		t.Errorf("Load() = %+v, want the settings of the TOML file", cfg)
+	}
+}
+
+func TestReadTOML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		{"escaped backslash", `bot_token = "a\\" # ends in a backslash`, map[string]string{"bot_token": `a\`}, ""},
+		{"escaped quote", `bot_token = "a\"b"`, map[string]string{"bot_token": `a"b`}, ""},
+		{"float", "cache_ttl = 1.5", map[string]string{"cache_ttl": "1.5"}, ""},
+		{"boolean list", "flags = [true, false]", map[string]string{"flags": "true,false"}, ""},
+		{"table", "[discord]\nbot_token = \"x\"", nil, "discord must be a string, number, boolean or a list of those"},
+		{"table in list", "guild_ids = [{ id = \"g1\" }]", nil, "guild_ids must be"},
+		{"no value", "bot_token", nil, "line 1"},
+		{"duplicate", "log_level = \"info\"\nlog_level = \"debug\"", nil, "line 2"},
+		{"bare word", "log_level = info", nil, "line 1"},
+		{"unterminated array", "guild_ids = [\"a\",", nil, "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readTOML([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("readTOML() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readTOML() returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readTOML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
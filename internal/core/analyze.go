@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+// FormIdentity is one verb, tense and person a conjugated form belongs to.
+type FormIdentity struct {
+	Infinitive string
+	// English is the translation of the conjugation, such as "I speak".
+	English   string
+	TenseName string
+	Mood      string
+	Tense     string
+	// Label names the person, such as "yo" or "Ud.".
+	Label string
+}
+
+// AnalyzedVerb is a conjugated verb found in a text.
+type AnalyzedVerb struct {
+	// Phrase is the verb as it appears in the text, with its clitics, e.g. "lo hemos visto".
+	Phrase string
+	// Form is the spelling verbs.db stores it under, e.g. "hemos visto".
+	Form string
+	// Matches lists every verb, tense and person Form belongs to, such as hablamos in the Present and the Preterite.
+	Matches []FormIdentity
+}
+
+// AnalysisResult lists the conjugated verbs of a text in order, each form once.
+type AnalysisResult struct {
+	Verbs []AnalyzedVerb
+	// More is set when the text has verbs beyond the limit asked for.
+	More bool
+}
+
+// Analyze finds up to limit conjugated verbs in text by looking up its words, grouped with their clitics and
+// auxiliaries by spanish.VerbPhrases, in the forms of verbs.db.
+func (s *Service) Analyze(ctx context.Context, text string, limit int) (AnalysisResult, error) {
+	var result AnalysisResult
+	seen := make(map[string]bool)
+	for _, phrase := range spanish.VerbPhrases(text) {
+		verb, found, err := s.identify(ctx, phrase)
+		if err != nil {
+			return result, err
+		}
+		if !found || seen[verb.Form] {
+			continue
+		}
+		if len(result.Verbs) == limit {
+			result.More = true
+			break
+		}
+		seen[verb.Form] = true
+		result.Verbs = append(result.Verbs, verb)
+	}
+	return result, nil
+}
+
+// identify looks up the candidates of phrase in turn and returns the first verbs.db knows.
+func (s *Service) identify(ctx context.Context, phrase spanish.VerbPhrase) (verb AnalyzedVerb, found bool, err error) {
+	for _, candidate := range phrase.Candidates {
+		matches, err := s.verbs.FindForm(ctx, candidate)
+		if errors.Is(err, db.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return AnalyzedVerb{}, false, err
+		}
+
+		verb = AnalyzedVerb{Phrase: phrase.Text, Form: candidate}
+		for _, m := range matches {
+			label := PersonLabels(m.Verb.Mood)[slices.Index(db.Persons, m.Person)]
+			verb.Matches = append(verb.Matches, FormIdentity{
+				Infinitive: m.Verb.Infinitive,
+				English:    db.NullStringToString(m.Verb.VerbEnglish),
+				TenseName:  spanish.TenseMoodName(spanish.TenseMood{Mood: m.Verb.Mood, Tense: m.Verb.Tense}),
+				Mood:       m.Verb.Mood,
+				Tense:      m.Verb.Tense,
+				Label:      label,
+			})
+		}
+		return verb, true, nil
+	}
+	return AnalyzedVerb{}, false, nil
+}
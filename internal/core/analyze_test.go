@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+func TestAnalyze(t *testing.T) {
+	verbs := append([]db.Verb{
+		{Infinitive: "ver", Mood: "Indicativo", Tense: "Presente perfecto", VerbEnglish: ns("I have seen"),
+			Form1s: ns("he visto"), Form2s: ns("has visto"), Form3s: ns("ha visto"), Form1p: ns("hemos visto"), Form2p: ns("habéis visto"), Form3p: ns("han visto")},
+	}, hablarImperativeTable...)
+	service := NewService(db.NewMemoryRepository(db.MemoryData{Verbs: verbs}), db.NewInfinitiveIndex(nil))
+
+	result, err := service.Analyze(context.Background(), "No lo hables: ¡lo hemos visto! Hablo, hablo.", 10)
+	if err != nil {
+		t.Fatalf("Analyze() returned an error: %v", err)
+	}
+	want := []AnalyzedVerb{
+		{Phrase: "no lo hables", Form: "no hables", Matches: []FormIdentity{
+			{Infinitive: "hablar", English: "Speak. Don't speak.", TenseName: "Negative Imperative", Mood: "Imperativo Negativo", Tense: "Presente", Label: "tú"},
+		}},
+		{Phrase: "lo hemos visto", Form: "hemos visto", Matches: []FormIdentity{
+			{Infinitive: "ver", English: "I have seen", TenseName: "Present perfect", Mood: "Indicativo", Tense: "Presente perfecto", Label: "nosotros"},
+		}},
+		{Phrase: "hablo", Form: "hablo", Matches: []FormIdentity{
+			{Infinitive: "hablar", TenseName: "Present", Mood: "Indicativo", Tense: "Presente", Label: "yo"},
+		}},
+	}
+	if !reflect.DeepEqual(result.Verbs, want) || result.More {
+		t.Errorf("Analyze() = %+v, want %+v", result, want)
+	}
+
+	result, err = service.Analyze(context.Background(), "hable usted", 1)
+	if err != nil {
+		t.Fatalf("Analyze() returned an error: %v", err)
+	}
+	if len(result.Verbs) != 1 || len(result.Verbs[0].Matches) != 3 || result.More {
+		t.Errorf("Analyze() = %+v, want hable as a command and twice in the subjunctive", result)
+	}
+
+	result, _ = service.Analyze(context.Background(), "hablo y hablas", 1)
+	if len(result.Verbs) != 1 || !result.More {
+		t.Errorf("Analyze() = %+v, want one verb and more", result)
+	}
+}
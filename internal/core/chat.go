@@ -0,0 +1,245 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+// ChatAdapter connects ChatBot to a chat platform. It delivers the commands users send and the buttons they press, and
+// posts and edits the bot's replies, rendering them as the platform allows: a platform without buttons may offer
+// them as numbered choices, for instance.
+type ChatAdapter interface {
+	// Receive calls handle for every command and button press until ctx is done or receiving fails for good.
+	Receive(ctx context.Context, handle func(ctx context.Context, event ChatEvent) error) error
+	// Reply answers event with a new message.
+	Reply(ctx context.Context, event ChatEvent, reply ChatReply) (MessageRef, error)
+	// Edit replaces a message the bot sent.
+	Edit(ctx context.Context, msg MessageRef, reply ChatReply) error
+}
+
+// MessageRef identifies a message on a chat platform.
+type MessageRef struct {
+	// Conversation is the room, channel or chat the message is in.
+	Conversation string
+	ID           string
+}
+
+// ChatEvent is a command a user sent, or a button of one of the bot's messages they pressed.
+type ChatEvent struct {
+	// Message is the message of the command, or the bot's message holding the pressed button.
+	Message MessageRef
+	// Sender identifies the user on the platform.
+	Sender string
+	// Command and Args are set for commands, e.g. "conjugate" and ["tener", "present", "subjunctive"].
+	Command string
+	Args    []string
+	// Button is the Data of the pressed button, and empty for commands.
+	Button string
+}
+
+// ChatReply is a message of the bot: text, an optional table, notes such as example sentences below it and buttons.
+type ChatReply struct {
+	Text    string
+	Table   *Table
+	Notes   []string
+	Buttons []Button
+}
+
+// Table is a table of text cells under a header.
+type Table struct {
+	Header []string
+	Rows   [][]Cell
+}
+
+// Cell is a table cell. Strong cells, such as irregular forms, are emphasized.
+type Cell struct {
+	Text   string
+	Strong bool
+}
+
+// Button is a choice offered under a reply. Data comes back in the ChatEvent of a press; platforms may limit its length
+// to 64 bytes.
+type Button struct {
+	Label string
+	Data  string
+}
+
+const (
+	chatHelp = "Commands: conjugate <infinitive> [tense] shows a conjugation; without a tense, pick one. " +
+		"imperative <infinitive> shows the commands. help shows this message."
+	chatChooseTense = "Choose a tense for %s:"
+
+	errChatInfinitiveMissing = "Infinitive not provided. Try: conjugate hablar"
+	errChatUnknownTense      = "Unknown tense %q. Send conjugate with only the infinitive to pick one."
+	errChatStaleButton       = "This choice no longer works, send conjugate again."
+
+	// tenseButtonPrefix starts the data of the tense buttons, "t:<choice index>:<infinitive>".
+	tenseButtonPrefix = "t:"
+	// chatExamples is the number of example sentences shown with a conjugation.
+	chatExamples = 3
+)
+
+// ChatBot answers the commands of any chat platform through its ChatAdapter, so a new platform only needs an adapter.
+type ChatBot struct {
+	service *Service
+	adapter ChatAdapter
+}
+
+// NewChatBot creates a bot that answers the commands adapter receives with lookups of service.
+func NewChatBot(service *Service, adapter ChatAdapter) *ChatBot {
+	return &ChatBot{service: service, adapter: adapter}
+}
+
+// Run answers commands until ctx is done or the adapter stops receiving.
+func (b *ChatBot) Run(ctx context.Context) error {
+	return b.adapter.Receive(ctx, b.Handle)
+}
+
+// Handle answers event. It returns an error only when the reply could not be sent; failed lookups are answered with an
+// explanation.
+func (b *ChatBot) Handle(ctx context.Context, event ChatEvent) error {
+	if event.Button != "" {
+		return b.handleButton(ctx, event)
+	}
+	switch strings.ToLower(event.Command) {
+	case "conjugate":
+		return b.handleConjugate(ctx, event)
+	case "imperative":
+		return b.handleImperative(ctx, event)
+	case "help", "start":
+		return b.reply(ctx, event, ChatReply{Text: chatHelp})
+	}
+	return nil
+}
+
+func (b *ChatBot) reply(ctx context.Context, event ChatEvent, reply ChatReply) error {
+	_, err := b.adapter.Reply(ctx, event, reply)
+	return err
+}
+
+func (b *ChatBot) handleConjugate(ctx context.Context, event ChatEvent) error {
+	if len(event.Args) == 0 {
+		return b.reply(ctx, event, ChatReply{Text: errChatInfinitiveMissing})
+	}
+	input, tenseName := event.Args[0], strings.Join(event.Args[1:], " ")
+
+	if tenseName == "" {
+		infinitive, err := b.service.Resolve(input)
+		if err != nil {
+			return b.reply(ctx, event, ChatReply{Text: LookupErrorMessage(ctx, input, err, nil)})
+		}
+		return b.reply(ctx, event, ChatReply{Text: fmt.Sprintf(chatChooseTense, infinitive), Buttons: TenseButtons(infinitive, "")})
+	}
+
+	if _, ok := spanish.LookupChoice(tenseName); !ok {
+		return b.reply(ctx, event, ChatReply{Text: fmt.Sprintf(errChatUnknownTense, tenseName)})
+	}
+	result, err := b.conjugate(ctx, input, tenseName)
+	if err != nil {
+		return b.reply(ctx, event, ChatReply{Text: LookupErrorMessage(ctx, input, err, nil)})
+	}
+	return b.reply(ctx, event, ConjugationReply(result))
+}
+
+func (b *ChatBot) handleImperative(ctx context.Context, event ChatEvent) error {
+	if len(event.Args) == 0 {
+		return b.reply(ctx, event, ChatReply{Text: errChatInfinitiveMissing})
+	}
+	result, err := b.service.Imperative(ctx, event.Args[0])
+	if err != nil {
+		return b.reply(ctx, event, ChatReply{Text: LookupErrorMessage(ctx, event.Args[0], err, nil)})
+	}
+	return b.reply(ctx, event, ImperativeReply(result))
+}
+
+// handleButton edits the message of a pressed tense button into that conjugation.
+func (b *ChatBot) handleButton(ctx context.Context, event ChatEvent) error {
+	choice, infinitive, ok := ParseTenseButton(event.Button)
+	if !ok {
+		return b.reply(ctx, event, ChatReply{Text: errChatStaleButton})
+	}
+	result, err := b.conjugate(ctx, infinitive, choice.Name)
+	if err != nil {
+		return b.reply(ctx, event, ChatReply{Text: LookupErrorMessage(ctx, infinitive, err, nil)})
+	}
+	return b.adapter.Edit(ctx, event.Message, ConjugationReply(result))
+}
+
+// conjugate conjugates input in the tense named tenseName with examples. A failed examples lookup is logged and the
+// conjugation returned without them.
+func (b *ChatBot) conjugate(ctx context.Context, input, tenseName string) (ConjugationResult, error) {
+	result, err := b.service.Conjugate(ctx, input, tenseName, chatExamples)
+	if err != nil && result.Infinitive != "" {
+		logging.FromContext(ctx).ErrorContext(ctx, "fetching examples", "error", err)
+		return result, nil
+	}
+	return result, err
+}
+
+// ConjugationReply shows r as a table of persons and forms, irregular forms strong, with its examples as notes and
+// buttons to switch tenses.
+func ConjugationReply(r ConjugationResult) ChatReply {
+	reply := ChatReply{
+		Text:    title(r.Infinitive, r.English) + "\n" + r.Heading(),
+		Table:   &Table{Header: []string{"persona", "forma"}},
+		Buttons: TenseButtons(r.Infinitive, r.TenseName),
+	}
+	for _, f := range r.Forms {
+		reply.Table.Rows = append(reply.Table.Rows, []Cell{{Text: f.Label}, {Text: f.Form, Strong: f.Irregular}})
+	}
+	for _, ex := range r.Examples {
+		note := ex.Sentence
+		if ex.English != "" {
+			note += " — " + ex.English
+		}
+		reply.Notes = append(reply.Notes, note)
+	}
+	return reply
+}
+
+// ImperativeReply shows r as a table of affirmative and negative commands.
+func ImperativeReply(r ImperativeResult) ChatReply {
+	reply := ChatReply{
+		Text:  title(r.Infinitive, r.English),
+		Table: &Table{Header: []string{"persona", "afirmativo", "negativo", "presente de subjuntivo"}},
+	}
+	for _, row := range r.Rows {
+		reply.Table.Rows = append(reply.Table.Rows, []Cell{{Text: row.Person}, {Text: row.Affirmative}, {Text: row.Negative}, {Text: row.Subjunctive}})
+	}
+	return reply
+}
+
+// TenseButtons offers every tense of spanish.TenseMoodChoices for infinitive, marking the one named current.
+func TenseButtons(infinitive, current string) []Button {
+	buttons := make([]Button, len(spanish.TenseMoodChoices))
+	for i, choice := range spanish.TenseMoodChoices {
+		label := choice.Name
+		if choice.Name == current {
+			label = "✓ " + label
+		}
+		buttons[i] = Button{Label: label, Data: tenseButtonPrefix + strconv.Itoa(i) + ":" + infinitive}
+	}
+	return buttons
+}
+
+// ParseTenseButton reads the Data of a button of TenseButtons.
+func ParseTenseButton(data string) (choice spanish.TenseMoodChoice, infinitive string, ok bool) {
+	rest, ok := strings.CutPrefix(data, tenseButtonPrefix)
+	if !ok {
+		return choice, "", false
+	}
+	index, infinitive, ok := strings.Cut(rest, ":")
+	if !ok || infinitive == "" {
+		return choice, "", false
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= len(spanish.TenseMoodChoices) {
+		return choice, "", false
+	}
+	return spanish.TenseMoodChoices[i], infinitive, true
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// recordingAdapter is a ChatAdapter that delivers a fixed list of events and records the replies and edits.
+type recordingAdapter struct {
+	events  []ChatEvent
+	replies []ChatReply
+	edits   map[MessageRef]ChatReply
+}
+
+func (a *recordingAdapter) Receive(ctx context.Context, handle func(ctx context.Context, event ChatEvent) error) error {
+	for _, event := range a.events {
+		if err := handle(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *recordingAdapter) Reply(ctx context.Context, event ChatEvent, reply ChatReply) (MessageRef, error) {
+	a.replies = append(a.replies, reply)
+	return MessageRef{Conversation: event.Message.Conversation, ID: "reply"}, nil
+}
+
+func (a *recordingAdapter) Edit(ctx context.Context, msg MessageRef, reply ChatReply) error {
+	if a.edits == nil {
+		a.edits = map[MessageRef]ChatReply{}
+	}
+	a.edits[msg] = reply
+	return nil
+}
+
+func TestChatBot(t *testing.T) {
+	room := MessageRef{Conversation: "!room", ID: "$command"}
+	tests := []struct {
+		name      string
+		event     ChatEvent
+		wantReply string
+		check     func(t *testing.T, a *recordingAdapter)
+	}{
+		{"tense picker", ChatEvent{Message: room, Command: "conjugate", Args: []string{"HABLAR"}}, "Choose a tense for hablar:",
+			func(t *testing.T, a *recordingAdapter) {
+				if got := a.replies[0].Buttons; len(got) != 18 || got[1].Data != "t:1:hablar" {
+					t.Errorf("Buttons = %+v, want every tense for hablar", got)
+				}
+			}},
+		{"conjugation", ChatEvent{Message: room, Command: "Conjugate", Args: []string{"pensar", "present"}}, "pensar – I think\nPresent (Indicativo Presente)",
+			func(t *testing.T, a *recordingAdapter) {
+				table := a.replies[0].Table
+				if table == nil || len(table.Rows) != 6 {
+					t.Fatalf("Table = %+v, want six persons", table)
+				}
+				if got := table.Rows[0][1]; got != (Cell{Text: "pienso", Strong: true}) {
+					t.Errorf("Cell = %+v, want pienso marked irregular", got)
+				}
+				if got := a.replies[0].Buttons[0].Label; got != "✓ Present" {
+					t.Errorf("Button = %q, want the current tense checked", got)
+				}
+			}},
+		{"examples", ChatEvent{Message: room, Command: "conjugate", Args: []string{"hablar", "Present"}}, "hablar – I speak\nPresent (Indicativo Presente)",
+			func(t *testing.T, a *recordingAdapter) {
+				if got := a.replies[0].Notes; len(got) != 2 || got[0] != "Hablo español. — I speak Spanish." {
+					t.Errorf("Notes = %q, want both examples", got)
+				}
+			}},
+		{"imperative", ChatEvent{Message: room, Command: "imperative", Args: []string{"hablar"}}, "hablar",
+			func(t *testing.T, a *recordingAdapter) {
+				if table := a.replies[0].Table; table == nil || len(table.Header) != 4 || len(table.Rows) != 5 {
+					t.Errorf("Table = %+v, want five persons with three forms each", table)
+				}
+			}},
+		{"button", ChatEvent{Message: room, Button: "t:0:pensar"}, "",
+			func(t *testing.T, a *recordingAdapter) {
+				if got := a.edits[room]; !strings.HasPrefix(got.Text, "pensar – I think") {
+					t.Errorf("Edit = %+v, want the message edited into the Present of pensar", got)
+				}
+			}},
+		{"stale button", ChatEvent{Message: room, Button: "t:99:pensar"}, errChatStaleButton, nil},
+		{"missing infinitive", ChatEvent{Message: room, Command: "conjugate"}, errChatInfinitiveMissing, nil},
+		{"unknown tense", ChatEvent{Message: room, Command: "conjugate", Args: []string{"hablar", "pasado"}}, `Unknown tense "pasado".`, nil},
+		{"unknown verb", ChatEvent{Message: room, Command: "conjugate", Args: []string{"blorp"}}, errVerbNotFound, nil},
+		{"ambiguous verb", ChatEvent{Message: room, Command: "conjugate", Args: []string{"sónar"}}, "Did you mean sonar or soñar?", nil},
+		{"help", ChatEvent{Message: room, Command: "help"}, "Commands:", nil},
+		{"unknown command", ChatEvent{Message: room, Command: "dance"}, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &recordingAdapter{events: []ChatEvent{tt.event}}
+			if err := NewChatBot(newTestService(), adapter).Run(context.Background()); err != nil {
+				t.Fatalf("Run() returned an error: %v", err)
+			}
+			if tt.wantReply == "" && len(adapter.replies) > 0 {
+				t.Errorf("Replies = %+v, want none", adapter.replies)
+			}
+			if tt.wantReply != "" && (len(adapter.replies) != 1 || !strings.HasPrefix(adapter.replies[0].Text, tt.wantReply)) {
+				t.Fatalf("Replies = %+v, want one starting with %q", adapter.replies, tt.wantReply)
+			}
+			if tt.check != nil {
+				tt.check(t, adapter)
+			}
+		})
+	}
+}
+
+func TestParseTenseButton(t *testing.T) {
+	for _, button := range TenseButtons("hablar", "") {
+		choice, infinitive, ok := ParseTenseButton(button.Data)
+		if !ok || infinitive != "hablar" || choice.Name != button.Label {
+			t.Errorf("ParseTenseButton(%q) = %v, %q, %v, want %s for hablar", button.Data, choice, infinitive, ok, button.Label)
+		}
+	}
+	for _, data := range []string{"", "t:", "t:1", "t:99:hablar", "t:x:hablar", "x:1:hablar"} {
+		if _, _, ok := ParseTenseButton(data); ok {
+			t.Errorf("ParseTenseButton(%q) succeeded, want it rejected", data)
+		}
+	}
+}
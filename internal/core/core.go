@@ -0,0 +1,161 @@
+// Package core answers conjugation lookups independently of any chat platform. Its Service resolves what users type
+// and returns neutral results that each front end turns into its own messages through a Renderer, so the Discord bot,
+// the CLI and other front ends share one behavior.
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+var (
+	// ErrUnknownVerb is returned when what the user typed is no infinitive of verbs.db.
+	ErrUnknownVerb = errors.New("unknown verb")
+	// ErrUnknownTense is returned for a tense name spanish.TenseMoodChoices does not offer.
+	ErrUnknownTense = errors.New("unknown tense")
+	// ErrIncompleteData is returned when verbs.db lacks rows a result is built from.
+	ErrIncompleteData = errors.New("incomplete verb data")
+)
+
+// Service looks up conjugations for every front end.
+type Service struct {
+	verbs       db.VerbRepository
+	infinitives *db.InfinitiveIndex
+}
+
+// NewService creates a service that reads verb data from verbs and resolves the infinitives users type through
+// infinitives.
+func NewService(verbs db.VerbRepository, infinitives *db.InfinitiveIndex) *Service {
+	return &Service{verbs: verbs, infinitives: infinitives}
+}
+
+// Resolve maps the infinitive a user typed to its spelling in verbs.db, ignoring case and accents. It returns an error
+// wrapping ErrUnknownVerb when nothing matches and a *db.AmbiguousInfinitiveError when several verbs do.
+func (s *Service) Resolve(input string) (string, error) {
+	infinitive, err := s.infinitives.Resolve(input)
+	if errors.Is(err, db.ErrNotFound) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownVerb, input)
+	}
+	return infinitive, err
+}
+
+// Conjugate returns the forms of input in the tense named tenseName, with up to examples example sentences.
+func (s *Service) Conjugate(ctx context.Context, input, tenseName string, examples int) (ConjugationResult, error) {
+	choice, ok := spanish.LookupChoice(tenseName)
+	if !ok {
+		return ConjugationResult{}, fmt.Errorf("%w: %q", ErrUnknownTense, tenseName)
+	}
+	tm := choice.Value
+	infinitive, err := s.Resolve(input)
+	if err != nil {
+		return ConjugationResult{}, err
+	}
+
+	verb, err := s.verbs.GetVerb(ctx, infinitive, tm.Mood, tm.Tense)
+	if err != nil {
+		return ConjugationResult{}, err
+	}
+	result := NewConjugationResult(verb)
+	result.TenseName = choice.Name
+	if level, ok := s.infinitives.Level(infinitive); ok {
+		result.Level, result.Rank = level.Level, level.Rank
+	}
+	if examples <= 0 {
+		return result, nil
+	}
+
+	// One more example than shown tells whether there are more. Examples are optional, so a failed lookup still
+	// returns the conjugation, along with the error.
+	found, err := s.verbs.GetExamples(ctx, infinitive, tm.Mood, tm.Tense, examples+1)
+	result.Examples = newExamples(found)
+	if len(result.Examples) > examples {
+		result.Examples, result.MoreExamples = result.Examples[:examples], true
+	}
+	return result, err
+}
+
+// Table returns every conjugation of input in tenses, or in every tense of verbs.db when tenses is empty, in the order
+// of spanish.TenseMoodChoices followed by the tenses no choice offers.
+func (s *Service) Table(ctx context.Context, input string, tenses []spanish.TenseMood) (TableResult, error) {
+	infinitive, err := s.Resolve(input)
+	if err != nil {
+		return TableResult{}, err
+	}
+	verbs, err := s.verbs.GetVerbs(ctx, infinitive)
+	if err != nil {
+		return TableResult{}, err
+	}
+
+	table := TableResult{Infinitive: infinitive}
+	if g, err := s.verbs.GetGerund(ctx, infinitive); err == nil {
+		table.Gerund = g.Gerund
+	}
+	if p, err := s.verbs.GetPastparticiple(ctx, infinitive); err == nil {
+		table.PastParticiple = p.Pastparticiple
+	}
+
+	byTense := make(map[spanish.TenseMood]db.Verb, len(verbs))
+	var order []spanish.TenseMood
+	for _, choice := range spanish.TenseMoodChoices {
+		order = append(order, choice.Value)
+	}
+	for _, v := range verbs {
+		tm := spanish.TenseMood{Mood: v.Mood, Tense: v.Tense}
+		byTense[tm] = v
+		order = append(order, tm)
+	}
+
+	selected := make(map[spanish.TenseMood]bool, len(tenses))
+	for _, tm := range tenses {
+		selected[tm] = true
+	}
+	seen := make(map[spanish.TenseMood]bool, len(order))
+	for _, tm := range order {
+		v, ok := byTense[tm]
+		if !ok || seen[tm] || len(selected) > 0 && !selected[tm] {
+			continue
+		}
+		seen[tm] = true
+		table.Conjugations = append(table.Conjugations, NewConjugationResult(v))
+	}
+	return table, nil
+}
+
+// Imperative returns the affirmative and negative commands of input side by side.
+func (s *Service) Imperative(ctx context.Context, input string) (ImperativeResult, error) {
+	infinitive, err := s.Resolve(input)
+	if err != nil {
+		return ImperativeResult{}, err
+	}
+	verbs, err := s.verbs.GetVerbs(ctx, infinitive)
+	if err != nil {
+		return ImperativeResult{}, err
+	}
+
+	forms, err := findImperativeForms(infinitive, verbs)
+	if err != nil {
+		return ImperativeResult{}, err
+	}
+	return ImperativeResult{
+		Infinitive: infinitive,
+		English:    db.NullStringToString(forms.affirmative.VerbEnglish),
+		Rows:       buildImperativeRows(forms),
+	}, nil
+}
+
+// Examples returns up to limit example sentences of infinitive, as verbs.db spells it, in the tense named tenseName.
+func (s *Service) Examples(ctx context.Context, infinitive, tenseName string, limit int) (ExamplesResult, error) {
+	choice, ok := spanish.LookupChoice(tenseName)
+	if !ok {
+		return ExamplesResult{}, fmt.Errorf("%w: %q", ErrUnknownTense, tenseName)
+	}
+	examples, err := s.verbs.GetExamples(ctx, infinitive, choice.Value.Mood, choice.Value.Tense, limit)
+	if err != nil {
+		return ExamplesResult{}, err
+	}
+	return ExamplesResult{Infinitive: infinitive, TenseName: choice.Name, Examples: newExamples(examples)}, nil
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+func newTestService() *Service {
+	infinitives := []db.Infinitive{{Infinitive: "hablar"}, {Infinitive: "pensar"}, {Infinitive: "sonar"}, {Infinitive: "soñar"}}
+	verbs := append([]db.Verb{
+		{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", VerbEnglish: ns("I speak"),
+			Form1s: ns("hablo"), Form2s: ns("hablas"), Form3s: ns("habla"), Form1p: ns("hablamos"), Form2p: ns("habláis"), Form3p: ns("hablan")},
+		{Infinitive: "pensar", Mood: "Indicativo", Tense: "Presente", VerbEnglish: ns("I think"),
+			Form1s: ns("pienso"), Form2s: ns("piensas"), Form3s: ns("piensa"), Form1p: ns("pensamos"), Form2p: ns("pensáis"), Form3p: ns("piensan")},
+	}, hablarImperativeTable...)
+	return NewService(db.NewMemoryRepository(db.MemoryData{
+		Infinitives: infinitives,
+		Verbs:       verbs,
+		Gerunds:     []db.Gerund{{Infinitive: "hablar", Gerund: "hablando"}},
+		Examples: []db.Example{
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", Person: "1s", Sentence: "Hablo español.", SentenceEnglish: ns("I speak Spanish.")},
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", Person: "3s", Sentence: "Habla poco."},
+		},
+	}), db.NewInfinitiveIndex(infinitives).WithLevels(map[string]db.VerbLevel{"hablar": {Rank: 12, Level: "A1"}}))
+}
+
+func TestConjugate(t *testing.T) {
+	service := newTestService()
+
+	result, err := service.Conjugate(context.Background(), "PENSAR", "present", 2)
+	if err != nil {
+		t.Fatalf("Conjugate() returned an error: %v", err)
+	}
+	if result.Infinitive != "pensar" || result.TenseName != "Present" || result.English != "I think" {
+		t.Errorf("Conjugate() = %+v, want pensar in the Present", result)
+	}
+	if len(result.Forms) != 6 {
+		t.Fatalf("Expected 6 forms, got %d", len(result.Forms))
+	}
+	wantIrregular := []bool{true, true, true, false, false, true}
+	for i, f := range result.Forms {
+		if f.Irregular != wantIrregular[i] {
+			t.Errorf("Form %s (%s): Irregular = %v, want %v", f.Label, f.Form, f.Irregular, wantIrregular[i])
+		}
+	}
+
+	result, err = service.Conjugate(context.Background(), "hablar", "Present", 1)
+	if err != nil {
+		t.Fatalf("Conjugate() returned an error: %v", err)
+	}
+	if len(result.Examples) != 1 || !result.MoreExamples || result.Examples[0].English != "I speak Spanish." {
+		t.Errorf("Conjugate() examples = %+v, more = %v, want one example and more", result.Examples, result.MoreExamples)
+	}
+	if result.Level != "A1" || result.Rank != 12 {
+		t.Errorf("Conjugate() level = %q, rank %d, want A1, rank 12", result.Level, result.Rank)
+	}
+
+	result, err = service.Conjugate(context.Background(), "hablar", "Imperative", 0)
+	if err != nil {
+		t.Fatalf("Conjugate() returned an error: %v", err)
+	}
+	if got := result.Forms[0]; len(result.Forms) != 4 || got.Label != "tú" || got.Form != "habla" {
+		t.Errorf("Conjugate() imperative forms = %+v, want tú, vosotros, Ud. and Uds.", result.Forms)
+	}
+}
+
+func TestConjugateErrors(t *testing.T) {
+	service := newTestService()
+	ctx := context.Background()
+
+	if _, err := service.Conjugate(ctx, "blorp", "Present", 0); !errors.Is(err, ErrUnknownVerb) {
+		t.Errorf("Conjugate(unknown verb) error = %v, want ErrUnknownVerb", err)
+	}
+	if _, err := service.Conjugate(ctx, "hablar", "Pasado", 0); !errors.Is(err, ErrUnknownTense) {
+		t.Errorf("Conjugate(unknown tense) error = %v, want ErrUnknownTense", err)
+	}
+	if _, err := service.Conjugate(ctx, "hablar", "Future", 0); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Conjugate(missing row) error = %v, want db.ErrNotFound", err)
+	}
+	var ambiguous *db.AmbiguousInfinitiveError
+	if _, err := service.Conjugate(ctx, "sonár", "Present", 0); !errors.As(err, &ambiguous) {
+		t.Errorf("Conjugate(ambiguous verb) error = %v, want an AmbiguousInfinitiveError", err)
+	}
+	if _, err := service.Imperative(ctx, "pensar"); !errors.Is(err, ErrIncompleteData) {
+		t.Errorf("Imperative(no imperative rows) error = %v, want ErrIncompleteData", err)
+	}
+}
+
+func TestTable(t *testing.T) {
+	service := newTestService()
+
+	table, err := service.Table(context.Background(), "hablar", nil)
+	if err != nil {
+		t.Fatalf("Table() returned an error: %v", err)
+	}
+	var names []string
+	for _, c := range table.Conjugations {
+		names = append(names, c.TenseName)
+	}
+	want := []string{"Present", "Present subjunctive", "Imperative", "Negative Imperative"}
+	if table.Gerund != "hablando" || len(names) != len(want) {
+		t.Fatalf("Table() = %v with gerund %q, want %v", names, table.Gerund, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Table() tense %d = %q, want %q", i, names[i], want[i])
+		}
+	}
+
+	table, err = service.Table(context.Background(), "hablar", []spanish.TenseMood{{Mood: "Subjuntivo", Tense: "Presente"}})
+	if err != nil || len(table.Conjugations) != 1 || table.Conjugations[0].Mood != "Subjuntivo" {
+		t.Errorf("Table(present subjunctive) = %+v, %v, want only the present subjunctive", table.Conjugations, err)
+	}
+}
+
+func TestImperative(t *testing.T) {
+	result, err := newTestService().Imperative(context.Background(), "Hablar")
+	if err != nil {
+		t.Fatalf("Imperative() returned an error: %v", err)
+	}
+	if result.Infinitive != "hablar" || result.English != "Speak. Don't speak." || len(result.Rows) != 5 {
+		t.Errorf("Imperative() = %+v, want the five rows of hablar", result)
+	}
+}
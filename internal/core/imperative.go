@@ -0,0 +1,105 @@
+package core
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	moodImperativeAffirmative = "Imperativo Afirmativo"
+	moodImperativeNegative    = "Imperativo Negativo"
+	moodSubjunctive           = "Subjuntivo"
+	tensePresent              = "Presente"
+
+	negationPrefix = "no "
+
+	errImperativeMissing = "%w: missing %s %s rows for %s"
+)
+
+// ImperativeRow pairs the affirmative and negative command for one person with the present subjunctive form
+// the command is built from.
+type ImperativeRow struct {
+	Person      string
+	Affirmative string
+	Negative    string
+	Subjunctive string
+}
+
+// imperativeForms holds the three rows needed to build the imperative view of a verb.
+type imperativeForms struct {
+	affirmative *db.Verb
+	negative    *db.Verb
+	subjunctive *db.Verb
+}
+
+// findImperativeForms picks the affirmative, negative and present subjunctive rows out of a verb's full table.
+func findImperativeForms(infinitive string, verbs []db.Verb) (imperativeForms, error) {
+	var forms imperativeForms
+	for i := range verbs {
+		v := &verbs[i]
+		if v.Tense != tensePresent {
+			continue
+		}
+		switch v.Mood {
+		case moodImperativeAffirmative:
+			forms.affirmative = v
+		case moodImperativeNegative:
+			forms.negative = v
+		case moodSubjunctive:
+			forms.subjunctive = v
+		}
+	}
+
+	switch {
+	case forms.affirmative == nil:
+		return forms, fmt.Errorf(errImperativeMissing, ErrIncompleteData, moodImperativeAffirmative, tensePresent, infinitive)
+	case forms.negative == nil:
+		return forms, fmt.Errorf(errImperativeMissing, ErrIncompleteData, moodImperativeNegative, tensePresent, infinitive)
+	case forms.subjunctive == nil:
+		return forms, fmt.Errorf(errImperativeMissing, ErrIncompleteData, moodSubjunctive, tensePresent, infinitive)
+	}
+	return forms, nil
+}
+
+// buildImperativeRows lines up the imperative forms of a verb by person. The imperative rows in verbs.db keep tú in
+// form_2s, vosotros in form_3s, Ud. in form_2p and Uds. in form_3p, and leave the yo and nosotros slots empty, so the
+// nosotros command is filled in from the present subjunctive.
+func buildImperativeRows(forms imperativeForms) []ImperativeRow {
+	aff, neg, subj := forms.affirmative, forms.negative, forms.subjunctive
+
+	rows := []ImperativeRow{
+		{Person: "tú", Affirmative: nullString(aff.Form2s), Negative: nullString(neg.Form2s), Subjunctive: nullString(subj.Form2s)},
+		{Person: "Ud.", Affirmative: nullString(aff.Form2p), Negative: nullString(neg.Form2p), Subjunctive: nullString(subj.Form3s)},
+		{Person: "nosotros", Affirmative: nullString(aff.Form1p), Negative: nullString(neg.Form1p), Subjunctive: nullString(subj.Form1p)},
+		{Person: "vosotros", Affirmative: nullString(aff.Form3s), Negative: nullString(neg.Form3s), Subjunctive: nullString(subj.Form2p)},
+		{Person: "Uds.", Affirmative: nullString(aff.Form3p), Negative: nullString(neg.Form3p), Subjunctive: nullString(subj.Form3p)},
+	}
+
+	for i := range rows {
+		if rows[i].Affirmative == "" {
+			rows[i].Affirmative = rows[i].Subjunctive
+		}
+		if rows[i].Negative == "" {
+			rows[i].Negative = rows[i].Subjunctive
+		}
+		rows[i].Negative = negate(rows[i].Negative)
+	}
+	return rows
+}
+
+// negate prefixes a command with "no" unless it already has it.
+func negate(form string) string {
+	form = strings.TrimSpace(form)
+	if form == "" || strings.HasPrefix(form, negationPrefix) {
+		return form
+	}
+	return negationPrefix + form
+}
+
+// nullString trims a nullable form.
+func nullString(s sql.NullString) string {
+	return strings.TrimSpace(db.NullStringToString(s))
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+func ns(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
+
+// hablarImperativeTable mirrors the layout of the imperative and present subjunctive rows in verbs.db.
+var hablarImperativeTable = []db.Verb{
+	{Infinitive: "hablar", Mood: "Imperativo Afirmativo", Tense: "Presente", VerbEnglish: ns("Speak. Don't speak."),
+		Form1s: ns(""), Form2s: ns("habla"), Form3s: ns("hablad"), Form1p: ns(""), Form2p: ns("hable"), Form3p: ns("hablen")},
+	{Infinitive: "hablar", Mood: "Imperativo Negativo", Tense: "Presente", VerbEnglish: ns("Speak. Don't speak."),
+		Form1s: ns(""), Form2s: ns("no hables"), Form3s: ns("habléis"), Form1p: ns(""), Form2p: ns("no hable"), Form3p: ns("no hablen")},
+	{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente",
+		Form1s: ns("hablo"), Form2s: ns("hablas"), Form3s: ns("habla"), Form1p: ns("hablamos"), Form2p: ns("habláis"), Form3p: ns("hablan")},
+	{Infinitive: "hablar", Mood: "Subjuntivo", Tense: "Presente",
+		Form1s: ns("hable"), Form2s: ns("hables"), Form3s: ns("hable"), Form1p: ns("hablemos"), Form2p: ns("habléis"), Form3p: ns("hablen")},
+}
+
+func TestBuildImperativeRows(t *testing.T) {
+	forms, err := findImperativeForms("hablar", hablarImperativeTable)
+	if err != nil {
+		t.Fatalf("findImperativeForms() returned an error: %v", err)
+	}
+
+	expected := []ImperativeRow{
+		{Person: "tú", Affirmative: "habla", Negative: "no hables", Subjunctive: "hables"},
+		{Person: "Ud.", Affirmative: "hable", Negative: "no hable", Subjunctive: "hable"},
+		{Person: "nosotros", Affirmative: "hablemos", Negative: "no hablemos", Subjunctive: "hablemos"},
+		{Person: "vosotros", Affirmative: "hablad", Negative: "no habléis", Subjunctive: "habléis"},
+		{Person: "Uds.", Affirmative: "hablen", Negative: "no hablen", Subjunctive: "hablen"},
+	}
+
+	rows := buildImperativeRows(forms)
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i, row := range expected {
+		if rows[i] != row {
+			t.Errorf("For index %d, expected %+v, got %+v", i, row, rows[i])
+		}
+	}
+}
+
+func TestFindImperativeFormsMissingRows(t *testing.T) {
+	if _, err := findImperativeForms("hablar", hablarImperativeTable[2:]); err == nil {
+		t.Error("Expected an error when the imperative rows are missing")
+	}
+}
+
+func TestNegate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"hables", "no hables"},
+		{"no hables", "no hables"},
+		{" hables ", "no hables"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negate(tt.input); got != tt.expected {
+			t.Errorf("negate(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
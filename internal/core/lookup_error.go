@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+const (
+	errVerbNotFound     = "Verb not found."
+	errAmbiguousVerb    = "Did you mean %s?"
+	errTenseData        = "Error getting tense data."
+	errImperativeData   = "Error building imperative forms."
+	errQueryingDatabase = "Error querying database."
+	errLookupTimeout    = "The lookup took too long, please try again."
+)
+
+// LookupErrorMessage explains to the user a failed lookup of the verb they typed as input, on any platform. Input that
+// is no infinitive is counted by metrics.VerbNotFound, and the errors worth a look are logged. emphasize marks up the
+// candidates of an ambiguous input, e.g. in bold, and may be nil; the rest of the message is plain text.
+func LookupErrorMessage(ctx context.Context, input string, err error, emphasize func(string) string) string {
+	logger := logging.FromContext(ctx)
+	var ambiguous *db.AmbiguousInfinitiveError
+	switch {
+	case errors.As(err, &ambiguous):
+		return FormatAmbiguousInfinitive(ambiguous.Candidates, emphasize)
+	case errors.Is(err, ErrUnknownVerb):
+		metrics.VerbNotFound(spanish.Normalize(input))
+		return errVerbNotFound
+	case errors.Is(err, db.ErrNotFound):
+		// The repository counts the infinitives it has no rows for.
+		return errVerbNotFound
+	case errors.Is(err, ErrUnknownTense):
+		return errTenseData
+	case errors.Is(err, ErrIncompleteData):
+		logger.ErrorContext(ctx, "building imperative", "error", err)
+		return errImperativeData
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		logger.WarnContext(ctx, "verb lookup interrupted", "error", err)
+		return errLookupTimeout
+	default:
+		logger.ErrorContext(ctx, "fetching verb", "error", err)
+		return errQueryingDatabase
+	}
+}
+
+// FormatAmbiguousInfinitive asks the user to pick one of the candidates, e.g. "Did you mean sonar or soñar?", marking
+// up each with emphasize unless it is nil.
+func FormatAmbiguousInfinitive(candidates []string, emphasize func(string) string) string {
+	quoted := make([]string, len(candidates))
+	for i, c := range candidates {
+		if emphasize != nil {
+			c = emphasize(c)
+		}
+		quoted[i] = c
+	}
+	last := len(quoted) - 1
+	if last == 0 {
+		return fmt.Sprintf(errAmbiguousVerb, quoted[0])
+	}
+	return fmt.Sprintf(errAmbiguousVerb, strings.Join(quoted[:last], ", ")+" or "+quoted[last])
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+)
+
+// notFoundCount reads verb_not_found_total for infinitive from the metrics registry.
+func notFoundCount(t *testing.T, infinitive string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() returned an error: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "conjugador_verb_not_found_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "infinitive" && label.GetValue() == infinitive {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestLookupErrorMessage(t *testing.T) {
+	ctx := context.Background()
+	bold := func(s string) string { return "<b>" + s + "</b>" }
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"ambiguous", &db.AmbiguousInfinitiveError{Input: "sonar", Candidates: []string{"sonar", "soñar"}}, "Did you mean <b>sonar</b> or <b>soñar</b>?"},
+		{"unknown verb", fmt.Errorf("%w: blorp", ErrUnknownVerb), errVerbNotFound},
+		{"no rows", db.ErrNotFound, errVerbNotFound},
+		{"unknown tense", ErrUnknownTense, errTenseData},
+		{"incomplete", ErrIncompleteData, errImperativeData},
+		{"timeout", context.DeadlineExceeded, errLookupTimeout},
+		{"other", errors.New("disk I/O error"), errQueryingDatabase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LookupErrorMessage(ctx, "Blorpar", tt.err, bold); got != tt.want {
+				t.Errorf("LookupErrorMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	before := notFoundCount(t, "blorpar")
+	LookupErrorMessage(ctx, "Blorpar", ErrUnknownVerb, nil)
+	if got := notFoundCount(t, "blorpar") - before; got != 1 {
+		t.Errorf("verb_not_found_total{infinitive=blorpar} increased by %v, want 1", got)
+	}
+}
+
+func TestFormatAmbiguousInfinitive(t *testing.T) {
+	bold := func(s string) string { return "**" + s + "**" }
+	tests := []struct {
+		name       string
+		candidates []string
+		emphasize  func(string) string
+		want       string
+	}{
+		{"one", []string{"sonar"}, bold, "Did you mean **sonar**?"},
+		{"two", []string{"sonar", "soñar"}, bold, "Did you mean **sonar** or **soñar**?"},
+		{"three", []string{"canar", "cañar", "cánar"}, bold, "Did you mean **canar**, **cañar** or **cánar**?"},
+		{"plain", []string{"sonar", "soñar"}, nil, "Did you mean sonar or soñar?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAmbiguousInfinitive(tt.candidates, tt.emphasize); got != tt.want {
+				t.Errorf("FormatAmbiguousInfinitive(%v) = %q, want %q", tt.candidates, got, tt.want)
+			}
+		})
+	}
+}
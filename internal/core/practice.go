@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+// ErrNoQuestion is returned by Service.Question when the selected verbs have no form in any of the selected tenses.
+var ErrNoQuestion = errors.New("no conjugation to practice with the selected verbs and tenses")
+
+// maxDraws bounds how many verb, tense and person draws Service.Question tries before giving up, which only happens
+// when the selection has no forms at all.
+const maxDraws = 100
+
+// Question asks for the form of one person of a verb in one mood and tense.
+type Question struct {
+	Infinitive string
+	Tense      spanish.TenseMood
+	// Person is the db.Persons key of the form asked for.
+	Person string
+	// Label names the person as PersonLabels does, such as "yo" or "ellos/ellas/Uds.".
+	Label  string
+	Answer string
+}
+
+// Grade is how an answer to a Question compares to its form.
+type Grade int
+
+const (
+	// GradeWrong is an answer that is not the form.
+	GradeWrong Grade = iota
+	// GradeCorrect is the form, ignoring case and surrounding spaces.
+	GradeCorrect
+	// GradeAccents is the form with wrong or missing accents. It counts as wrong.
+	GradeAccents
+)
+
+// Grade compares answer to the form q asks for.
+func (q Question) Grade(answer string) Grade {
+	answer = spanish.Normalize(answer)
+	switch {
+	case answer == spanish.Normalize(q.Answer):
+		return GradeCorrect
+	case answer != "" && spanish.Fold(answer) == spanish.Fold(q.Answer):
+		return GradeAccents
+	}
+	return GradeWrong
+}
+
+// Infinitives returns every infinitive of verbs.db. The caller must not modify it.
+func (s *Service) Infinitives() []string {
+	return s.infinitives.Infinitives()
+}
+
+// UpToLevel keeps the infinitives ranked at level, one of db.CEFRLevels, or an easier level. Unranked verbs are left
+// out, and so is every verb when level is no CEFR level.
+func (s *Service) UpToLevel(infinitives []string, level string) []string {
+	maxLevel := slices.Index(db.CEFRLevels, level)
+	var kept []string
+	for _, infinitive := range infinitives {
+		ranked, ok := s.infinitives.Level(infinitive)
+		if ok && slices.Index(db.CEFRLevels, ranked.Level) <= maxLevel {
+			kept = append(kept, infinitive)
+		}
+	}
+	return kept
+}
+
+// Question draws a question about one of infinitives in one of tenses, with rand returning a random number in [0, n).
+// Verbs without a row for the drawn tense, such as impersonal verbs, and persons without a form are drawn again. It
+// returns ErrNoQuestion when no draw finds a form.
+func (s *Service) Question(ctx context.Context, infinitives []string, tenses []spanish.TenseMood, rand func(n int) int) (Question, error) {
+	if len(infinitives) == 0 || len(tenses) == 0 {
+		return Question{}, ErrNoQuestion
+	}
+	for range maxDraws {
+		infinitive := infinitives[rand(len(infinitives))]
+		tense := tenses[rand(len(tenses))]
+		q, err := s.QuestionFor(ctx, infinitive, tense, db.Persons[rand(len(db.Persons))])
+		if errors.Is(err, ErrNoQuestion) {
+			continue
+		}
+		return q, err
+	}
+	return Question{}, ErrNoQuestion
+}
+
+// QuestionFor returns the question about the form of person, a db.Persons key, of infinitive in tense, so a front end
+// can grade an answer to a question it drew earlier. It returns ErrNoQuestion when verbs.db has no such form.
+func (s *Service) QuestionFor(ctx context.Context, infinitive string, tense spanish.TenseMood, person string) (Question, error) {
+	row, err := s.verbs.GetVerb(ctx, infinitive, tense.Mood, tense.Tense)
+	if errors.Is(err, db.ErrNotFound) {
+		return Question{}, ErrNoQuestion
+	}
+	if err != nil {
+		return Question{}, err
+	}
+
+	i := slices.Index(db.Persons, person)
+	if i < 0 {
+		return Question{}, ErrNoQuestion
+	}
+	label := PersonLabels(tense.Mood)[i]
+	form, err := row.Form(person)
+	if err != nil || form == "" || label == "" {
+		return Question{}, ErrNoQuestion
+	}
+	return Question{Infinitive: infinitive, Tense: tense, Person: person, Label: label, Answer: form}, nil
+}
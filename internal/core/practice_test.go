@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+func TestQuestion(t *testing.T) {
+	s := newTestService()
+	present := spanish.TenseMood{Mood: "Indicativo", Tense: "Presente"}
+	preterite := spanish.TenseMood{Mood: "Indicativo", Tense: "Pretérito"}
+
+	// sequence returns the given draws in turn, so a test picks the verb, tense and person of every attempt.
+	sequence := func(draws ...int) func(int) int {
+		return func(n int) int {
+			d := draws[0]
+			draws = draws[1:]
+			return d % n
+		}
+	}
+
+	tests := []struct {
+		name        string
+		infinitives []string
+		tenses      []spanish.TenseMood
+		rand        func(int) int
+		want        Question
+		wantErr     error
+	}{
+		{
+			name:        "first draw",
+			infinitives: []string{"hablar", "pensar"},
+			tenses:      []spanish.TenseMood{present},
+			rand:        sequence(1, 0, 2),
+			want:        Question{Infinitive: "pensar", Tense: present, Person: "3s", Label: "él/ella/Ud.", Answer: "piensa"},
+		},
+		{
+			name:        "verb without the tense is drawn again",
+			infinitives: []string{"sonar", "hablar"},
+			tenses:      []spanish.TenseMood{present},
+			rand:        sequence(0, 0, 3, 1, 0, 0),
+			want:        Question{Infinitive: "hablar", Tense: present, Person: "1s", Label: "yo", Answer: "hablo"},
+		},
+		{name: "no forms", infinitives: []string{"hablar"}, tenses: []spanish.TenseMood{preterite}, rand: func(int) int { return 0 }, wantErr: ErrNoQuestion},
+		{name: "no verbs", tenses: []spanish.TenseMood{present}, wantErr: ErrNoQuestion},
+		{name: "no tenses", infinitives: []string{"hablar"}, wantErr: ErrNoQuestion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Question(context.Background(), tt.infinitives, tt.tenses, tt.rand)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Question() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Question() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuestionFor(t *testing.T) {
+	s := newTestService()
+	present := spanish.TenseMood{Mood: "Indicativo", Tense: "Presente"}
+	imperative := spanish.TenseMood{Mood: "Imperativo Afirmativo", Tense: "Presente"}
+
+	tests := []struct {
+		name       string
+		infinitive string
+		tense      spanish.TenseMood
+		person     string
+		want       Question
+		wantErr    error
+	}{
+		{
+			name:       "form",
+			infinitive: "hablar", tense: present, person: "2p",
+			want: Question{Infinitive: "hablar", Tense: present, Person: "2p", Label: "vosotros", Answer: "habláis"},
+		},
+		{name: "unknown person", infinitive: "hablar", tense: present, person: "4s", wantErr: ErrNoQuestion},
+		{name: "no row", infinitive: "sonar", tense: present, person: "1s", wantErr: ErrNoQuestion},
+		{name: "person without imperative", infinitive: "hablar", tense: imperative, person: "1s", wantErr: ErrNoQuestion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.QuestionFor(context.Background(), tt.infinitive, tt.tense, tt.person)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("QuestionFor() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("QuestionFor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpToLevel(t *testing.T) {
+	s := NewService(db.NewMemoryRepository(db.MemoryData{}), db.NewInfinitiveIndex(nil).WithLevels(map[string]db.VerbLevel{
+		"ser":    {Rank: 1, Level: "A1"},
+		"tener":  {Rank: 3, Level: "A2"},
+		"surgir": {Rank: 900, Level: "B2"},
+	}))
+	infinitives := []string{"ser", "tener", "surgir", "desdecir"}
+
+	tests := []struct {
+		level string
+		want  []string
+	}{
+		{level: "A1", want: []string{"ser"}},
+		{level: "B1", want: []string{"ser", "tener"}},
+		{level: "C2", want: []string{"ser", "tener", "surgir"}},
+		{level: "Z9", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := s.UpToLevel(infinitives, tt.level); !slices.Equal(got, tt.want) {
+			t.Errorf("UpToLevel(%q) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestQuestionGrade(t *testing.T) {
+	q := Question{Answer: "habláis"}
+
+	tests := []struct {
+		answer string
+		want   Grade
+	}{
+		{answer: "habláis", want: GradeCorrect},
+		{answer: "  HABLÁIS ", want: GradeCorrect},
+		{answer: "hablais", want: GradeAccents},
+		{answer: "hablaís", want: GradeAccents},
+		{answer: "hablas", want: GradeWrong},
+		{answer: "", want: GradeWrong},
+	}
+
+	for _, tt := range tests {
+		if got := q.Grade(tt.answer); got != tt.want {
+			t.Errorf("Grade(%q) = %d, want %d", tt.answer, got, tt.want)
+		}
+	}
+}
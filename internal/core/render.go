@@ -0,0 +1,202 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+// Renderer turns results into the messages of one front end, such as Discord embeds or Markdown text.
+type Renderer[T any] interface {
+	Conjugation(ConjugationResult) T
+	Imperative(ImperativeResult) T
+	Examples(ExamplesResult) T
+}
+
+const (
+	examplesHeading = "Ejemplos"
+	imperativeKey   = "afirmativo · negativo ↳ presente de subjuntivo"
+)
+
+// TextRenderer renders results as plain text with aligned columns.
+type TextRenderer struct{}
+
+// MarkdownRenderer renders results as CommonMark with tables, marking irregular forms in bold.
+type MarkdownRenderer struct{}
+
+// HTMLRenderer renders results as HTML fragments, marking irregular forms with <strong class="irregular">.
+type HTMLRenderer struct{}
+
+var (
+	_ Renderer[string] = TextRenderer{}
+	_ Renderer[string] = MarkdownRenderer{}
+	_ Renderer[string] = HTMLRenderer{}
+)
+
+// title joins a verb and its translation, e.g. "hablar – I speak".
+func title(infinitive, english string) string {
+	if english == "" {
+		return infinitive
+	}
+	return infinitive + " – " + english
+}
+
+func (TextRenderer) Conjugation(r ConjugationResult) string {
+	var b strings.Builder
+	b.WriteString(title(r.Infinitive, r.English) + "\n")
+	b.WriteString(r.Heading() + "\n")
+
+	width := 0
+	for _, f := range r.Forms {
+		width = max(width, utf8.RuneCountInString(f.Label))
+	}
+	for _, f := range r.Forms {
+		fmt.Fprintf(&b, "  %-*s  %s\n", width, f.Label, f.Form)
+	}
+	if len(r.Examples) > 0 {
+		b.WriteString("\n" + examplesHeading + "\n")
+		writeTextExamples(&b, r.Examples)
+	}
+	return b.String()
+}
+
+func (TextRenderer) Imperative(r ImperativeResult) string {
+	var b strings.Builder
+	b.WriteString(title(r.Infinitive, r.English) + "\n")
+	width := 0
+	for _, row := range r.Rows {
+		width = max(width, utf8.RuneCountInString(row.Person))
+	}
+	for _, row := range r.Rows {
+		fmt.Fprintf(&b, "  %-*s  %s · %s ↳ %s\n", width, row.Person, row.Affirmative, row.Negative, row.Subjunctive)
+	}
+	b.WriteString(imperativeKey + "\n")
+	return b.String()
+}
+
+func (TextRenderer) Examples(r ExamplesResult) string {
+	var b strings.Builder
+	b.WriteString(title(r.Infinitive, r.TenseName) + "\n")
+	writeTextExamples(&b, r.Examples)
+	return b.String()
+}
+
+func writeTextExamples(b *strings.Builder, examples []Example) {
+	for _, ex := range examples {
+		b.WriteString("• " + ex.Sentence)
+		if ex.English != "" {
+			b.WriteString(" — " + ex.English)
+		}
+		b.WriteByte('\n')
+	}
+}
+
+// markdownEscaper escapes the characters CommonMark would read as formatting.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `|`, `\|`, `[`, `\[`, `]`, `\]`, `<`, `\<`)
+
+func (MarkdownRenderer) Conjugation(r ConjugationResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**", markdownEscaper.Replace(r.Infinitive))
+	if r.English != "" {
+		b.WriteString(" – " + markdownEscaper.Replace(r.English))
+	}
+	fmt.Fprintf(&b, "\n\n*%s*\n\n| persona | forma |\n| --- | --- |\n", markdownEscaper.Replace(r.Heading()))
+	for _, f := range r.Forms {
+		form := markdownEscaper.Replace(f.Form)
+		if f.Irregular {
+			form = "**" + form + "**"
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", markdownEscaper.Replace(f.Label), form)
+	}
+	if len(r.Examples) > 0 {
+		b.WriteString("\n**" + examplesHeading + "**\n\n")
+		writeMarkdownExamples(&b, r.Examples)
+	}
+	return b.String()
+}
+
+func (MarkdownRenderer) Imperative(r ImperativeResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**", markdownEscaper.Replace(r.Infinitive))
+	if r.English != "" {
+		b.WriteString(" – " + markdownEscaper.Replace(r.English))
+	}
+	b.WriteString("\n\n| persona | afirmativo | negativo | presente de subjuntivo |\n| --- | --- | --- | --- |\n")
+	for _, row := range r.Rows {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", markdownEscaper.Replace(row.Person), markdownEscaper.Replace(row.Affirmative),
+			markdownEscaper.Replace(row.Negative), markdownEscaper.Replace(row.Subjunctive))
+	}
+	return b.String()
+}
+
+func (MarkdownRenderer) Examples(r ExamplesResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** – %s\n\n", markdownEscaper.Replace(r.Infinitive), markdownEscaper.Replace(r.TenseName))
+	writeMarkdownExamples(&b, r.Examples)
+	return b.String()
+}
+
+func writeMarkdownExamples(b *strings.Builder, examples []Example) {
+	for _, ex := range examples {
+		fmt.Fprintf(b, "- *%s*", markdownEscaper.Replace(ex.Sentence))
+		if ex.English != "" {
+			b.WriteString(" — " + markdownEscaper.Replace(ex.English))
+		}
+		b.WriteByte('\n')
+	}
+}
+
+func (HTMLRenderer) Conjugation(r ConjugationResult) string {
+	var b strings.Builder
+	b.WriteString(`<section class="conjugation">` + "\n")
+	fmt.Fprintf(&b, "<h2>%s</h2>\n<h3>%s</h3>\n<table>\n", html.EscapeString(title(r.Infinitive, r.English)), html.EscapeString(r.Heading()))
+	for _, f := range r.Forms {
+		form := html.EscapeString(f.Form)
+		if f.Irregular {
+			form = `<strong class="irregular">` + form + "</strong>"
+		}
+		fmt.Fprintf(&b, "<tr><th>%s</th><td>%s</td></tr>\n", html.EscapeString(f.Label), form)
+	}
+	b.WriteString("</table>\n")
+	if len(r.Examples) > 0 {
+		b.WriteString("<h3>" + examplesHeading + "</h3>\n")
+		writeHTMLExamples(&b, r.Examples)
+	}
+	b.WriteString("</section>\n")
+	return b.String()
+}
+
+func (HTMLRenderer) Imperative(r ImperativeResult) string {
+	var b strings.Builder
+	b.WriteString(`<section class="imperative">` + "\n")
+	fmt.Fprintf(&b, "<h2>%s</h2>\n<table>\n", html.EscapeString(title(r.Infinitive, r.English)))
+	b.WriteString("<tr><th></th><th>afirmativo</th><th>negativo</th><th>presente de subjuntivo</th></tr>\n")
+	for _, row := range r.Rows {
+		fmt.Fprintf(&b, "<tr><th>%s</th><td>%s</td><td>%s</td><td>%s</td></tr>\n", html.EscapeString(row.Person),
+			html.EscapeString(row.Affirmative), html.EscapeString(row.Negative), html.EscapeString(row.Subjunctive))
+	}
+	b.WriteString("</table>\n</section>\n")
+	return b.String()
+}
+
+func (HTMLRenderer) Examples(r ExamplesResult) string {
+	var b strings.Builder
+	b.WriteString(`<section class="examples">` + "\n")
+	fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(title(r.Infinitive, r.TenseName)))
+	writeHTMLExamples(&b, r.Examples)
+	b.WriteString("</section>\n")
+	return b.String()
+}
+
+func writeHTMLExamples(b *strings.Builder, examples []Example) {
+	b.WriteString("<ul>\n")
+	for _, ex := range examples {
+		fmt.Fprintf(b, "<li><em>%s</em>", html.EscapeString(ex.Sentence))
+		if ex.English != "" {
+			b.WriteString(" — " + html.EscapeString(ex.English))
+		}
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</ul>\n")
+}
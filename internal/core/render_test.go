@@ -0,0 +1,67 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+var pensarPresent = ConjugationResult{
+	Infinitive: "pensar",
+	English:    "I think",
+	TenseName:  "Present",
+	Mood:       "Indicativo",
+	Tense:      "Presente",
+	Forms: []Form{
+		{Person: "1s", Label: "yo", Form: "pienso", Irregular: true},
+		{Person: "1p", Label: "nosotros", Form: "pensamos"},
+	},
+	Examples: []Example{{Sentence: "Pienso <luego> existo.", English: "I think, therefore I am."}},
+}
+
+func TestRenderers(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer[string]
+		want     []string
+	}{
+		{"text", TextRenderer{}, []string{
+			"pensar – I think\nPresent (Indicativo Presente)\n",
+			"  yo        pienso\n  nosotros  pensamos\n",
+			"• Pienso <luego> existo. — I think, therefore I am.\n",
+		}},
+		{"markdown", MarkdownRenderer{}, []string{
+			"**pensar** – I think",
+			"| yo | **pienso** |\n| nosotros | pensamos |\n",
+			"- *Pienso \\<luego> existo.*",
+		}},
+		{"html", HTMLRenderer{}, []string{
+			"<h2>pensar – I think</h2>",
+			`<tr><th>yo</th><td><strong class="irregular">pienso</strong></td></tr>`,
+			"<li><em>Pienso &lt;luego&gt; existo.</em> — I think, therefore I am.</li>",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.renderer.Conjugation(pensarPresent)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Conjugation() = %q, want it to contain %q", got, want)
+				}
+			}
+
+			imperative := tt.renderer.Imperative(ImperativeResult{
+				Infinitive: "hablar",
+				Rows:       []ImperativeRow{{Person: "tú", Affirmative: "habla", Negative: "no hables", Subjunctive: "hables"}},
+			})
+			if !strings.Contains(imperative, "no hables") {
+				t.Errorf("Imperative() = %q, want the negative command", imperative)
+			}
+
+			examples := tt.renderer.Examples(ExamplesResult{Infinitive: "pensar", TenseName: "Present", Examples: pensarPresent.Examples})
+			if !strings.Contains(examples, "I think, therefore I am.") {
+				t.Errorf("Examples() = %q, want the translation", examples)
+			}
+		})
+	}
+}
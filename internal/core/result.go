@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+// personLabels names the form_* columns of verbs.db in db.Persons order. The imperative rows keep tú, vosotros, Ud.
+// and Uds. in form_2s, form_3s, form_2p and form_3p and leave the other two empty.
+var (
+	personLabels     = [6]string{"yo", "tú", "él/ella/Ud.", "nosotros", "vosotros", "ellos/ellas/Uds."}
+	imperativeLabels = [6]string{"", "tú", "vosotros", "", "Ud.", "Uds."}
+)
+
+// PersonLabels returns the label of each form_* column of a verbs.db row in mood, in db.Persons order. Columns the
+// mood leaves empty have an empty label.
+func PersonLabels(mood string) [6]string {
+	if strings.HasPrefix(mood, "Imperativo") {
+		return imperativeLabels
+	}
+	return personLabels
+}
+
+// Form is the conjugated form of one person.
+type Form struct {
+	// Person is the db.Persons key of the form_* column the form comes from.
+	Person string
+	// Label names the person, such as "yo" or "Ud.".
+	Label string
+	Form  string
+	// Irregular is set when the form differs from the one a regular verb would have; see spanish.RegularForms.
+	Irregular bool
+}
+
+// Example is an example sentence and its translation, which may be empty.
+type Example struct {
+	Sentence string
+	English  string
+}
+
+// ConjugationResult is a verb conjugated in one mood and tense.
+type ConjugationResult struct {
+	Infinitive string
+	// English is the translation of the conjugation, such as "I speak".
+	English string
+	// TenseName is the name of the tense in spanish.TenseMoodChoices.
+	TenseName string
+	Mood      string
+	Tense     string
+	// Forms lists the persons the mood has, in db.Persons order.
+	Forms []Form
+	// Examples holds the example sentences asked for; MoreExamples is set when verbs.db has more.
+	Examples     []Example
+	MoreExamples bool
+	// Level is the CEFR level of the verb and Rank its rank by frequency, both zero when verbs.db does not rank it.
+	Level string
+	Rank  int
+}
+
+// Heading names the tense of r by its choice and its name in verbs.db, e.g. "Present (Indicativo Presente)".
+func (r ConjugationResult) Heading() string {
+	if r.TenseName == "" || r.TenseName == r.Mood+" "+r.Tense {
+		return r.Mood + " " + r.Tense
+	}
+	return fmt.Sprintf("%s (%s %s)", r.TenseName, r.Mood, r.Tense)
+}
+
+// NewConjugationResult builds the result of a verbs.db row, marking its irregular forms. TenseName is the first
+// choice offering the row's mood and tense.
+func NewConjugationResult(v db.Verb) ConjugationResult {
+	tm := spanish.TenseMood{Mood: v.Mood, Tense: v.Tense}
+	result := ConjugationResult{
+		Infinitive: v.Infinitive,
+		English:    db.NullStringToString(v.VerbEnglish),
+		TenseName:  spanish.TenseMoodName(tm),
+		Mood:       v.Mood,
+		Tense:      v.Tense,
+	}
+
+	regular, hasRegular := spanish.RegularForms(v.Infinitive, tm)
+	labels := PersonLabels(v.Mood)
+	for i, person := range db.Persons {
+		form, _ := v.Form(person)
+		if labels[i] == "" {
+			continue
+		}
+		result.Forms = append(result.Forms, Form{
+			Person:    person,
+			Label:     labels[i],
+			Form:      form,
+			Irregular: hasRegular && form != "" && spanish.Normalize(form) != regular[i],
+		})
+	}
+	return result
+}
+
+// TableResult is every conjugation of a verb.
+type TableResult struct {
+	Infinitive     string
+	Gerund         string
+	PastParticiple string
+	Conjugations   []ConjugationResult
+}
+
+// ImperativeResult shows the affirmative and negative commands of a verb side by side.
+type ImperativeResult struct {
+	Infinitive string
+	English    string
+	Rows       []ImperativeRow
+}
+
+// ExamplesResult lists example sentences of a verb in one tense.
+type ExamplesResult struct {
+	Infinitive string
+	TenseName  string
+	Examples   []Example
+}
+
+func newExamples(examples []db.Example) []Example {
+	if len(examples) == 0 {
+		return nil
+	}
+	result := make([]Example, len(examples))
+	for i, ex := range examples {
+		result[i] = Example{Sentence: ex.Sentence, English: db.NullStringToString(ex.SentenceEnglish)}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/cache"
+)
+
+// CacheStats reports the lookups served by each cache of a CachedRepository.
+type CacheStats struct {
+	Verb  cache.Stats
+	Verbs cache.Stats
+}
+
+// CachedRepository is a VerbRepository that keeps recently used conjugation tables in memory in front of another
+// repository. Single tables are cached by infinitive, mood and tense, full tables by infinitive. Lookups that fail are
+// not cached.
+type CachedRepository struct {
+	VerbRepository
+	verb  *cache.LRU[verbKey, Verb]
+	verbs *cache.LRU[string, []Verb]
+}
+
+// NewCachedRepository wraps next with caches of up to size single tables and size full tables.
+func NewCachedRepository(next VerbRepository, size int) *CachedRepository {
+	return &CachedRepository{
+		VerbRepository: next,
+		verb:           cache.NewLRU[verbKey, Verb](size),
+		verbs:          cache.NewLRU[string, []Verb](size),
+	}
+}
+
+func (r *CachedRepository) GetVerb(ctx context.Context, infinitive, mood, tense string) (Verb, error) {
+	key := verbKey{infinitive, mood, tense}
+	if verb, ok := r.verb.Get(key); ok {
+		return verb, nil
+	}
+
+	verb, err := r.VerbRepository.GetVerb(ctx, infinitive, mood, tense)
+	if err != nil {
+		return Verb{}, err
+	}
+	r.verb.Add(key, verb)
+	return verb, nil
+}
+
+// GetVerbs returns a copy of the cached slice, so callers can modify it without corrupting the cache.
+func (r *CachedRepository) GetVerbs(ctx context.Context, infinitive string) ([]Verb, error) {
+	if verbs, ok := r.verbs.Get(infinitive); ok {
+		return append([]Verb(nil), verbs...), nil
+	}
+
+	verbs, err := r.VerbRepository.GetVerbs(ctx, infinitive)
+	if err != nil {
+		return nil, err
+	}
+	r.verbs.Add(infinitive, append([]Verb(nil), verbs...))
+	return verbs, nil
+}
+
+// Stats returns the hit and miss counters of both caches.
+func (r *CachedRepository) Stats() CacheStats {
+	return CacheStats{Verb: r.verb.Stats(), Verbs: r.verbs.Stats()}
+}
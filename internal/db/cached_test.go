@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCachedRepository(NewSQLiteRepository(newTestDB(t)), 16)
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.GetVerb(ctx, "hablar", "Indicativo", "Presente"); err != nil {
+			t.Fatalf("GetVerb() returned an error: %v", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := repo.GetVerb(ctx, "vivir", "Indicativo", "Presente"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("GetVerb() error = %v, want ErrNotFound", err)
+		}
+	}
+
+	stats := repo.Stats().Verb
+	if stats.Hits != 2 || stats.Misses != 3 || stats.Size != 1 {
+		t.Errorf("Verb cache stats = %+v, want 2 hits, 3 misses and 1 entry", stats)
+	}
+}
+
+func TestCachedRepositoryGetVerbsReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCachedRepository(NewSQLiteRepository(newTestDB(t)), 16)
+
+	verbs, err := repo.GetVerbs(ctx, "hablar")
+	if err != nil {
+		t.Fatalf("GetVerbs() returned an error: %v", err)
+	}
+	verbs[0].Mood = "changed"
+
+	cached, err := repo.GetVerbs(ctx, "hablar")
+	if err != nil {
+		t.Fatalf("GetVerbs() returned an error: %v", err)
+	}
+	if cached[0].Mood == "changed" {
+		t.Error("Modifying a returned slice changed the cached one")
+	}
+	if stats := repo.Stats().Verbs; stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Verbs cache stats = %+v, want 1 hit and 1 miss", stats)
+	}
+}
+
+// BenchmarkGetVerb compares verb lookups against verbs.db with and without the cache, with lookups spread over a
+// working set of conjugation tables the way concurrent /conjugate handlers would issue them.
+func BenchmarkGetVerb(b *testing.B) {
+	ctx := context.Background()
+	sqlDB := openVerbsDB(b)
+
+	verbs, err := New(sqlDB).ListVerbs(ctx)
+	if err != nil {
+		b.Fatalf("ListVerbs() returned an error: %v", err)
+	}
+	const workingSet = 500
+	keys := make([]verbKey, 0, workingSet)
+	for i := 0; i < len(verbs) && len(keys) < workingSet; i += len(verbs) / workingSet {
+		keys = append(keys, verbKey{verbs[i].Infinitive, verbs[i].Mood, verbs[i].Tense})
+	}
+
+	repositories := []struct {
+		name string
+		repo VerbRepository
+	}{
+		{"uncached", NewSQLiteRepository(sqlDB)},
+		{"cached", NewCachedRepository(NewSQLiteRepository(sqlDB), 1024)},
+	}
+
+	for _, r := range repositories {
+		b.Run(r.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					key := keys[i%len(keys)]
+					if _, err := r.repo.GetVerb(ctx, key.infinitive, key.mood, key.tense); err != nil {
+						b.Errorf("GetVerb(%v) returned an error: %v", key, err)
+						return
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+// openVerbsDB opens the real verbs.db read-only.
+func openVerbsDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	sqlDB, err := sql.Open("sqlite3", ReadOnlyDSN("verbs.db"))
+	if err != nil {
+		tb.Fatalf("Failed to open verbs.db: %v", err)
+	}
+	tb.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
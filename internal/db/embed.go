@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// embeddedVerbsDB is verbs.db as it was when the binary was built.
+//
+//go:embed verbs.db
+var embeddedVerbsDB []byte
+
+// embeddedVerbsDBChecksum is the expected SHA-256 of verbs.db. Regenerate it with `verbsctl checksum -write` after
+// changing the database.
+//
+//go:embed verbs.db.sha256
+var embeddedVerbsDBChecksum string
+
+// expectedSchema lists the columns each reference table must have.
+var expectedSchema = map[string][]string{
+	"infinitive":     {"infinitive", "infinitive_english"},
+	"mood":           {"mood", "mood_english"},
+	"tense":          {"tense", "tense_english"},
+	"gerund":         {"infinitive", "gerund", "gerund_english"},
+	"pastparticiple": {"infinitive", "pastparticiple", "pastparticiple_english"},
+	"verbs":          {"infinitive", "mood", "tense", "verb_english", "form_1s", "form_2s", "form_3s", "form_1p", "form_2p", "form_3p"},
+	"examples":       {"id", "infinitive", "mood", "tense", "person", "sentence", "sentence_english"},
+}
+
+const (
+	errChecksumMismatch = "embedded verbs.db checksum %s does not match expected %s"
+	errMaterialize      = "error writing embedded verbs.db to disk"
+	errVerbsDBPath      = "error reading verbs database %s"
+	errSchemaTable      = "table %q is missing"
+	errSchemaColumn     = "table %q is missing column %q"
+	errSchemaRead       = "error reading schema of table %q: %w"
+)
+
+// Checksum returns the hex-encoded SHA-256 of data.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyEmbeddedChecksum checks the embedded verbs.db against the checksum committed next to it, catching a database
+// that changed without its checksum being updated or a file that is not a database at all, such as a Git LFS pointer.
+func VerifyEmbeddedChecksum() error {
+	got, want := Checksum(embeddedVerbsDB), strings.TrimSpace(embeddedVerbsDBChecksum)
+	if got != want {
+		return fmt.Errorf(errChecksumMismatch, got, want)
+	}
+	return nil
+}
+
+// MaterializeEmbeddedVerbsDB writes the embedded verbs.db to a temporary file, since SQLite can only open files, and
+// returns its path along with a function that removes it.
+func MaterializeEmbeddedVerbsDB() (string, func() error, error) {
+	f, err := os.CreateTemp("", "verbs-*.db")
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", errMaterialize, err)
+	}
+	remove := func() error { return os.Remove(f.Name()) }
+
+	if _, err := f.Write(embeddedVerbsDB); err != nil {
+		f.Close()
+		remove()
+		return "", nil, fmt.Errorf("%s: %w", errMaterialize, err)
+	}
+	if err := f.Close(); err != nil {
+		remove()
+		return "", nil, fmt.Errorf("%s: %w", errMaterialize, err)
+	}
+	return f.Name(), remove, nil
+}
+
+// ResolveVerbsDB returns the path verbs.db should be opened from. A non-empty override is used as is; otherwise the
+// embedded copy is checked against its checksum and written to a temporary file. The returned function removes any
+// temporary file and should be called after the database is closed.
+func ResolveVerbsDB(override string) (string, func() error, error) {
+	if override != "" {
+		if _, err := os.Stat(override); err != nil {
+			return "", nil, fmt.Errorf(errVerbsDBPath+": %w", override, err)
+		}
+		return override, func() error { return nil }, nil
+	}
+
+	if err := VerifyEmbeddedChecksum(); err != nil {
+		return "", nil, err
+	}
+	return MaterializeEmbeddedVerbsDB()
+}
+
+// VerifySchema checks that the database has every table and column the queries rely on.
+func VerifySchema(ctx context.Context, db DBTX) error {
+	var errs []error
+	for table, columns := range expectedSchema {
+		found, err := tableColumns(ctx, db, table)
+		if err != nil {
+			return fmt.Errorf(errSchemaRead, table, err)
+		}
+		if len(found) == 0 {
+			errs = append(errs, fmt.Errorf(errSchemaTable, table))
+			continue
+		}
+		for _, col := range columns {
+			if !found[col] {
+				errs = append(errs, fmt.Errorf(errSchemaColumn, table, col))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func tableColumns(ctx context.Context, db DBTX, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+func TestVerifyEmbeddedChecksum(t *testing.T) {
+	if err := VerifyEmbeddedChecksum(); err != nil {
+		t.Fatalf("VerifyEmbeddedChecksum() returned an error: %v", err)
+	}
+
+	original := embeddedVerbsDBChecksum
+	defer func() { embeddedVerbsDBChecksum = original }()
+	embeddedVerbsDBChecksum = strings.Repeat("0", 64)
+	if err := VerifyEmbeddedChecksum(); err == nil {
+		t.Error("Expected an error for a mismatched checksum")
+	}
+}
+
+func TestMaterializeEmbeddedVerbsDB(t *testing.T) {
+	path, remove, err := MaterializeEmbeddedVerbsDB()
+	if err != nil {
+		t.Fatalf("MaterializeEmbeddedVerbsDB() returned an error: %v", err)
+	}
+
+	sqlDB, err := sql.Open("sqlite3", ReadOnlyDSN(path))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := VerifySchema(context.Background(), sqlDB); err != nil {
+		t.Errorf("VerifySchema() returned an error for the embedded database: %v", err)
+	}
+	sqlDB.Close()
+
+	if err := remove(); err != nil {
+		t.Fatalf("remove() returned an error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed, got %v", path, err)
+	}
+}
+
+func TestVerifySchema_Missing(t *testing.T) {
+	sqlDB := openTempDB(t)
+	if _, err := sqlDB.Exec("CREATE TABLE verbs (infinitive character varying)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	err := VerifySchema(context.Background(), sqlDB)
+	if err == nil {
+		t.Fatal("VerifySchema() expected an error, got nil")
+	}
+	for _, want := range []string{`table "infinitive" is missing`, `table "verbs" is missing column "form_1s"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestResolveVerbsDB(t *testing.T) {
+	path, remove, err := ResolveVerbsDB("")
+	if err != nil {
+		t.Fatalf("ResolveVerbsDB() returned an error: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the embedded database at %s: %v", path, err)
+	}
+	remove()
+
+	path, remove, err = ResolveVerbsDB("verbs.db")
+	if err != nil || path != "verbs.db" {
+		t.Fatalf("ResolveVerbsDB() = %q, %v; want verbs.db", path, err)
+	}
+	if err := remove(); err != nil {
+		t.Errorf("remove() returned an error: %v", err)
+	}
+	if _, err := os.Stat("verbs.db"); err != nil {
+		t.Errorf("Expected an override file to be left in place: %v", err)
+	}
+
+	if _, _, err := ResolveVerbsDB("missing.db"); err == nil {
+		t.Error("Expected an error for a missing override file")
+	}
+}
+
+func TestTenseMoodChoicesInEmbeddedVerbsDB(t *testing.T) {
+	path, remove, err := MaterializeEmbeddedVerbsDB()
+	if err != nil {
+		t.Fatalf("MaterializeEmbeddedVerbsDB() returned an error: %v", err)
+	}
+	defer remove()
+	sqlDB, err := sql.Open("sqlite3", ReadOnlyDSN(path))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer sqlDB.Close()
+
+	moodTenses, err := ListMoodTenses(context.Background(), sqlDB)
+	if err != nil {
+		t.Fatalf("ListMoodTenses() returned an error: %v", err)
+	}
+	for _, choice := range spanish.TenseMoodChoices {
+		if _, ok := moodTenses[MoodTense{choice.Value.Mood, choice.Value.Tense}]; !ok {
+			t.Errorf("%s maps to %s %s, which verbs.db does not conjugate", choice.Name, choice.Value.Mood, choice.Value.Tense)
+		}
+	}
+}
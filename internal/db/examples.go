@@ -0,0 +1,207 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// createExamplesTable creates the examples table in databases built before it existed.
+const createExamplesTable = `CREATE TABLE IF NOT EXISTS examples (
+    id integer NOT NULL PRIMARY KEY,
+    infinitive character varying NOT NULL,
+    mood character varying NOT NULL,
+    tense character varying NOT NULL,
+    person character varying NOT NULL,
+    sentence character varying NOT NULL,
+    sentence_english character varying,
+    UNIQUE (infinitive, mood, tense, person, sentence)
+)`
+
+const (
+	examplesTSVColumns = 6
+
+	errExamplesRead    = "error reading examples file"
+	errExampleColumns  = "line %d: expected %d tab-separated columns, got %d"
+	errExampleEmpty    = "line %d: column %q is empty"
+	errExamplePerson   = "line %d: %w"
+	errExampleVerb     = "line %d: no %s %s conjugation for %q"
+	errExampleLookup   = "line %d: error looking up verb: %w"
+	errExampleNoForm   = "line %d: %q has no %s form in %s %s"
+	errExampleMismatch = "line %d: sentence does not contain %q"
+	errExampleInsert   = "line %d: error inserting example: %w"
+	errExamplesTable   = "error creating examples table"
+)
+
+// ExampleLine is an example parsed from a TSV file, along with the line it came from.
+type ExampleLine struct {
+	Line    int
+	Example InsertExampleParams
+}
+
+// ParseExamplesTSV reads examples from tab-separated lines of infinitive, mood, tense, person, sentence and English
+// translation. Blank lines, lines starting with '#' and a header row starting with "infinitive" are skipped.
+func ParseExamplesTSV(r io.Reader) ([]ExampleLine, error) {
+	var (
+		lines []ExampleLine
+		errs  []error
+	)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "infinitive\t") {
+			continue
+		}
+
+		cols := strings.Split(text, "\t")
+		if len(cols) != examplesTSVColumns {
+			errs = append(errs, fmt.Errorf(errExampleColumns, n, examplesTSVColumns, len(cols)))
+			continue
+		}
+		for i := range cols {
+			cols[i] = strings.TrimSpace(cols[i])
+		}
+
+		ex := InsertExampleParams{
+			Infinitive:      cols[0],
+			Mood:            cols[1],
+			Tense:           cols[2],
+			Person:          cols[3],
+			Sentence:        cols[4],
+			SentenceEnglish: sql.NullString{String: cols[5], Valid: cols[5] != ""},
+		}
+		if err := checkRequiredColumns(n, ex); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		lines = append(lines, ExampleLine{Line: n, Example: ex})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errExamplesRead, err)
+	}
+
+	return lines, errors.Join(errs...)
+}
+
+func checkRequiredColumns(line int, ex InsertExampleParams) error {
+	required := []struct{ name, value string }{
+		{"infinitive", ex.Infinitive},
+		{"mood", ex.Mood},
+		{"tense", ex.Tense},
+		{"person", ex.Person},
+		{"sentence", ex.Sentence},
+	}
+	for _, col := range required {
+		if col.value == "" {
+			return fmt.Errorf(errExampleEmpty, line, col.name)
+		}
+	}
+	return nil
+}
+
+// ValidateExamples checks that every example refers to an existing conjugation and that its sentence contains the
+// referenced form. It returns one error per invalid line.
+func ValidateExamples(ctx context.Context, q *Queries, lines []ExampleLine) error {
+	var errs []error
+	for _, l := range lines {
+		if err := validateExample(ctx, q, l); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateExample(ctx context.Context, q *Queries, l ExampleLine) error {
+	ex := l.Example
+
+	verb, err := q.GetVerbByInfinitiveMoodTense(ctx, GetVerbByInfinitiveMoodTenseParams{
+		Infinitive: ex.Infinitive,
+		Mood:       ex.Mood,
+		Tense:      ex.Tense,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf(errExampleVerb, l.Line, ex.Mood, ex.Tense, ex.Infinitive)
+	}
+	if err != nil {
+		return fmt.Errorf(errExampleLookup, l.Line, err)
+	}
+
+	form, err := verb.Form(ex.Person)
+	if err != nil {
+		return fmt.Errorf(errExamplePerson, l.Line, err)
+	}
+	if strings.TrimSpace(form) == "" {
+		return fmt.Errorf(errExampleNoForm, l.Line, ex.Infinitive, ex.Person, ex.Mood, ex.Tense)
+	}
+	if !ContainsForm(ex.Sentence, form) {
+		return fmt.Errorf(errExampleMismatch, l.Line, form)
+	}
+	return nil
+}
+
+// ContainsForm reports whether the words of form appear consecutively in sentence, ignoring case and punctuation, so
+// that "he hablado" matches "¡Ya he hablado con ella!" but "habla" does not match "hablaba".
+func ContainsForm(sentence, form string) bool {
+	sentenceWords := splitWords(sentence)
+	formWords := splitWords(form)
+	if len(formWords) == 0 {
+		return false
+	}
+
+	for i := 0; i+len(formWords) <= len(sentenceWords); i++ {
+		match := true
+		for j, w := range formWords {
+			if sentenceWords[i+j] != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// ImportExamples inserts the examples in a single transaction, creating the examples table if needed. Examples that
+// are already present are left untouched. It returns the number of examples added.
+func ImportExamples(ctx context.Context, sqlDB *sql.DB, lines []ExampleLine) (int, error) {
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, createExamplesTable); err != nil {
+		return 0, fmt.Errorf("%s: %w", errExamplesTable, err)
+	}
+
+	var before, after int
+	if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM examples").Scan(&before); err != nil {
+		return 0, err
+	}
+
+	q := New(tx)
+	for _, l := range lines {
+		if err := q.InsertExample(ctx, l.Example); err != nil {
+			return 0, fmt.Errorf(errExampleInsert, l.Line, err)
+		}
+	}
+
+	if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM examples").Scan(&after); err != nil {
+		return 0, err
+	}
+	return after - before, tx.Commit()
+}
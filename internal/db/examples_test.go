@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+)
+
+// newTestDB opens an in-memory database with schema.sql applied and a single verb row.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	schema, err := os.ReadFile("schema.sql")
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	if _, err := sqlDB.Exec(string(schema)); err != nil {
+		t.Fatalf("Failed to apply schema: %v", err)
+	}
+
+	_, err = sqlDB.Exec(`INSERT INTO verbs VALUES
+		('hablar', 'Indicativo', 'Presente', 'I speak', 'hablo', 'hablas', 'habla', 'hablamos', 'habláis', 'hablan'),
+		('hablar', 'Indicativo', 'Presente perfecto', 'I have spoken', 'he hablado', 'has hablado', 'ha hablado', 'hemos hablado', 'habéis hablado', 'han hablado'),
+		('hablar', 'Imperativo Afirmativo', 'Presente', 'Speak.', '', 'habla', 'hablad', '', 'hable', 'hablen')`)
+	if err != nil {
+		t.Fatalf("Failed to insert verbs: %v", err)
+	}
+	return sqlDB
+}
+
+const examplesTSV = "infinitive\tmood\ttense\tperson\tsentence\tenglish\n" +
+	"# comment\n" +
+	"hablar\tIndicativo\tPresente\t1s\tYo hablo español.\tI speak Spanish.\n" +
+	"\n" +
+	"hablar\tIndicativo\tPresente perfecto\t1p\t¡Ya hemos hablado!\t\n"
+
+func TestParseExamplesTSV(t *testing.T) {
+	lines, err := ParseExamplesTSV(strings.NewReader(examplesTSV))
+	if err != nil {
+		t.Fatalf("ParseExamplesTSV() returned an error: %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 examples, got %d", len(lines))
+	}
+
+	first := lines[0]
+	if first.Line != 3 || first.Example.Person != "1s" || first.Example.Sentence != "Yo hablo español." {
+		t.Errorf("Unexpected first example %+v", first)
+	}
+	if !first.Example.SentenceEnglish.Valid || first.Example.SentenceEnglish.String != "I speak Spanish." {
+		t.Errorf("Unexpected translation %+v", first.Example.SentenceEnglish)
+	}
+	if lines[1].Example.SentenceEnglish.Valid {
+		t.Errorf("Expected an empty translation to be NULL, got %+v", lines[1].Example.SentenceEnglish)
+	}
+}
+
+func TestParseExamplesTSV_Errors(t *testing.T) {
+	input := "hablar\tIndicativo\tPresente\t1s\n" +
+		"hablar\tIndicativo\tPresente\t\tYo hablo.\t\n"
+
+	_, err := ParseExamplesTSV(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("ParseExamplesTSV() expected an error, got nil")
+	}
+	for _, want := range []string{"line 1: expected 6", `line 2: column "person" is empty`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestContainsForm(t *testing.T) {
+	tests := []struct {
+		sentence string
+		form     string
+		expected bool
+	}{
+		{"Yo hablo español.", "hablo", true},
+		{"¡Ya HEMOS hablado!", "hemos hablado", true},
+		{"Hablaba mucho.", "habla", false},
+		{"He visto y hablado.", "he hablado", false},
+		{"Habla.", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := ContainsForm(tt.sentence, tt.form); got != tt.expected {
+			t.Errorf("ContainsForm(%q, %q) = %v, want %v", tt.sentence, tt.form, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateExamples(t *testing.T) {
+	sqlDB := newTestDB(t)
+	q := New(sqlDB)
+
+	lines := []ExampleLine{
+		{Line: 1, Example: InsertExampleParams{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", Person: "1s", Sentence: "Yo hablo."}},
+		{Line: 2, Example: InsertExampleParams{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", Person: "2s", Sentence: "Yo hablo."}},
+		{Line: 3, Example: InsertExampleParams{Infinitive: "comer", Mood: "Indicativo", Tense: "Presente", Person: "1s", Sentence: "Como."}},
+		{Line: 4, Example: InsertExampleParams{Infinitive: "hablar", Mood: "Imperativo Afirmativo", Tense: "Presente", Person: "1s", Sentence: "Hablo."}},
+		{Line: 5, Example: InsertExampleParams{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", Person: "4s", Sentence: "Hablo."}},
+	}
+
+	err := ValidateExamples(context.Background(), q, lines)
+	if err == nil {
+		t.Fatal("ValidateExamples() expected an error, got nil")
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "line 1:") {
+		t.Errorf("Did not expect line 1 to be reported: %v", msg)
+	}
+	for _, want := range []string{
+		`line 2: sentence does not contain "hablas"`,
+		`line 3: no Indicativo Presente conjugation for "comer"`,
+		`line 4: "hablar" has no 1s form`,
+		`line 5: unknown person "4s"`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error to contain %q, got %v", want, msg)
+		}
+	}
+}
+
+func TestImportExamples(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := newTestDB(t)
+
+	lines, err := ParseExamplesTSV(strings.NewReader(examplesTSV))
+	if err != nil {
+		t.Fatalf("ParseExamplesTSV() returned an error: %v", err)
+	}
+
+	added, err := ImportExamples(ctx, sqlDB, lines)
+	if err != nil {
+		t.Fatalf("ImportExamples() returned an error: %v", err)
+	}
+	if added != 2 {
+		t.Errorf("Expected 2 examples added, got %d", added)
+	}
+
+	added, err = ImportExamples(ctx, sqlDB, lines)
+	if err != nil {
+		t.Fatalf("ImportExamples() returned an error on re-import: %v", err)
+	}
+	if added != 0 {
+		t.Errorf("Expected re-import to add nothing, got %d", added)
+	}
+
+	examples, err := New(sqlDB).GetExamplesByInfinitiveMoodTense(ctx, GetExamplesByInfinitiveMoodTenseParams{
+		Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("GetExamplesByInfinitiveMoodTense() returned an error: %v", err)
+	}
+	if len(examples) != 1 || examples[0].Sentence != "Yo hablo español." {
+		t.Errorf("Unexpected examples %+v", examples)
+	}
+}
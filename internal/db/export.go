@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// TableData is a table dumped as rows of nullable column values, in the column order of schema.sql.
+type TableData struct {
+	Name    string
+	Columns []string
+	Rows    [][]sql.NullString
+}
+
+func valid(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
+
+// ExportTables reads every table of the verb database for export.
+func ExportTables(ctx context.Context, q *Queries) ([]TableData, error) {
+	infinitives, err := q.ListInfinitives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing infinitives: %w", err)
+	}
+	moods, err := q.ListMoods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing moods: %w", err)
+	}
+	tenses, err := q.ListTenses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tenses: %w", err)
+	}
+	gerunds, err := q.ListGerunds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing gerunds: %w", err)
+	}
+	participles, err := q.ListPastparticiples(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing past participles: %w", err)
+	}
+	verbs, err := q.ListVerbs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing verbs: %w", err)
+	}
+	examples, err := q.ListExamples(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing examples: %w", err)
+	}
+
+	tables := []TableData{
+		{Name: "gerund", Columns: []string{"infinitive", "gerund", "gerund_english"}},
+		{Name: "infinitive", Columns: []string{"infinitive", "infinitive_english"}},
+		{Name: "mood", Columns: []string{"mood", "mood_english"}},
+		{Name: "pastparticiple", Columns: []string{"infinitive", "pastparticiple", "pastparticiple_english"}},
+		{Name: "tense", Columns: []string{"tense", "tense_english"}},
+		{Name: "verbs", Columns: []string{"infinitive", "mood", "tense", "verb_english", "form_1s", "form_2s", "form_3s", "form_1p", "form_2p", "form_3p"}},
+		{Name: "examples", Columns: []string{"id", "infinitive", "mood", "tense", "person", "sentence", "sentence_english"}},
+	}
+
+	for _, g := range gerunds {
+		tables[0].Rows = append(tables[0].Rows, []sql.NullString{valid(g.Infinitive), valid(g.Gerund), g.GerundEnglish})
+	}
+	for _, inf := range infinitives {
+		tables[1].Rows = append(tables[1].Rows, []sql.NullString{valid(inf.Infinitive), inf.InfinitiveEnglish})
+	}
+	for _, m := range moods {
+		tables[2].Rows = append(tables[2].Rows, []sql.NullString{valid(m.Mood), m.MoodEnglish})
+	}
+	for _, p := range participles {
+		tables[3].Rows = append(tables[3].Rows, []sql.NullString{valid(p.Infinitive), valid(p.Pastparticiple), p.PastparticipleEnglish})
+	}
+	for _, t := range tenses {
+		tables[4].Rows = append(tables[4].Rows, []sql.NullString{valid(t.Tense), t.TenseEnglish})
+	}
+	for _, v := range verbs {
+		tables[5].Rows = append(tables[5].Rows, []sql.NullString{
+			valid(v.Infinitive), valid(v.Mood), valid(v.Tense), v.VerbEnglish,
+			v.Form1s, v.Form2s, v.Form3s, v.Form1p, v.Form2p, v.Form3p,
+		})
+	}
+	for _, ex := range examples {
+		tables[6].Rows = append(tables[6].Rows, []sql.NullString{
+			valid(strconv.FormatInt(ex.ID, 10)), valid(ex.Infinitive), valid(ex.Mood), valid(ex.Tense),
+			valid(ex.Person), valid(ex.Sentence), ex.SentenceEnglish,
+		})
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+// AmbiguousInfinitiveError is returned by InfinitiveIndex.Resolve when input folds to more than one infinitive and
+// matches none of them exactly.
+type AmbiguousInfinitiveError struct {
+	Input      string
+	Candidates []string
+}
+
+func (e *AmbiguousInfinitiveError) Error() string {
+	return fmt.Sprintf("%q could be any of: %s", e.Input, strings.Join(e.Candidates, ", "))
+}
+
+// InfinitiveIndex maps user input to the canonical spelling of an infinitive. Input is matched exactly after
+// normalization first and by its folded key second, so "OÍR", " oír " and "oir" all resolve to "oír".
+type InfinitiveIndex struct {
+	canonical map[string]bool
+	folded    map[string][]string
+	levels    map[string]VerbLevel
+	// all lists every infinitive once, in the order it was indexed.
+	all []string
+}
+
+// NewInfinitiveIndex indexes the given infinitives by their folded key.
+func NewInfinitiveIndex(infinitives []Infinitive) *InfinitiveIndex {
+	idx := &InfinitiveIndex{
+		canonical: make(map[string]bool, len(infinitives)),
+		folded:    make(map[string][]string, len(infinitives)),
+	}
+	for _, inf := range infinitives {
+		if idx.canonical[inf.Infinitive] {
+			continue
+		}
+		idx.canonical[inf.Infinitive] = true
+		idx.all = append(idx.all, inf.Infinitive)
+		key := spanish.Fold(inf.Infinitive)
+		idx.folded[key] = append(idx.folded[key], inf.Infinitive)
+	}
+	for _, candidates := range idx.folded {
+		sort.Strings(candidates)
+	}
+	return idx
+}
+
+// LoadInfinitiveIndex builds an InfinitiveIndex from the infinitive table, with the levels of the verb_levels table
+// when verbs.db has one.
+func LoadInfinitiveIndex(ctx context.Context, q *Queries) (*InfinitiveIndex, error) {
+	infinitives, err := q.ListInfinitives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing infinitives: %w", err)
+	}
+	levels, err := ListVerbLevels(ctx, q.db)
+	if err != nil {
+		return nil, err
+	}
+	return NewInfinitiveIndex(infinitives).WithLevels(levels), nil
+}
+
+// WithLevels sets the levels Level returns and returns idx.
+func (idx *InfinitiveIndex) WithLevels(levels map[string]VerbLevel) *InfinitiveIndex {
+	idx.levels = levels
+	return idx
+}
+
+// Infinitives returns every infinitive of the index in the order it was indexed. The caller must not modify it.
+func (idx *InfinitiveIndex) Infinitives() []string {
+	return idx.all
+}
+
+// Level returns the level of the canonical infinitive, if it is ranked.
+func (idx *InfinitiveIndex) Level(infinitive string) (VerbLevel, bool) {
+	level, ok := idx.levels[infinitive]
+	return level, ok
+}
+
+// Resolve returns the canonical spelling of the infinitive input refers to. It returns ErrNotFound when nothing
+// matches and an *AmbiguousInfinitiveError when the folded key matches several infinitives.
+func (idx *InfinitiveIndex) Resolve(input string) (string, error) {
+	normalized := spanish.Normalize(input)
+	if idx.canonical[normalized] {
+		return normalized, nil
+	}
+
+	candidates := idx.folded[spanish.Fold(normalized)]
+	switch len(candidates) {
+	case 0:
+		return "", ErrNotFound
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", &AmbiguousInfinitiveError{Input: input, Candidates: append([]string(nil), candidates...)}
+	}
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInfinitiveIndexResolve(t *testing.T) {
+	idx := NewInfinitiveIndex([]Infinitive{
+		{Infinitive: "hablar"},
+		{Infinitive: "oír"},
+		{Infinitive: "reír"},
+		{Infinitive: "añadir"},
+		{Infinitive: "cañar"},
+		{Infinitive: "canar"},
+		{Infinitive: "cánar"},
+	})
+
+	tests := []struct {
+		name       string
+		input      string
+		want       string
+		wantErr    error
+		candidates []string
+	}{
+		{name: "exact", input: "hablar", want: "hablar"},
+		{name: "uppercase", input: "Oir", want: "oír"},
+		{name: "uppercase accent", input: "OÍR", want: "oír"},
+		{name: "whitespace", input: " oír ", want: "oír"},
+		{name: "missing accent", input: "reir", want: "reír"},
+		{name: "decomposed accent", input: "rei\u0301r", want: "reír"},
+		{name: "missing tilde", input: "anadir", want: "añadir"},
+		{name: "exact wins over collision", input: "CAÑAR", want: "cañar"},
+		{name: "ambiguous", input: "cañár", candidates: []string{"canar", "cañar", "cánar"}},
+		{name: "unknown", input: "blorp", wantErr: ErrNotFound},
+		{name: "empty", input: "  ", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := idx.Resolve(tt.input)
+
+			if tt.candidates != nil {
+				var ambiguous *AmbiguousInfinitiveError
+				if !errors.As(err, &ambiguous) {
+					t.Fatalf("Resolve(%q) error = %v, want *AmbiguousInfinitiveError", tt.input, err)
+				}
+				if !reflect.DeepEqual(ambiguous.Candidates, tt.candidates) {
+					t.Errorf("candidates = %v, want %v", ambiguous.Candidates, tt.candidates)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolve(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MoodTense identifies one conjugation table of a verb.
+type MoodTense struct {
+	Mood  string
+	Tense string
+}
+
+// ExpectedMoodTenses lists the mood and tense combinations every verb is expected to have.
+var ExpectedMoodTenses = []MoodTense{
+	{"Indicativo", "Presente"},
+	{"Indicativo", "Pretérito"},
+	{"Indicativo", "Imperfecto"},
+	{"Indicativo", "Condicional"},
+	{"Indicativo", "Futuro"},
+	{"Indicativo", "Presente perfecto"},
+	{"Indicativo", "Pretérito anterior"},
+	{"Indicativo", "Pluscuamperfecto"},
+	{"Indicativo", "Condicional perfecto"},
+	{"Indicativo", "Futuro perfecto"},
+	{"Subjuntivo", "Presente"},
+	{"Subjuntivo", "Imperfecto"},
+	{"Subjuntivo", "Futuro"},
+	{"Subjuntivo", "Presente perfecto"},
+	{"Subjuntivo", "Pluscuamperfecto"},
+	{"Subjuntivo", "Futuro perfecto"},
+	{"Imperativo Afirmativo", "Presente"},
+	{"Imperativo Negativo", "Presente"},
+}
+
+// listMoodTenses selects every mood and tense verbs.db conjugates, with the English names of the mood and the tense.
+const listMoodTenses = `SELECT DISTINCT v.mood, v.tense, coalesce(m.mood_english, ''), coalesce(t.tense_english, '')
+FROM verbs v
+LEFT JOIN mood m ON m.mood = v.mood
+LEFT JOIN tense t ON t.tense = v.tense`
+
+// ListMoodTenses returns every mood and tense with at least one row in the verbs table, mapped to its English name:
+// the English tense in the indicative, and the English mood before it otherwise, as in "Subjunctive Future Perfect".
+func ListMoodTenses(ctx context.Context, db DBTX) (map[MoodTense]string, error) {
+	rows, err := db.QueryContext(ctx, listMoodTenses)
+	if err != nil {
+		return nil, fmt.Errorf("listing moods and tenses: %w", err)
+	}
+	defer rows.Close()
+	english := make(map[MoodTense]string)
+	for rows.Next() {
+		var (
+			mt                 MoodTense
+			mood, tenseEnglish string
+		)
+		if err := rows.Scan(&mt.Mood, &mt.Tense, &mood, &tenseEnglish); err != nil {
+			return nil, fmt.Errorf("listing moods and tenses: %w", err)
+		}
+		if mt.Mood != "Indicativo" && mood != "" {
+			tenseEnglish = strings.TrimSpace(mood + " " + tenseEnglish)
+		}
+		english[mt] = tenseEnglish
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing moods and tenses: %w", err)
+	}
+	return english, nil
+}
+
+// optionalForms lists the persons that are legitimately empty for a mood. Imperative rows have no yo form and keep
+// nosotros empty.
+var optionalForms = map[string]map[string]bool{
+	"Imperativo Afirmativo": {Person1s: true, Person1p: true},
+	"Imperativo Negativo":   {Person1s: true, Person1p: true},
+}
+
+// Problem describes one integrity issue found in the verb data.
+type Problem struct {
+	Table   string
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s[%s]: %s", p.Table, p.Key, p.Message)
+}
+
+// snapshot holds every reference table in memory so checks can cross-reference them.
+type snapshot struct {
+	infinitives     []Infinitive
+	moods           []Mood
+	tenses          []Tense
+	gerunds         []Gerund
+	pastparticiples []Pastparticiple
+	verbs           []Verb
+}
+
+func loadSnapshot(ctx context.Context, q *Queries) (*snapshot, error) {
+	var (
+		s   snapshot
+		err error
+	)
+	if s.infinitives, err = q.ListInfinitives(ctx); err != nil {
+		return nil, fmt.Errorf("listing infinitives: %w", err)
+	}
+	if s.moods, err = q.ListMoods(ctx); err != nil {
+		return nil, fmt.Errorf("listing moods: %w", err)
+	}
+	if s.tenses, err = q.ListTenses(ctx); err != nil {
+		return nil, fmt.Errorf("listing tenses: %w", err)
+	}
+	if s.gerunds, err = q.ListGerunds(ctx); err != nil {
+		return nil, fmt.Errorf("listing gerunds: %w", err)
+	}
+	if s.pastparticiples, err = q.ListPastparticiples(ctx); err != nil {
+		return nil, fmt.Errorf("listing past participles: %w", err)
+	}
+	if s.verbs, err = q.ListVerbs(ctx); err != nil {
+		return nil, fmt.Errorf("listing verbs: %w", err)
+	}
+	return &s, nil
+}
+
+// ValidateIntegrity checks that every infinitive has all ExpectedMoodTenses rows, that every row refers to existing
+// infinitives, moods and tenses, and that no required form is empty.
+func ValidateIntegrity(ctx context.Context, q *Queries) ([]Problem, error) {
+	s, err := loadSnapshot(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	infinitives := make(map[string]bool, len(s.infinitives))
+	for _, inf := range s.infinitives {
+		infinitives[inf.Infinitive] = true
+	}
+	moods := make(map[string]bool, len(s.moods))
+	for _, m := range s.moods {
+		moods[m.Mood] = true
+	}
+	tenses := make(map[string]bool, len(s.tenses))
+	for _, t := range s.tenses {
+		tenses[t.Tense] = true
+	}
+
+	var problems []Problem
+	rowsByInfinitive := make(map[string]map[MoodTense]bool)
+	for _, v := range s.verbs {
+		key := fmt.Sprintf("%s/%s/%s", v.Infinitive, v.Mood, v.Tense)
+		if !infinitives[v.Infinitive] {
+			problems = append(problems, Problem{"verbs", key, "infinitive missing from infinitive table"})
+		}
+		if !moods[v.Mood] {
+			problems = append(problems, Problem{"verbs", key, "mood missing from mood table"})
+		}
+		if !tenses[v.Tense] {
+			problems = append(problems, Problem{"verbs", key, "tense missing from tense table"})
+		}
+		for _, person := range Persons {
+			form, _ := v.Form(person)
+			if form == "" && !optionalForms[v.Mood][person] {
+				problems = append(problems, Problem{"verbs", key, fmt.Sprintf("form_%s is empty", person)})
+			}
+		}
+
+		if rowsByInfinitive[v.Infinitive] == nil {
+			rowsByInfinitive[v.Infinitive] = make(map[MoodTense]bool)
+		}
+		rowsByInfinitive[v.Infinitive][MoodTense{v.Mood, v.Tense}] = true
+	}
+
+	for _, inf := range s.infinitives {
+		rows := rowsByInfinitive[inf.Infinitive]
+		for _, mt := range ExpectedMoodTenses {
+			if !rows[mt] {
+				problems = append(problems, Problem{"verbs", inf.Infinitive, fmt.Sprintf("missing %s %s", mt.Mood, mt.Tense)})
+			}
+		}
+	}
+
+	gerunds := make(map[string]bool, len(s.gerunds))
+	for _, g := range s.gerunds {
+		gerunds[g.Infinitive] = true
+		if !infinitives[g.Infinitive] {
+			problems = append(problems, Problem{"gerund", g.Infinitive, "infinitive missing from infinitive table"})
+		}
+		if g.Gerund == "" {
+			problems = append(problems, Problem{"gerund", g.Infinitive, "gerund is empty"})
+		}
+	}
+	participles := make(map[string]bool, len(s.pastparticiples))
+	for _, p := range s.pastparticiples {
+		participles[p.Infinitive] = true
+		if !infinitives[p.Infinitive] {
+			problems = append(problems, Problem{"pastparticiple", p.Infinitive, "infinitive missing from infinitive table"})
+		}
+		if p.Pastparticiple == "" {
+			problems = append(problems, Problem{"pastparticiple", p.Infinitive, "past participle is empty"})
+		}
+	}
+	for _, inf := range s.infinitives {
+		if !gerunds[inf.Infinitive] {
+			problems = append(problems, Problem{"gerund", inf.Infinitive, "missing gerund"})
+		}
+		if !participles[inf.Infinitive] {
+			problems = append(problems, Problem{"pastparticiple", inf.Infinitive, "missing past participle"})
+		}
+	}
+
+	return problems, nil
+}
+
+// MoodTenseCoverage counts the verbs and examples available for one mood and tense.
+type MoodTenseCoverage struct {
+	MoodTense
+	Verbs    int
+	Examples int
+}
+
+// Coverage summarises how complete the verb data is.
+type Coverage struct {
+	Infinitives         int
+	CompleteInfinitives int
+	Verbs               int
+	Moods               int
+	Tenses              int
+	Gerunds             int
+	Pastparticiples     int
+	Examples            int
+	ByMoodTense         []MoodTenseCoverage
+}
+
+// ComputeCoverage counts rows per table and per mood and tense, and how many infinitives have a complete set of
+// conjugation tables.
+func ComputeCoverage(ctx context.Context, q *Queries) (Coverage, error) {
+	s, err := loadSnapshot(ctx, q)
+	if err != nil {
+		return Coverage{}, err
+	}
+	examples, err := q.ListExamples(ctx)
+	if err != nil {
+		return Coverage{}, fmt.Errorf("listing examples: %w", err)
+	}
+
+	c := Coverage{
+		Infinitives:     len(s.infinitives),
+		Verbs:           len(s.verbs),
+		Moods:           len(s.moods),
+		Tenses:          len(s.tenses),
+		Gerunds:         len(s.gerunds),
+		Pastparticiples: len(s.pastparticiples),
+		Examples:        len(examples),
+	}
+
+	counts := make(map[MoodTense]*MoodTenseCoverage)
+	countFor := func(mt MoodTense) *MoodTenseCoverage {
+		if counts[mt] == nil {
+			counts[mt] = &MoodTenseCoverage{MoodTense: mt}
+		}
+		return counts[mt]
+	}
+	for _, mt := range ExpectedMoodTenses {
+		countFor(mt)
+	}
+
+	rowsByInfinitive := make(map[string]int)
+	for _, v := range s.verbs {
+		countFor(MoodTense{v.Mood, v.Tense}).Verbs++
+		rowsByInfinitive[v.Infinitive]++
+	}
+	for _, ex := range examples {
+		countFor(MoodTense{ex.Mood, ex.Tense}).Examples++
+	}
+	for _, inf := range s.infinitives {
+		if rowsByInfinitive[inf.Infinitive] >= len(ExpectedMoodTenses) {
+			c.CompleteInfinitives++
+		}
+	}
+
+	order := make(map[MoodTense]int, len(ExpectedMoodTenses))
+	for i, mt := range ExpectedMoodTenses {
+		order[mt] = i
+	}
+	for _, mtc := range counts {
+		c.ByMoodTense = append(c.ByMoodTense, *mtc)
+	}
+	sort.Slice(c.ByMoodTense, func(i, j int) bool {
+		a, b := c.ByMoodTense[i].MoodTense, c.ByMoodTense[j].MoodTense
+		ia, aok := order[a]
+		ib, bok := order[b]
+		switch {
+		case aok && bok:
+			return ia < ib
+		case aok != bok:
+			return aok
+		case a.Mood != b.Mood:
+			return a.Mood < b.Mood
+		default:
+			return a.Tense < b.Tense
+		}
+	})
+
+	return c, nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidateIntegrity(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := newTestDB(t)
+
+	_, err := sqlDB.Exec(`
+		INSERT INTO infinitive VALUES ('hablar', 'to speak');
+		INSERT INTO mood VALUES ('Indicativo', 'Indicative');
+		INSERT INTO tense VALUES ('Presente', 'Present');
+		INSERT INTO gerund VALUES ('hablar', 'hablando', 'speaking');
+		UPDATE verbs SET form_3p = '' WHERE tense = 'Presente perfecto'`)
+	if err != nil {
+		t.Fatalf("Failed to insert lookup rows: %v", err)
+	}
+
+	problems, err := ValidateIntegrity(ctx, New(sqlDB))
+	if err != nil {
+		t.Fatalf("ValidateIntegrity() returned an error: %v", err)
+	}
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	report := strings.Join(messages, "\n")
+
+	for _, want := range []string{
+		"verbs[hablar/Indicativo/Presente perfecto]: tense missing from tense table",
+		"verbs[hablar/Imperativo Afirmativo/Presente]: mood missing from mood table",
+		"verbs[hablar/Indicativo/Presente perfecto]: form_3p is empty",
+		"verbs[hablar]: missing Subjuntivo Presente",
+		"pastparticiple[hablar]: missing past participle",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected problem %q, got:\n%s", want, report)
+		}
+	}
+	for _, unwanted := range []string{"form_1s is empty", "form_1p is empty", "missing Indicativo Presente\n", "missing gerund"} {
+		if strings.Contains(report+"\n", unwanted) {
+			t.Errorf("Did not expect problem %q, got:\n%s", unwanted, report)
+		}
+	}
+}
+
+func TestComputeCoverage(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := newTestDB(t)
+
+	_, err := sqlDB.Exec(`
+		INSERT INTO infinitive VALUES ('hablar', 'to speak'), ('comer', 'to eat');
+		INSERT INTO examples (infinitive, mood, tense, person, sentence) VALUES ('hablar', 'Indicativo', 'Presente', '1s', 'Hablo.')`)
+	if err != nil {
+		t.Fatalf("Failed to insert rows: %v", err)
+	}
+
+	c, err := ComputeCoverage(ctx, New(sqlDB))
+	if err != nil {
+		t.Fatalf("ComputeCoverage() returned an error: %v", err)
+	}
+	if c.Infinitives != 2 || c.Verbs != 3 || c.Examples != 1 || c.CompleteInfinitives != 0 {
+		t.Errorf("Unexpected coverage %+v", c)
+	}
+	if len(c.ByMoodTense) != len(ExpectedMoodTenses) {
+		t.Fatalf("Expected %d mood/tense rows, got %d", len(ExpectedMoodTenses), len(c.ByMoodTense))
+	}
+	first := c.ByMoodTense[0]
+	if first.Mood != "Indicativo" || first.Tense != "Presente" || first.Verbs != 1 || first.Examples != 1 {
+		t.Errorf("Unexpected first row %+v", first)
+	}
+}
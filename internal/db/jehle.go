@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// jehleColumns lists the columns of Fred Jehle's jehle_verb_database.csv. Each row is one verb in one mood and tense,
+// repeating the lookup values for the infinitive, mood, tense, gerund and past participle.
+var jehleColumns = []string{
+	"infinitive", "infinitive_english",
+	"mood", "mood_english",
+	"tense", "tense_english",
+	"verb_english",
+	"form_1s", "form_2s", "form_3s", "form_1p", "form_2p", "form_3p",
+	"gerund", "gerund_english",
+	"pastparticiple", "pastparticiple_english",
+}
+
+const (
+	errJehleHeader  = "error reading CSV header"
+	errJehleColumn  = "CSV is missing column %q"
+	errJehleEmpty   = "line %d: %s is empty"
+	errJehleUpsert  = "line %d: error writing %s: %w"
+	errJehleNoRows  = "CSV has no data rows"
+	errJehleCommit  = "error committing import"
+	errJehleBeginTx = "error starting import"
+)
+
+// JehleImportResult counts the rows written by ImportJehleCSV.
+type JehleImportResult struct {
+	Rows        int
+	Infinitives int
+}
+
+// ImportJehleCSV upserts every row of a CSV in the jehle_verb_database format into the infinitive, mood, tense,
+// verbs, gerund and pastparticiple tables in a single transaction. Columns are matched by header name, so extra
+// columns and a different column order are accepted. Forms are stored exactly as they appear in the file.
+func ImportJehleCSV(ctx context.Context, sqlDB *sql.DB, r io.Reader) (JehleImportResult, error) {
+	var result JehleImportResult
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", errJehleHeader, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, col := range jehleColumns {
+		if _, ok := index[col]; !ok {
+			return result, fmt.Errorf(errJehleColumn, col)
+		}
+	}
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", errJehleBeginTx, err)
+	}
+	defer tx.Rollback()
+	q := New(tx)
+
+	seen := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(col string) string {
+			if i := index[col]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		nullable := func(col string) sql.NullString {
+			v := get(col)
+			return sql.NullString{String: v, Valid: v != ""}
+		}
+
+		for _, col := range []string{"infinitive", "mood", "tense"} {
+			if get(col) == "" {
+				return result, fmt.Errorf(errJehleEmpty, line, col)
+			}
+		}
+		infinitive := get("infinitive")
+
+		if err := q.UpsertInfinitive(ctx, UpsertInfinitiveParams{Infinitive: infinitive, InfinitiveEnglish: nullable("infinitive_english")}); err != nil {
+			return result, fmt.Errorf(errJehleUpsert, line, "infinitive", err)
+		}
+		if err := q.UpsertMood(ctx, UpsertMoodParams{Mood: get("mood"), MoodEnglish: nullable("mood_english")}); err != nil {
+			return result, fmt.Errorf(errJehleUpsert, line, "mood", err)
+		}
+		if err := q.UpsertTense(ctx, UpsertTenseParams{Tense: get("tense"), TenseEnglish: nullable("tense_english")}); err != nil {
+			return result, fmt.Errorf(errJehleUpsert, line, "tense", err)
+		}
+		if err := q.UpsertVerb(ctx, UpsertVerbParams{
+			Infinitive:  infinitive,
+			Mood:        get("mood"),
+			Tense:       get("tense"),
+			VerbEnglish: nullable("verb_english"),
+			Form1s:      nullable("form_1s"),
+			Form2s:      nullable("form_2s"),
+			Form3s:      nullable("form_3s"),
+			Form1p:      nullable("form_1p"),
+			Form2p:      nullable("form_2p"),
+			Form3p:      nullable("form_3p"),
+		}); err != nil {
+			return result, fmt.Errorf(errJehleUpsert, line, "verb", err)
+		}
+		if gerund := get("gerund"); gerund != "" {
+			if err := q.UpsertGerund(ctx, UpsertGerundParams{Infinitive: infinitive, Gerund: gerund, GerundEnglish: nullable("gerund_english")}); err != nil {
+				return result, fmt.Errorf(errJehleUpsert, line, "gerund", err)
+			}
+		}
+		if participle := get("pastparticiple"); participle != "" {
+			if err := q.UpsertPastparticiple(ctx, UpsertPastparticipleParams{Infinitive: infinitive, Pastparticiple: participle, PastparticipleEnglish: nullable("pastparticiple_english")}); err != nil {
+				return result, fmt.Errorf(errJehleUpsert, line, "past participle", err)
+			}
+		}
+
+		result.Rows++
+		if !seen[infinitive] {
+			seen[infinitive] = true
+			result.Infinitives++
+		}
+	}
+
+	if result.Rows == 0 {
+		return result, errors.New(errJehleNoRows)
+	}
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("%s: %w", errJehleCommit, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const jehleCSV = `infinitive,infinitive_english,mood,mood_english,tense,tense_english,verb_english,form_1s,form_2s,form_3s,form_1p,form_2p,form_3p,gerund,gerund_english,pastparticiple,pastparticiple_english
+comer,to eat,Indicativo,Indicative,Presente,Present,"I eat, am eating",como,comes,come,comemos,coméis,comen,comiendo,eating,comido,eaten
+comer,to eat,Indicativo,Indicative,Futuro,Future,I will eat,comeré,comerás,comerá,comeremos,comeréis,comerán,comiendo,eating,comido,eaten
+`
+
+func TestImportJehleCSV(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := newTestDB(t)
+
+	result, err := ImportJehleCSV(ctx, sqlDB, strings.NewReader(jehleCSV))
+	if err != nil {
+		t.Fatalf("ImportJehleCSV() returned an error: %v", err)
+	}
+	if result.Rows != 2 || result.Infinitives != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	q := New(sqlDB)
+	verb, err := q.GetVerbByInfinitiveMoodTense(ctx, GetVerbByInfinitiveMoodTenseParams{Infinitive: "comer", Mood: "Indicativo", Tense: "Presente"})
+	if err != nil {
+		t.Fatalf("GetVerbByInfinitiveMoodTense() returned an error: %v", err)
+	}
+	if NullStringToString(verb.Form2p) != "coméis" || NullStringToString(verb.VerbEnglish) != "I eat, am eating" {
+		t.Errorf("Unexpected verb %+v", verb)
+	}
+
+	gerunds, err := q.ListGerunds(ctx)
+	if err != nil {
+		t.Fatalf("ListGerunds() returned an error: %v", err)
+	}
+	if len(gerunds) != 1 || gerunds[0].Gerund != "comiendo" {
+		t.Errorf("Unexpected gerunds %+v", gerunds)
+	}
+
+	// Importing the same file again updates rows in place.
+	if _, err := ImportJehleCSV(ctx, sqlDB, strings.NewReader(jehleCSV)); err != nil {
+		t.Fatalf("ImportJehleCSV() returned an error on re-import: %v", err)
+	}
+	verbs, err := q.GetVerbsByInfinitive(ctx, "comer")
+	if err != nil {
+		t.Fatalf("GetVerbsByInfinitive() returned an error: %v", err)
+	}
+	if len(verbs) != 2 {
+		t.Errorf("Expected 2 rows after re-import, got %d", len(verbs))
+	}
+}
+
+func TestImportJehleCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing column", "infinitive,mood,tense\ncomer,Indicativo,Presente\n", `missing column "infinitive_english"`},
+		{"no rows", strings.SplitN(jehleCSV, "\n", 2)[0] + "\n", "no data rows"},
+		{"empty infinitive", strings.SplitN(jehleCSV, "\n", 2)[0] + "\n,to eat,Indicativo,,Presente,,,,,,,,,,,,\n", "line 2: infinitive is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportJehleCSV(context.Background(), newTestDB(t), strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
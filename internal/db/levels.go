@@ -0,0 +1,194 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// createLevelsTable creates the verb_levels table, which verbs.db only has once levels are imported.
+const createLevelsTable = `CREATE TABLE IF NOT EXISTS verb_levels (
+    infinitive character varying NOT NULL PRIMARY KEY,
+    rank integer NOT NULL UNIQUE,
+    level character varying NOT NULL
+)`
+
+const (
+	levelsTableExists = `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'verb_levels'`
+	listLevels        = `SELECT infinitive, rank, level FROM verb_levels ORDER BY rank`
+
+	levelsTSVColumns = 3
+
+	errLevelsRead      = "error reading levels file"
+	errLevelColumns    = "line %d: expected %d tab-separated columns, got %d"
+	errLevelRank       = "line %d: rank %q is not a positive integer"
+	errLevelUnknown    = "line %d: level %q is not one of %s"
+	errLevelEmpty      = "line %d: infinitive is empty"
+	errLevelDupRank    = "line %d: rank %d is already given on line %d"
+	errLevelDupVerb    = "line %d: %q is already ranked on line %d"
+	errLevelNoVerb     = "line %d: %q is not in the infinitive table"
+	errLevelsTable     = "error creating verb_levels table"
+	errLevelInsert     = "line %d: error inserting level: %w"
+	errLevelsListError = "listing verb levels: %w"
+)
+
+// CEFRLevels are the levels of the Common European Framework of Reference, easiest first.
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+// VerbLevel is how common a verb is: its rank by frequency, 1 for the most common, and the CEFR level it is taught at.
+type VerbLevel struct {
+	Rank  int
+	Level string
+}
+
+// LevelLine is a verb level parsed from a TSV file, along with the line it came from.
+type LevelLine struct {
+	Line       int
+	Infinitive string
+	VerbLevel
+}
+
+// ParseLevelsTSV reads verb levels from tab-separated lines of rank, infinitive and CEFR level. Blank lines, lines
+// starting with '#' and a header row starting with "rank" are skipped. Levels are matched regardless of case. Every
+// rank and infinitive must appear once.
+func ParseLevelsTSV(r io.Reader) ([]LevelLine, error) {
+	var (
+		lines []LevelLine
+		errs  []error
+	)
+	ranks := make(map[int]int)
+	infinitives := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "rank\t") {
+			continue
+		}
+
+		cols := strings.Split(text, "\t")
+		if len(cols) != levelsTSVColumns {
+			errs = append(errs, fmt.Errorf(errLevelColumns, n, levelsTSVColumns, len(cols)))
+			continue
+		}
+		for i := range cols {
+			cols[i] = strings.TrimSpace(cols[i])
+		}
+
+		rank, err := strconv.Atoi(cols[0])
+		if err != nil || rank < 1 {
+			errs = append(errs, fmt.Errorf(errLevelRank, n, cols[0]))
+			continue
+		}
+		if cols[1] == "" {
+			errs = append(errs, fmt.Errorf(errLevelEmpty, n))
+			continue
+		}
+		level := strings.ToUpper(cols[2])
+		if !slices.Contains(CEFRLevels, level) {
+			errs = append(errs, fmt.Errorf(errLevelUnknown, n, cols[2], strings.Join(CEFRLevels, ", ")))
+			continue
+		}
+		if prev, dup := ranks[rank]; dup {
+			errs = append(errs, fmt.Errorf(errLevelDupRank, n, rank, prev))
+			continue
+		}
+		if prev, dup := infinitives[cols[1]]; dup {
+			errs = append(errs, fmt.Errorf(errLevelDupVerb, n, cols[1], prev))
+			continue
+		}
+		ranks[rank] = n
+		infinitives[cols[1]] = n
+		lines = append(lines, LevelLine{Line: n, Infinitive: cols[1], VerbLevel: VerbLevel{Rank: rank, Level: level}})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errLevelsRead, err)
+	}
+
+	return lines, errors.Join(errs...)
+}
+
+// CheckLevelInfinitives returns the lines whose infinitive is in the infinitive table, and in unknown an error for
+// every line whose infinitive is not. err is only set when the infinitives cannot be read.
+func CheckLevelInfinitives(ctx context.Context, q *Queries, lines []LevelLine) (known []LevelLine, unknown, err error) {
+	rows, err := q.ListInfinitives(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing infinitives: %w", err)
+	}
+	infinitives := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		infinitives[row.Infinitive] = true
+	}
+
+	var errs []error
+	for _, l := range lines {
+		if !infinitives[l.Infinitive] {
+			errs = append(errs, fmt.Errorf(errLevelNoVerb, l.Line, l.Infinitive))
+			continue
+		}
+		known = append(known, l)
+	}
+	return known, errors.Join(errs...), nil
+}
+
+// ImportLevels replaces the contents of the verb_levels table, creating it if needed, with lines in a single
+// transaction: the file is the whole ranking, so verbs it no longer lists lose their level.
+func ImportLevels(ctx context.Context, sqlDB *sql.DB, lines []LevelLine) error {
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, createLevelsTable); err != nil {
+		return fmt.Errorf("%s: %w", errLevelsTable, err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM verb_levels"); err != nil {
+		return err
+	}
+	for _, l := range lines {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO verb_levels (infinitive, rank, level) VALUES (?, ?, ?)",
+			l.Infinitive, l.Rank, l.Level); err != nil {
+			return fmt.Errorf(errLevelInsert, l.Line, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListVerbLevels returns the level of every ranked infinitive, or none when verbs.db has no verb_levels table.
+func ListVerbLevels(ctx context.Context, db DBTX) (map[string]VerbLevel, error) {
+	var count int
+	if err := db.QueryRowContext(ctx, levelsTableExists).Scan(&count); err != nil {
+		return nil, fmt.Errorf(errLevelsListError, err)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, listLevels)
+	if err != nil {
+		return nil, fmt.Errorf(errLevelsListError, err)
+	}
+	defer rows.Close()
+	levels := make(map[string]VerbLevel)
+	for rows.Next() {
+		var (
+			infinitive string
+			level      VerbLevel
+		)
+		if err := rows.Scan(&infinitive, &level.Rank, &level.Level); err != nil {
+			return nil, fmt.Errorf(errLevelsListError, err)
+		}
+		levels[infinitive] = level
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(errLevelsListError, err)
+	}
+	return levels, nil
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const levelsTSV = "rank\tinfinitive\tlevel\n" +
+	"# comment\n" +
+	"1\tser\tA1\n" +
+	"2\thablar\ta1\n" +
+	"\n" +
+	"3\tzzzar\tB2\n"
+
+func TestParseLevelsTSV(t *testing.T) {
+	lines, err := ParseLevelsTSV(strings.NewReader(levelsTSV))
+	if err != nil {
+		t.Fatalf("ParseLevelsTSV() returned an error: %v", err)
+	}
+	want := []LevelLine{
+		{Line: 3, Infinitive: "ser", VerbLevel: VerbLevel{Rank: 1, Level: "A1"}},
+		{Line: 4, Infinitive: "hablar", VerbLevel: VerbLevel{Rank: 2, Level: "A1"}},
+		{Line: 6, Infinitive: "zzzar", VerbLevel: VerbLevel{Rank: 3, Level: "B2"}},
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("ParseLevelsTSV() = %+v, want %+v", lines, want)
+	}
+}
+
+func TestParseLevelsTSVErrors(t *testing.T) {
+	input := "1\tser\tA1\n" +
+		"0\testar\tA1\n" +
+		"x\testar\tA1\n" +
+		"2\testar\tD1\n" +
+		"1\testar\tA1\n" +
+		"3\tser\tA2\n" +
+		"4\t\tA2\n" +
+		"5\tir\n"
+	lines, err := ParseLevelsTSV(strings.NewReader(input))
+	if len(lines) != 1 {
+		t.Errorf("Expected only the first line to parse, got %+v", lines)
+	}
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	for _, want := range []string{
+		`line 2: rank "0"`,
+		`line 3: rank "x"`,
+		`line 4: level "D1"`,
+		"line 5: rank 1 is already given on line 1",
+		`line 6: "ser" is already ranked on line 1`,
+		"line 7: infinitive is empty",
+		"line 8: expected 3",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestImportLevels(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := newTestDB(t)
+	if _, err := sqlDB.Exec(`INSERT INTO infinitive VALUES ('ser', 'to be'), ('hablar', 'to speak')`); err != nil {
+		t.Fatalf("Failed to prepare database: %v", err)
+	}
+
+	// A verbs.db without levels has none.
+	if levels, err := ListVerbLevels(ctx, sqlDB); err != nil || len(levels) != 0 {
+		t.Errorf("ListVerbLevels() = %v, %v, want no levels", levels, err)
+	}
+
+	lines, err := ParseLevelsTSV(strings.NewReader(levelsTSV))
+	if err != nil {
+		t.Fatalf("ParseLevelsTSV() returned an error: %v", err)
+	}
+	known, unknown, err := CheckLevelInfinitives(ctx, New(sqlDB), lines)
+	if err != nil {
+		t.Fatalf("CheckLevelInfinitives() returned an error: %v", err)
+	}
+	if unknown == nil || !strings.Contains(unknown.Error(), `line 6: "zzzar" is not in the infinitive table`) {
+		t.Errorf("CheckLevelInfinitives() unknown = %v, want zzzar flagged", unknown)
+	}
+	if len(known) != 2 {
+		t.Fatalf("Expected 2 known infinitives, got %+v", known)
+	}
+
+	if err := ImportLevels(ctx, sqlDB, known); err != nil {
+		t.Fatalf("ImportLevels() returned an error: %v", err)
+	}
+	// A second import replaces the first.
+	if err := ImportLevels(ctx, sqlDB, known[1:]); err != nil {
+		t.Fatalf("ImportLevels() returned an error: %v", err)
+	}
+
+	idx, err := LoadInfinitiveIndex(ctx, New(sqlDB))
+	if err != nil {
+		t.Fatalf("LoadInfinitiveIndex() returned an error: %v", err)
+	}
+	if level, ok := idx.Level("hablar"); !ok || level != (VerbLevel{Rank: 2, Level: "A1"}) {
+		t.Errorf("Level(hablar) = %+v, %v, want rank 2, A1", level, ok)
+	}
+	if _, ok := idx.Level("ser"); ok {
+		t.Error("Expected ser to lose its level when the second import left it out")
+	}
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MemoryData is the reference data a MemoryRepository serves.
+type MemoryData struct {
+	Infinitives     []Infinitive
+	Verbs           []Verb
+	Gerunds         []Gerund
+	Pastparticiples []Pastparticiple
+	Examples        []Example
+}
+
+// MemoryRepository is a VerbRepository that serves everything from memory. The reference data never changes while
+// the bot runs, so it is loaded once at startup and no lookup touches the database afterwards.
+type MemoryRepository struct {
+	infinitives     []Infinitive
+	verbs           map[string][]Verb
+	forms           map[string][]FormMatch
+	gerunds         map[string]Gerund
+	pastparticiples map[string]Pastparticiple
+	examples        map[verbKey][]Example
+}
+
+// verbKey identifies one conjugation table of one infinitive.
+type verbKey struct {
+	infinitive string
+	mood       string
+	tense      string
+}
+
+// NewMemoryRepository indexes data for lookups. The slices are not modified.
+func NewMemoryRepository(data MemoryData) *MemoryRepository {
+	r := &MemoryRepository{
+		infinitives:     append([]Infinitive(nil), data.Infinitives...),
+		verbs:           make(map[string][]Verb),
+		forms:           make(map[string][]FormMatch),
+		gerunds:         make(map[string]Gerund, len(data.Gerunds)),
+		pastparticiples: make(map[string]Pastparticiple, len(data.Pastparticiples)),
+		examples:        make(map[verbKey][]Example),
+	}
+
+	sort.Slice(r.infinitives, func(i, j int) bool { return r.infinitives[i].Infinitive < r.infinitives[j].Infinitive })
+
+	for _, v := range data.Verbs {
+		r.verbs[v.Infinitive] = append(r.verbs[v.Infinitive], v)
+		for _, person := range Persons {
+			if form, _ := v.Form(person); form != "" {
+				r.forms[form] = append(r.forms[form], FormMatch{Verb: v, Person: person})
+			}
+		}
+	}
+	for _, verbs := range r.verbs {
+		sort.Slice(verbs, func(i, j int) bool {
+			if verbs[i].Mood != verbs[j].Mood {
+				return verbs[i].Mood < verbs[j].Mood
+			}
+			return verbs[i].Tense < verbs[j].Tense
+		})
+	}
+	for _, g := range data.Gerunds {
+		r.gerunds[g.Infinitive] = g
+	}
+	for _, p := range data.Pastparticiples {
+		r.pastparticiples[p.Infinitive] = p
+	}
+	for _, ex := range data.Examples {
+		key := verbKey{ex.Infinitive, ex.Mood, ex.Tense}
+		r.examples[key] = append(r.examples[key], ex)
+	}
+	for _, examples := range r.examples {
+		sort.Slice(examples, func(i, j int) bool { return examples[i].ID < examples[j].ID })
+	}
+
+	return r
+}
+
+// LoadMemoryRepository reads all reference data through q into a MemoryRepository.
+func LoadMemoryRepository(ctx context.Context, q *Queries) (*MemoryRepository, error) {
+	var (
+		data MemoryData
+		err  error
+	)
+	if data.Infinitives, err = q.ListInfinitives(ctx); err != nil {
+		return nil, fmt.Errorf("listing infinitives: %w", err)
+	}
+	if data.Verbs, err = q.ListVerbs(ctx); err != nil {
+		return nil, fmt.Errorf("listing verbs: %w", err)
+	}
+	if data.Gerunds, err = q.ListGerunds(ctx); err != nil {
+		return nil, fmt.Errorf("listing gerunds: %w", err)
+	}
+	if data.Pastparticiples, err = q.ListPastparticiples(ctx); err != nil {
+		return nil, fmt.Errorf("listing past participles: %w", err)
+	}
+	if data.Examples, err = q.ListExamples(ctx); err != nil {
+		return nil, fmt.Errorf("listing examples: %w", err)
+	}
+	return NewMemoryRepository(data), nil
+}
+
+func (r *MemoryRepository) GetVerb(ctx context.Context, infinitive, mood, tense string) (Verb, error) {
+	for _, v := range r.verbs[infinitive] {
+		if v.Mood == mood && v.Tense == tense {
+			return v, nil
+		}
+	}
+	return Verb{}, ErrNotFound
+}
+
+func (r *MemoryRepository) GetVerbs(ctx context.Context, infinitive string) ([]Verb, error) {
+	verbs, ok := r.verbs[infinitive]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]Verb(nil), verbs...), nil
+}
+
+func (r *MemoryRepository) SearchInfinitives(ctx context.Context, prefix string, limit int) ([]Infinitive, error) {
+	prefix = strings.ToLower(stripLikeWildcards(prefix))
+	start := sort.Search(len(r.infinitives), func(i int) bool { return r.infinitives[i].Infinitive >= prefix })
+
+	var results []Infinitive
+	for _, inf := range r.infinitives[start:] {
+		if !strings.HasPrefix(inf.Infinitive, prefix) || len(results) == limit {
+			break
+		}
+		results = append(results, inf)
+	}
+	return results, nil
+}
+
+func (r *MemoryRepository) FindForm(ctx context.Context, form string) ([]FormMatch, error) {
+	matches, ok := r.forms[form]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]FormMatch(nil), matches...), nil
+}
+
+func (r *MemoryRepository) GetGerund(ctx context.Context, infinitive string) (Gerund, error) {
+	gerund, ok := r.gerunds[infinitive]
+	if !ok {
+		return Gerund{}, ErrNotFound
+	}
+	return gerund, nil
+}
+
+func (r *MemoryRepository) GetPastparticiple(ctx context.Context, infinitive string) (Pastparticiple, error) {
+	participle, ok := r.pastparticiples[infinitive]
+	if !ok {
+		return Pastparticiple{}, ErrNotFound
+	}
+	return participle, nil
+}
+
+func (r *MemoryRepository) GetExamples(ctx context.Context, infinitive, mood, tense string, limit int) ([]Example, error) {
+	examples := r.examples[verbKey{infinitive, mood, tense}]
+	if len(examples) > limit {
+		examples = examples[:limit]
+	}
+	return append([]Example(nil), examples...), nil
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// migrationFilePattern matches migration file names such as 0001_init.sql.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version integer NOT NULL PRIMARY KEY,
+    name character varying NOT NULL,
+    applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+const (
+	errMigrationsRead      = "error reading migrations"
+	errMigrationName       = "migration file %q does not match NNNN_name.sql"
+	errMigrationDuplicate  = "duplicate migration version %d (%s and %s)"
+	errMigrationsTable     = "error creating schema_migrations table"
+	errMigrationsVersion   = "error reading schema version"
+	errMigrationsNewer     = "database schema version %d is newer than the latest known migration %d"
+	errMigrationApply      = "error applying migration %d_%s: %w"
+	errMigrationRecord     = "error recording migration %d_%s: %w"
+	errMigrationCommit     = "error committing migration %d_%s: %w"
+	errMigrationBeginTx    = "error starting migration %d_%s: %w"
+	errMigrationsNotSorted = "migrations are not sorted by version"
+)
+
+// Migration is one versioned SQL script.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// LoadMigrations reads the NNNN_name.sql files in dir, sorted by version.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMigrationsRead, err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf(errMigrationName, entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf(errMigrationName, entry.Name())
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf(errMigrationDuplicate, version, other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		contents, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errMigrationsRead, err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: match[2], SQL: string(contents)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// SchemaVersion returns the highest migration version applied to the database, or 0 if none has been.
+func SchemaVersion(ctx context.Context, sqlDB *sql.DB) (int, error) {
+	if _, err := sqlDB.ExecContext(ctx, createSchemaMigrations); err != nil {
+		return 0, fmt.Errorf("%s: %w", errMigrationsTable, err)
+	}
+
+	var version int
+	if err := sqlDB.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("%s: %w", errMigrationsVersion, err)
+	}
+	return version, nil
+}
+
+// Migrate applies every migration newer than the database's schema version, each in its own transaction, and returns
+// how many were applied. It refuses to run against a database migrated by a newer binary.
+func Migrate(ctx context.Context, sqlDB *sql.DB, migrations []Migration) (int, error) {
+	if !sort.SliceIsSorted(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version }) {
+		return 0, fmt.Errorf(errMigrationsNotSorted)
+	}
+
+	current, err := SchemaVersion(ctx, sqlDB)
+	if err != nil {
+		return 0, err
+	}
+	if latest := latestVersion(migrations); current > latest {
+		return 0, fmt.Errorf(errMigrationsNewer, current, latest)
+	}
+
+	applied := 0
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := applyMigration(ctx, sqlDB, m); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+func applyMigration(ctx context.Context, sqlDB *sql.DB, m Migration) error {
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf(errMigrationBeginTx, m.Version, m.Name, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf(errMigrationApply, m.Version, m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name); err != nil {
+		return fmt.Errorf(errMigrationRecord, m.Version, m.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(errMigrationCommit, m.Version, m.Name, err)
+	}
+	return nil
+}
+
+func latestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// ReadOnlyDSN returns a data source name that opens the SQLite file at path read-only and immutable, so SQLite skips
+// locking and change detection for reference data that never changes while the bot runs.
+func ReadOnlyDSN(path string) string {
+	return "file:" + path + "?mode=ro&immutable=1"
+}
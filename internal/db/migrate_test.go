@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_second.sql": {Data: []byte("CREATE TABLE b (id integer);")},
+		"migrations/0001_first.sql":  {Data: []byte("CREATE TABLE a (id integer);")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "migrations")
+	if err != nil {
+		t.Fatalf("LoadMigrations() returned an error: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[0].Name != "first" || migrations[1].Version != 2 {
+		t.Errorf("Unexpected migrations %+v", migrations)
+	}
+}
+
+func TestLoadMigrations_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"bad name", fstest.MapFS{"m/first.sql": {}}, "does not match"},
+		{"duplicate", fstest.MapFS{"m/0001_a.sql": {}, "m/001_b.sql": {}}, "duplicate migration version 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.fsys, "m")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func openTempDB(t *testing.T) *sql.DB {
+	t.Helper()
+	sqlDB, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := openTempDB(t)
+
+	migrations := []Migration{
+		{Version: 1, Name: "first", SQL: "CREATE TABLE a (id integer);"},
+		{Version: 2, Name: "second", SQL: "CREATE TABLE b (id integer); INSERT INTO b VALUES (1);"},
+	}
+
+	applied, err := Migrate(ctx, sqlDB, migrations[:1])
+	if err != nil || applied != 1 {
+		t.Fatalf("Migrate() = %d, %v; want 1, nil", applied, err)
+	}
+	applied, err = Migrate(ctx, sqlDB, migrations)
+	if err != nil || applied != 1 {
+		t.Fatalf("Migrate() = %d, %v; want 1, nil", applied, err)
+	}
+	applied, err = Migrate(ctx, sqlDB, migrations)
+	if err != nil || applied != 0 {
+		t.Fatalf("Migrate() = %d, %v; want 0, nil", applied, err)
+	}
+
+	if version, _ := SchemaVersion(ctx, sqlDB); version != 2 {
+		t.Errorf("Expected schema version 2, got %d", version)
+	}
+
+	// A binary that only knows the first migration must not run against the newer schema.
+	if _, err := Migrate(ctx, sqlDB, migrations[:1]); err == nil {
+		t.Error("Expected an error migrating a newer database")
+	}
+}
+
+func TestMigrate_FailureRollsBack(t *testing.T) {
+	ctx := context.Background()
+	sqlDB := openTempDB(t)
+
+	migrations := []Migration{
+		{Version: 1, Name: "broken", SQL: "CREATE TABLE a (id integer); INSERT INTO missing VALUES (1);"},
+	}
+	if _, err := Migrate(ctx, sqlDB, migrations); err == nil {
+		t.Fatal("Expected Migrate() to fail")
+	}
+
+	if version, _ := SchemaVersion(ctx, sqlDB); version != 0 {
+		t.Errorf("Expected schema version 0 after a failed migration, got %d", version)
+	}
+	var count int
+	sqlDB.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = 'a'").Scan(&count)
+	if count != 0 {
+		t.Error("Expected the failed migration to be rolled back")
+	}
+}
+
+func TestReadOnlyDSN(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ro.db")
+	rw, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := rw.Exec("CREATE TABLE a (id integer)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	rw.Close()
+
+	ro, err := sql.Open("sqlite3", ReadOnlyDSN(path))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer ro.Close()
+
+	var count int
+	if err := ro.QueryRow("SELECT count(*) FROM a").Scan(&count); err != nil {
+		t.Errorf("Expected reads to succeed: %v", err)
+	}
+	if _, err := ro.Exec("INSERT INTO a VALUES (1)"); err == nil {
+		t.Error("Expected writes to fail on a read-only connection")
+	}
+}
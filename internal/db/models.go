@@ -8,6 +8,16 @@ import (
 	"database/sql"
 )
 
+type Example struct {
+	ID              int64
+	Infinitive      string
+	Mood            string
+	Tense           string
+	Person          string
+	Sentence        string
+	SentenceEnglish sql.NullString
+}
+
 type Gerund struct {
 	Infinitive    string
 	Gerund        string
@@ -12,3 +12,165 @@ SELECT
     form_3p
 FROM verbs
 WHERE infinitive = ? AND mood = ? AND tense = ?;
+
+-- name: GetVerbsByInfinitive :many
+SELECT
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+FROM verbs
+WHERE infinitive = ?
+ORDER BY mood, tense;
+
+-- name: GetExamplesByInfinitiveMoodTense :many
+SELECT
+    id,
+    infinitive,
+    mood,
+    tense,
+    person,
+    sentence,
+    sentence_english
+FROM examples
+WHERE infinitive = ? AND mood = ? AND tense = ?
+ORDER BY id
+LIMIT ?;
+
+-- name: InsertExample :exec
+INSERT OR IGNORE INTO examples (
+    infinitive,
+    mood,
+    tense,
+    person,
+    sentence,
+    sentence_english
+) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListInfinitives :many
+SELECT infinitive, infinitive_english FROM infinitive ORDER BY infinitive;
+
+-- name: ListMoods :many
+SELECT mood, mood_english FROM mood ORDER BY mood;
+
+-- name: ListTenses :many
+SELECT tense, tense_english FROM tense ORDER BY tense;
+
+-- name: ListGerunds :many
+SELECT infinitive, gerund, gerund_english FROM gerund ORDER BY infinitive;
+
+-- name: ListPastparticiples :many
+SELECT infinitive, pastparticiple, pastparticiple_english FROM pastparticiple ORDER BY infinitive;
+
+-- name: ListVerbs :many
+SELECT
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+FROM verbs
+ORDER BY infinitive, mood, tense;
+
+-- name: ListExamples :many
+SELECT
+    id,
+    infinitive,
+    mood,
+    tense,
+    person,
+    sentence,
+    sentence_english
+FROM examples
+ORDER BY id;
+
+-- name: UpsertInfinitive :exec
+INSERT INTO infinitive (infinitive, infinitive_english)
+VALUES (?, ?)
+ON CONFLICT (infinitive) DO UPDATE SET infinitive_english = excluded.infinitive_english;
+
+-- name: UpsertMood :exec
+INSERT INTO mood (mood, mood_english)
+VALUES (?, ?)
+ON CONFLICT (mood) DO UPDATE SET mood_english = excluded.mood_english;
+
+-- name: UpsertTense :exec
+INSERT INTO tense (tense, tense_english)
+VALUES (?, ?)
+ON CONFLICT (tense) DO UPDATE SET tense_english = excluded.tense_english;
+
+-- name: UpsertGerund :exec
+INSERT INTO gerund (infinitive, gerund, gerund_english)
+VALUES (?, ?, ?)
+ON CONFLICT (infinitive) DO UPDATE SET
+    gerund = excluded.gerund,
+    gerund_english = excluded.gerund_english;
+
+-- name: UpsertPastparticiple :exec
+INSERT INTO pastparticiple (infinitive, pastparticiple, pastparticiple_english)
+VALUES (?, ?, ?)
+ON CONFLICT (infinitive) DO UPDATE SET
+    pastparticiple = excluded.pastparticiple,
+    pastparticiple_english = excluded.pastparticiple_english;
+
+-- name: UpsertVerb :exec
+INSERT INTO verbs (
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (infinitive, mood, tense) DO UPDATE SET
+    verb_english = excluded.verb_english,
+    form_1s = excluded.form_1s,
+    form_2s = excluded.form_2s,
+    form_3s = excluded.form_3s,
+    form_1p = excluded.form_1p,
+    form_2p = excluded.form_2p,
+    form_3p = excluded.form_3p;
+
+-- name: SearchInfinitives :many
+SELECT infinitive, infinitive_english
+FROM infinitive
+WHERE infinitive LIKE ?
+ORDER BY infinitive
+LIMIT ?;
+
+-- name: GetGerund :one
+SELECT infinitive, gerund, gerund_english FROM gerund WHERE infinitive = ?;
+
+-- name: GetPastparticiple :one
+SELECT infinitive, pastparticiple, pastparticiple_english FROM pastparticiple WHERE infinitive = ?;
+
+-- name: FindVerbsByForm :many
+SELECT
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+FROM verbs
+WHERE form_1s = ?1 OR form_2s = ?1 OR form_3s = ?1 OR form_1p = ?1 OR form_2p = ?1 OR form_3p = ?1
+ORDER BY infinitive, mood, tense;
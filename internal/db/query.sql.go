@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const getVerbByInfinitiveMoodTense = `-- name: GetVerbByInfinitiveMoodTense :one
@@ -48,3 +49,648 @@ func (q *Queries) GetVerbByInfinitiveMoodTense(ctx context.Context, arg GetVerbB
 	)
 	return i, err
 }
+
+const getVerbsByInfinitive = `-- name: GetVerbsByInfinitive :many
+SELECT
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+FROM verbs
+WHERE infinitive = ?
+ORDER BY mood, tense
+`
+
+func (q *Queries) GetVerbsByInfinitive(ctx context.Context, infinitive string) ([]Verb, error) {
+	rows, err := q.db.QueryContext(ctx, getVerbsByInfinitive, infinitive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Verb
+	for rows.Next() {
+		var i Verb
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.Mood,
+			&i.Tense,
+			&i.VerbEnglish,
+			&i.Form1s,
+			&i.Form2s,
+			&i.Form3s,
+			&i.Form1p,
+			&i.Form2p,
+			&i.Form3p,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExamplesByInfinitiveMoodTense = `-- name: GetExamplesByInfinitiveMoodTense :many
+SELECT
+    id,
+    infinitive,
+    mood,
+    tense,
+    person,
+    sentence,
+    sentence_english
+FROM examples
+WHERE infinitive = ? AND mood = ? AND tense = ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetExamplesByInfinitiveMoodTenseParams struct {
+	Infinitive string
+	Mood       string
+	Tense      string
+	Limit      int64
+}
+
+func (q *Queries) GetExamplesByInfinitiveMoodTense(ctx context.Context, arg GetExamplesByInfinitiveMoodTenseParams) ([]Example, error) {
+	rows, err := q.db.QueryContext(ctx, getExamplesByInfinitiveMoodTense,
+		arg.Infinitive,
+		arg.Mood,
+		arg.Tense,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Example
+	for rows.Next() {
+		var i Example
+		if err := rows.Scan(
+			&i.ID,
+			&i.Infinitive,
+			&i.Mood,
+			&i.Tense,
+			&i.Person,
+			&i.Sentence,
+			&i.SentenceEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertExample = `-- name: InsertExample :exec
+INSERT OR IGNORE INTO examples (
+    infinitive,
+    mood,
+    tense,
+    person,
+    sentence,
+    sentence_english
+) VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type InsertExampleParams struct {
+	Infinitive      string
+	Mood            string
+	Tense           string
+	Person          string
+	Sentence        string
+	SentenceEnglish sql.NullString
+}
+
+func (q *Queries) InsertExample(ctx context.Context, arg InsertExampleParams) error {
+	_, err := q.db.ExecContext(ctx, insertExample,
+		arg.Infinitive,
+		arg.Mood,
+		arg.Tense,
+		arg.Person,
+		arg.Sentence,
+		arg.SentenceEnglish,
+	)
+	return err
+}
+
+const listInfinitives = `-- name: ListInfinitives :many
+SELECT infinitive, infinitive_english FROM infinitive ORDER BY infinitive
+`
+
+func (q *Queries) ListInfinitives(ctx context.Context) ([]Infinitive, error) {
+	rows, err := q.db.QueryContext(ctx, listInfinitives)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Infinitive
+	for rows.Next() {
+		var i Infinitive
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.InfinitiveEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMoods = `-- name: ListMoods :many
+SELECT mood, mood_english FROM mood ORDER BY mood
+`
+
+func (q *Queries) ListMoods(ctx context.Context) ([]Mood, error) {
+	rows, err := q.db.QueryContext(ctx, listMoods)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mood
+	for rows.Next() {
+		var i Mood
+		if err := rows.Scan(
+			&i.Mood,
+			&i.MoodEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenses = `-- name: ListTenses :many
+SELECT tense, tense_english FROM tense ORDER BY tense
+`
+
+func (q *Queries) ListTenses(ctx context.Context) ([]Tense, error) {
+	rows, err := q.db.QueryContext(ctx, listTenses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tense
+	for rows.Next() {
+		var i Tense
+		if err := rows.Scan(
+			&i.Tense,
+			&i.TenseEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGerunds = `-- name: ListGerunds :many
+SELECT infinitive, gerund, gerund_english FROM gerund ORDER BY infinitive
+`
+
+func (q *Queries) ListGerunds(ctx context.Context) ([]Gerund, error) {
+	rows, err := q.db.QueryContext(ctx, listGerunds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Gerund
+	for rows.Next() {
+		var i Gerund
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.Gerund,
+			&i.GerundEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPastparticiples = `-- name: ListPastparticiples :many
+SELECT infinitive, pastparticiple, pastparticiple_english FROM pastparticiple ORDER BY infinitive
+`
+
+func (q *Queries) ListPastparticiples(ctx context.Context) ([]Pastparticiple, error) {
+	rows, err := q.db.QueryContext(ctx, listPastparticiples)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Pastparticiple
+	for rows.Next() {
+		var i Pastparticiple
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.Pastparticiple,
+			&i.PastparticipleEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVerbs = `-- name: ListVerbs :many
+SELECT
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+FROM verbs
+ORDER BY infinitive, mood, tense
+`
+
+func (q *Queries) ListVerbs(ctx context.Context) ([]Verb, error) {
+	rows, err := q.db.QueryContext(ctx, listVerbs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Verb
+	for rows.Next() {
+		var i Verb
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.Mood,
+			&i.Tense,
+			&i.VerbEnglish,
+			&i.Form1s,
+			&i.Form2s,
+			&i.Form3s,
+			&i.Form1p,
+			&i.Form2p,
+			&i.Form3p,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExamples = `-- name: ListExamples :many
+SELECT
+    id,
+    infinitive,
+    mood,
+    tense,
+    person,
+    sentence,
+    sentence_english
+FROM examples
+ORDER BY id
+`
+
+func (q *Queries) ListExamples(ctx context.Context) ([]Example, error) {
+	rows, err := q.db.QueryContext(ctx, listExamples)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Example
+	for rows.Next() {
+		var i Example
+		if err := rows.Scan(
+			&i.ID,
+			&i.Infinitive,
+			&i.Mood,
+			&i.Tense,
+			&i.Person,
+			&i.Sentence,
+			&i.SentenceEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertInfinitive = `-- name: UpsertInfinitive :exec
+INSERT INTO infinitive (infinitive, infinitive_english)
+VALUES (?, ?)
+ON CONFLICT (infinitive) DO UPDATE SET infinitive_english = excluded.infinitive_english
+`
+
+type UpsertInfinitiveParams struct {
+	Infinitive        string
+	InfinitiveEnglish sql.NullString
+}
+
+func (q *Queries) UpsertInfinitive(ctx context.Context, arg UpsertInfinitiveParams) error {
+	_, err := q.db.ExecContext(ctx, upsertInfinitive, arg.Infinitive, arg.InfinitiveEnglish)
+	return err
+}
+
+const upsertMood = `-- name: UpsertMood :exec
+INSERT INTO mood (mood, mood_english)
+VALUES (?, ?)
+ON CONFLICT (mood) DO UPDATE SET mood_english = excluded.mood_english
+`
+
+type UpsertMoodParams struct {
+	Mood        string
+	MoodEnglish sql.NullString
+}
+
+func (q *Queries) UpsertMood(ctx context.Context, arg UpsertMoodParams) error {
+	_, err := q.db.ExecContext(ctx, upsertMood, arg.Mood, arg.MoodEnglish)
+	return err
+}
+
+const upsertTense = `-- name: UpsertTense :exec
+INSERT INTO tense (tense, tense_english)
+VALUES (?, ?)
+ON CONFLICT (tense) DO UPDATE SET tense_english = excluded.tense_english
+`
+
+type UpsertTenseParams struct {
+	Tense        string
+	TenseEnglish sql.NullString
+}
+
+func (q *Queries) UpsertTense(ctx context.Context, arg UpsertTenseParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTense, arg.Tense, arg.TenseEnglish)
+	return err
+}
+
+const upsertGerund = `-- name: UpsertGerund :exec
+INSERT INTO gerund (infinitive, gerund, gerund_english)
+VALUES (?, ?, ?)
+ON CONFLICT (infinitive) DO UPDATE SET
+    gerund = excluded.gerund,
+    gerund_english = excluded.gerund_english
+`
+
+type UpsertGerundParams struct {
+	Infinitive    string
+	Gerund        string
+	GerundEnglish sql.NullString
+}
+
+func (q *Queries) UpsertGerund(ctx context.Context, arg UpsertGerundParams) error {
+	_, err := q.db.ExecContext(ctx, upsertGerund, arg.Infinitive, arg.Gerund, arg.GerundEnglish)
+	return err
+}
+
+const upsertPastparticiple = `-- name: UpsertPastparticiple :exec
+INSERT INTO pastparticiple (infinitive, pastparticiple, pastparticiple_english)
+VALUES (?, ?, ?)
+ON CONFLICT (infinitive) DO UPDATE SET
+    pastparticiple = excluded.pastparticiple,
+    pastparticiple_english = excluded.pastparticiple_english
+`
+
+type UpsertPastparticipleParams struct {
+	Infinitive            string
+	Pastparticiple        string
+	PastparticipleEnglish sql.NullString
+}
+
+func (q *Queries) UpsertPastparticiple(ctx context.Context, arg UpsertPastparticipleParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPastparticiple, arg.Infinitive, arg.Pastparticiple, arg.PastparticipleEnglish)
+	return err
+}
+
+const upsertVerb = `-- name: UpsertVerb :exec
+INSERT INTO verbs (
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (infinitive, mood, tense) DO UPDATE SET
+    verb_english = excluded.verb_english,
+    form_1s = excluded.form_1s,
+    form_2s = excluded.form_2s,
+    form_3s = excluded.form_3s,
+    form_1p = excluded.form_1p,
+    form_2p = excluded.form_2p,
+    form_3p = excluded.form_3p
+`
+
+type UpsertVerbParams struct {
+	Infinitive  string
+	Mood        string
+	Tense       string
+	VerbEnglish sql.NullString
+	Form1s      sql.NullString
+	Form2s      sql.NullString
+	Form3s      sql.NullString
+	Form1p      sql.NullString
+	Form2p      sql.NullString
+	Form3p      sql.NullString
+}
+
+func (q *Queries) UpsertVerb(ctx context.Context, arg UpsertVerbParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVerb,
+		arg.Infinitive,
+		arg.Mood,
+		arg.Tense,
+		arg.VerbEnglish,
+		arg.Form1s,
+		arg.Form2s,
+		arg.Form3s,
+		arg.Form1p,
+		arg.Form2p,
+		arg.Form3p,
+	)
+	return err
+}
+
+const searchInfinitives = `-- name: SearchInfinitives :many
+SELECT infinitive, infinitive_english
+FROM infinitive
+WHERE infinitive LIKE ?
+ORDER BY infinitive
+LIMIT ?
+`
+
+type SearchInfinitivesParams struct {
+	Infinitive string
+	Limit      int64
+}
+
+func (q *Queries) SearchInfinitives(ctx context.Context, arg SearchInfinitivesParams) ([]Infinitive, error) {
+	rows, err := q.db.QueryContext(ctx, searchInfinitives, arg.Infinitive, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Infinitive
+	for rows.Next() {
+		var i Infinitive
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.InfinitiveEnglish,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGerund = `-- name: GetGerund :one
+SELECT infinitive, gerund, gerund_english FROM gerund WHERE infinitive = ?
+`
+
+func (q *Queries) GetGerund(ctx context.Context, infinitive string) (Gerund, error) {
+	row := q.db.QueryRowContext(ctx, getGerund, infinitive)
+	var i Gerund
+	err := row.Scan(
+		&i.Infinitive,
+		&i.Gerund,
+		&i.GerundEnglish,
+	)
+	return i, err
+}
+
+const getPastparticiple = `-- name: GetPastparticiple :one
+SELECT infinitive, pastparticiple, pastparticiple_english FROM pastparticiple WHERE infinitive = ?
+`
+
+func (q *Queries) GetPastparticiple(ctx context.Context, infinitive string) (Pastparticiple, error) {
+	row := q.db.QueryRowContext(ctx, getPastparticiple, infinitive)
+	var i Pastparticiple
+	err := row.Scan(
+		&i.Infinitive,
+		&i.Pastparticiple,
+		&i.PastparticipleEnglish,
+	)
+	return i, err
+}
+
+const findVerbsByForm = `-- name: FindVerbsByForm :many
+SELECT
+    infinitive,
+    mood,
+    tense,
+    verb_english,
+    form_1s,
+    form_2s,
+    form_3s,
+    form_1p,
+    form_2p,
+    form_3p
+FROM verbs
+WHERE form_1s = ?1 OR form_2s = ?1 OR form_3s = ?1 OR form_1p = ?1 OR form_2p = ?1 OR form_3p = ?1
+ORDER BY infinitive, mood, tense
+`
+
+func (q *Queries) FindVerbsByForm(ctx context.Context, form sql.NullString) ([]Verb, error) {
+	rows, err := q.db.QueryContext(ctx, findVerbsByForm, form)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Verb
+	for rows.Next() {
+		var i Verb
+		if err := rows.Scan(
+			&i.Infinitive,
+			&i.Mood,
+			&i.Tense,
+			&i.VerbEnglish,
+			&i.Form1s,
+			&i.Form2s,
+			&i.Form3s,
+			&i.Form1p,
+			&i.Form2p,
+			&i.Form3p,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// ErrNotFound is returned by a VerbRepository when the requested verb, form or table does not exist.
+var ErrNotFound = errors.New("not found")
+
+// FormMatch is one place a conjugated form appears: the verb row it belongs to and the person column it is stored in.
+type FormMatch struct {
+	Verb   Verb
+	Person string
+}
+
+// VerbRepository provides read access to the verb reference data.
+type VerbRepository interface {
+	// GetVerb returns the conjugation of an infinitive in one mood and tense.
+	GetVerb(ctx context.Context, infinitive, mood, tense string) (Verb, error)
+	// GetVerbs returns every mood and tense of an infinitive, ordered by mood and tense.
+	GetVerbs(ctx context.Context, infinitive string) ([]Verb, error)
+	// SearchInfinitives returns up to limit infinitives starting with prefix.
+	SearchInfinitives(ctx context.Context, prefix string, limit int) ([]Infinitive, error)
+	// FindForm returns every verb row and person a conjugated form appears in.
+	FindForm(ctx context.Context, form string) ([]FormMatch, error)
+	// GetGerund returns the gerund of an infinitive.
+	GetGerund(ctx context.Context, infinitive string) (Gerund, error)
+	// GetPastparticiple returns the past participle of an infinitive.
+	GetPastparticiple(ctx context.Context, infinitive string) (Pastparticiple, error)
+	// GetExamples returns up to limit example sentences for an infinitive in one mood and tense.
+	GetExamples(ctx context.Context, infinitive, mood, tense string, limit int) ([]Example, error)
+}
+
+// SQLiteRepository is a VerbRepository that queries the database on every call.
+type SQLiteRepository struct {
+	q *Queries
+}
+
+// NewSQLiteRepository creates a VerbRepository backed by the given connection.
+func NewSQLiteRepository(db DBTX) *SQLiteRepository {
+	return &SQLiteRepository{q: New(db)}
+}
+
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (r *SQLiteRepository) GetVerb(ctx context.Context, infinitive, mood, tense string) (Verb, error) {
+	verb, err := r.q.GetVerbByInfinitiveMoodTense(ctx, GetVerbByInfinitiveMoodTenseParams{Infinitive: infinitive, Mood: mood, Tense: tense})
+	return verb, notFound(err)
+}
+
+func (r *SQLiteRepository) GetVerbs(ctx context.Context, infinitive string) ([]Verb, error) {
+	verbs, err := r.q.GetVerbsByInfinitive(ctx, infinitive)
+	if err != nil {
+		return nil, err
+	}
+	if len(verbs) == 0 {
+		return nil, ErrNotFound
+	}
+	return verbs, nil
+}
+
+func (r *SQLiteRepository) SearchInfinitives(ctx context.Context, prefix string, limit int) ([]Infinitive, error) {
+	return r.q.SearchInfinitives(ctx, SearchInfinitivesParams{Infinitive: stripLikeWildcards(prefix) + "%", Limit: int64(limit)})
+}
+
+func (r *SQLiteRepository) FindForm(ctx context.Context, form string) ([]FormMatch, error) {
+	verbs, err := r.q.FindVerbsByForm(ctx, sql.NullString{String: form, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []FormMatch
+	for _, v := range verbs {
+		matches = append(matches, matchPersons(v, form)...)
+	}
+	if len(matches) == 0 {
+		return nil, ErrNotFound
+	}
+	return matches, nil
+}
+
+func (r *SQLiteRepository) GetGerund(ctx context.Context, infinitive string) (Gerund, error) {
+	gerund, err := r.q.GetGerund(ctx, infinitive)
+	return gerund, notFound(err)
+}
+
+func (r *SQLiteRepository) GetPastparticiple(ctx context.Context, infinitive string) (Pastparticiple, error) {
+	participle, err := r.q.GetPastparticiple(ctx, infinitive)
+	return participle, notFound(err)
+}
+
+func (r *SQLiteRepository) GetExamples(ctx context.Context, infinitive, mood, tense string, limit int) ([]Example, error) {
+	return r.q.GetExamplesByInfinitiveMoodTense(ctx, GetExamplesByInfinitiveMoodTenseParams{
+		Infinitive: infinitive,
+		Mood:       mood,
+		Tense:      tense,
+		Limit:      int64(limit),
+	})
+}
+
+// matchPersons returns a FormMatch for every person column of v that holds form.
+func matchPersons(v Verb, form string) []FormMatch {
+	var matches []FormMatch
+	for _, person := range Persons {
+		if f, _ := v.Form(person); f == form {
+			matches = append(matches, FormMatch{Verb: v, Person: person})
+		}
+	}
+	return matches
+}
+
+// stripLikeWildcards removes the LIKE wildcards % and _ from s. Neither appears in infinitives, so user input cannot
+// use them to widen a prefix search.
+func stripLikeWildcards(s string) string {
+	return strings.NewReplacer("%", "", "_", "").Replace(s)
+}
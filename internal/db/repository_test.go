@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newTestRepositories returns every VerbRepository implementation over the same test data.
+func newTestRepositories(t *testing.T) map[string]VerbRepository {
+	t.Helper()
+
+	sqlDB := newTestDB(t)
+	_, err := sqlDB.Exec(`
+		INSERT INTO infinitive VALUES ('hablar', 'to speak'), ('hacer', 'to do, make'), ('comer', 'to eat');
+		INSERT INTO gerund VALUES ('hablar', 'hablando', 'speaking');
+		INSERT INTO pastparticiple VALUES ('hablar', 'hablado', 'spoken');
+		INSERT INTO examples (infinitive, mood, tense, person, sentence) VALUES
+			('hablar', 'Indicativo', 'Presente', '1s', 'Hablo.'),
+			('hablar', 'Indicativo', 'Presente', '3s', 'Ella habla.'),
+			('hablar', 'Indicativo', 'Presente', '1p', 'Hablamos.')`)
+	if err != nil {
+		t.Fatalf("Failed to insert rows: %v", err)
+	}
+
+	memory, err := LoadMemoryRepository(context.Background(), New(sqlDB))
+	if err != nil {
+		t.Fatalf("LoadMemoryRepository() returned an error: %v", err)
+	}
+
+	return map[string]VerbRepository{
+		"sqlite": NewSQLiteRepository(sqlDB),
+		"memory": memory,
+		"cached": NewCachedRepository(NewSQLiteRepository(sqlDB), 16),
+	}
+}
+
+func TestVerbRepository(t *testing.T) {
+	ctx := context.Background()
+
+	for name, repo := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			verb, err := repo.GetVerb(ctx, "hablar", "Indicativo", "Presente")
+			if err != nil || NullStringToString(verb.Form1s) != "hablo" {
+				t.Errorf("GetVerb() = %+v, %v", verb, err)
+			}
+			if _, err := repo.GetVerb(ctx, "hablar", "Subjuntivo", "Presente"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("GetVerb() error = %v, want ErrNotFound", err)
+			}
+
+			verbs, err := repo.GetVerbs(ctx, "hablar")
+			if err != nil || len(verbs) != 3 {
+				t.Fatalf("GetVerbs() = %d verbs, %v; want 3", len(verbs), err)
+			}
+			if verbs[0].Mood != "Imperativo Afirmativo" || verbs[2].Tense != "Presente perfecto" {
+				t.Errorf("GetVerbs() returned rows out of order: %+v", verbs)
+			}
+			if _, err := repo.GetVerbs(ctx, "vivir"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("GetVerbs() error = %v, want ErrNotFound", err)
+			}
+
+			infinitives, err := repo.SearchInfinitives(ctx, "ha", 1)
+			if err != nil || len(infinitives) != 1 || infinitives[0].Infinitive != "hablar" {
+				t.Errorf("SearchInfinitives() = %+v, %v", infinitives, err)
+			}
+			infinitives, err = repo.SearchInfinitives(ctx, "%", 10)
+			if err != nil || len(infinitives) != 3 {
+				t.Errorf("SearchInfinitives() with a wildcard = %+v, %v; want all 3 infinitives", infinitives, err)
+			}
+
+			matches, err := repo.FindForm(ctx, "habla")
+			if err != nil || len(matches) != 2 {
+				t.Fatalf("FindForm() = %+v, %v; want 2 matches", matches, err)
+			}
+			if matches[0].Verb.Mood != "Imperativo Afirmativo" || matches[0].Person != Person2s ||
+				matches[1].Verb.Mood != "Indicativo" || matches[1].Person != Person3s {
+				t.Errorf("FindForm() = %+v", matches)
+			}
+			if _, err := repo.FindForm(ctx, "comí"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("FindForm() error = %v, want ErrNotFound", err)
+			}
+
+			gerund, err := repo.GetGerund(ctx, "hablar")
+			if err != nil || gerund.Gerund != "hablando" {
+				t.Errorf("GetGerund() = %+v, %v", gerund, err)
+			}
+			if _, err := repo.GetGerund(ctx, "comer"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("GetGerund() error = %v, want ErrNotFound", err)
+			}
+
+			participle, err := repo.GetPastparticiple(ctx, "hablar")
+			if err != nil || participle.Pastparticiple != "hablado" {
+				t.Errorf("GetPastparticiple() = %+v, %v", participle, err)
+			}
+			if _, err := repo.GetPastparticiple(ctx, "comer"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("GetPastparticiple() error = %v, want ErrNotFound", err)
+			}
+
+			examples, err := repo.GetExamples(ctx, "hablar", "Indicativo", "Presente", 2)
+			if err != nil || len(examples) != 2 || examples[0].Sentence != "Hablo." {
+				t.Errorf("GetExamples() = %+v, %v", examples, err)
+			}
+		})
+	}
+}
@@ -33,3 +33,13 @@ CREATE TABLE verbs (
     form_3p character varying,
     PRIMARY KEY (infinitive, mood, tense)
 );
+CREATE TABLE examples (
+    id integer NOT NULL PRIMARY KEY,
+    infinitive character varying NOT NULL,
+    mood character varying NOT NULL,
+    tense character varying NOT NULL,
+    person character varying NOT NULL,
+    sentence character varying NOT NULL,
+    sentence_english character varying,
+    UNIQUE (infinitive, mood, tense, person, sentence)
+);
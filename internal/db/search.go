@@ -0,0 +1,248 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+var (
+	// ErrSearchUnavailable is returned by CheckSearchIndex when the database has no search index or SQLite was built
+	// without FTS5. Build with `-tags sqlite_fts5` and run `verbsctl search-index` to enable search.
+	ErrSearchUnavailable = errors.New("full-text search is unavailable")
+	// ErrEmptySearch is returned by Search when the query contains no words.
+	ErrEmptySearch = errors.New("search query has no words")
+)
+
+const (
+	// SearchHighlightStart and SearchHighlightEnd surround the matched words in a SearchResult snippet, rendering them
+	// bold in Markdown.
+	SearchHighlightStart = "**"
+	SearchHighlightEnd   = "**"
+
+	searchSnippetWords = 8
+
+	// The search index has one row per infinitive. Matches in the infinitive rank above matches in its English
+	// glosses, which rank above matches in one of its conjugated forms.
+	createSearchIndex = `CREATE VIRTUAL TABLE verbs_fts USING fts5(
+    infinitive,
+    english,
+    forms,
+    tokenize = 'unicode61 remove_diacritics 2'
+)`
+	dropSearchIndex   = `DROP TABLE IF EXISTS verbs_fts`
+	insertSearchIndex = `INSERT INTO verbs_fts (infinitive, english, forms) VALUES (?, ?, ?)`
+	searchIndexExists = `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'verbs_fts'`
+	probeSearchIndex  = `SELECT count(*) FROM verbs_fts WHERE rowid = 0`
+	countSearch       = `SELECT count(*) FROM verbs_fts WHERE verbs_fts MATCH ?`
+	search            = `SELECT verbs_fts.infinitive, infinitive.infinitive_english,
+    snippet(verbs_fts, -1, ?, ?, '…', ?),
+    bm25(verbs_fts, 10.0, 4.0, 1.0) AS rank
+FROM verbs_fts
+JOIN infinitive ON infinitive.infinitive = verbs_fts.infinitive
+WHERE verbs_fts MATCH ?
+ORDER BY rank, verbs_fts.infinitive
+LIMIT ? OFFSET ?`
+)
+
+// SearchResult is one infinitive matching a search.
+type SearchResult struct {
+	Infinitive        string
+	InfinitiveEnglish sql.NullString
+	// Snippet is the part of the best matching column with the matched words highlighted.
+	Snippet string
+	// Rank is the bm25 score of the match; lower is better.
+	Rank float64
+}
+
+// SearchPage is one page of search results along with the total number of matches.
+type SearchPage struct {
+	Results []SearchResult
+	Total   int
+}
+
+// Searcher runs full-text searches over the verb data.
+type Searcher interface {
+	// Search returns up to limit results for query, skipping the first offset.
+	Search(ctx context.Context, query string, limit, offset int) (SearchPage, error)
+}
+
+// FTSSearcher is a Searcher backed by the verbs_fts index in verbs.db.
+type FTSSearcher struct {
+	db DBTX
+}
+
+// NewFTSSearcher creates a Searcher that queries the search index through the given connection. Call
+// CheckSearchIndex first to make sure the index can be queried.
+func NewFTSSearcher(db DBTX) *FTSSearcher {
+	return &FTSSearcher{db: db}
+}
+
+func (s *FTSSearcher) Search(ctx context.Context, query string, limit, offset int) (SearchPage, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return SearchPage{}, ErrEmptySearch
+	}
+
+	var page SearchPage
+	if err := s.db.QueryRowContext(ctx, countSearch, match).Scan(&page.Total); err != nil {
+		return SearchPage{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, search, SearchHighlightStart, SearchHighlightEnd, searchSnippetWords, match, limit, offset)
+	if err != nil {
+		return SearchPage{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r SearchResult
+		if err := rows.Scan(
+			&r.Infinitive,
+			&r.InfinitiveEnglish,
+			&r.Snippet,
+			&r.Rank,
+		); err != nil {
+			return SearchPage{}, err
+		}
+		page.Results = append(page.Results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return SearchPage{}, err
+	}
+	return page, nil
+}
+
+// matchQuery turns user input into an FTS5 query that matches rows containing every word of it as a prefix. Words are
+// quoted so characters with a meaning in the FTS5 query syntax are searched for literally instead of failing the query.
+func matchQuery(query string) string {
+	words := strings.FieldsFunc(spanish.Normalize(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + w + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// CheckSearchIndex returns ErrSearchUnavailable when the search index is missing or cannot be queried.
+func CheckSearchIndex(ctx context.Context, db DBTX) error {
+	var count int
+	if err := db.QueryRowContext(ctx, searchIndexExists).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: verbs_fts table is missing", ErrSearchUnavailable)
+	}
+	if err := db.QueryRowContext(ctx, probeSearchIndex).Scan(&count); err != nil {
+		return fmt.Errorf("%w: %v", ErrSearchUnavailable, err)
+	}
+	return nil
+}
+
+// BuildSearchIndex recreates the verbs_fts index from the infinitive, verbs, gerund and pastparticiple tables and
+// returns the number of infinitives indexed. It runs as a build step on a writable verbs.db, so the bot never builds
+// the index at startup.
+func BuildSearchIndex(ctx context.Context, sqlDB *sql.DB) (int, error) {
+	q := New(sqlDB)
+	infinitives, err := q.ListInfinitives(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing infinitives: %w", err)
+	}
+	verbs, err := q.ListVerbs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing verbs: %w", err)
+	}
+	gerunds, err := q.ListGerunds(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing gerunds: %w", err)
+	}
+	pastparticiples, err := q.ListPastparticiples(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing past participles: %w", err)
+	}
+
+	english := make(map[string]*wordSet)
+	forms := make(map[string]*wordSet)
+	add := func(m map[string]*wordSet, infinitive, word string) {
+		if m[infinitive] == nil {
+			m[infinitive] = newWordSet()
+		}
+		m[infinitive].add(word)
+	}
+	for _, inf := range infinitives {
+		add(english, inf.Infinitive, NullStringToString(inf.InfinitiveEnglish))
+	}
+	for _, v := range verbs {
+		add(english, v.Infinitive, NullStringToString(v.VerbEnglish))
+		for _, person := range Persons {
+			form, _ := v.Form(person)
+			add(forms, v.Infinitive, form)
+		}
+	}
+	for _, g := range gerunds {
+		add(english, g.Infinitive, NullStringToString(g.GerundEnglish))
+		add(forms, g.Infinitive, g.Gerund)
+	}
+	for _, p := range pastparticiples {
+		add(english, p.Infinitive, NullStringToString(p.PastparticipleEnglish))
+		add(forms, p.Infinitive, p.Pastparticiple)
+	}
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, dropSearchIndex); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSearchUnavailable, err)
+	}
+	if _, err := tx.ExecContext(ctx, createSearchIndex); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrSearchUnavailable, err)
+	}
+	for _, inf := range infinitives {
+		if _, err := tx.ExecContext(ctx, insertSearchIndex, inf.Infinitive, english[inf.Infinitive].String(), forms[inf.Infinitive].String()); err != nil {
+			return 0, fmt.Errorf("indexing %s: %w", inf.Infinitive, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(infinitives), nil
+}
+
+// wordSet collects the distinct non-empty strings indexed for one column of one infinitive.
+type wordSet struct {
+	seen map[string]bool
+}
+
+func newWordSet() *wordSet {
+	return &wordSet{seen: make(map[string]bool)}
+}
+
+func (w *wordSet) add(s string) {
+	if s = strings.TrimSpace(s); s != "" {
+		w.seen[s] = true
+	}
+}
+
+// String joins the collected strings in sorted order so rebuilding the index produces identical rows. Entries are
+// separated by " | " so that a snippet shows where one ends.
+func (w *wordSet) String() string {
+	if w == nil {
+		return ""
+	}
+	words := make([]string, 0, len(w.seen))
+	for s := range w.seen {
+		words = append(words, s)
+	}
+	sort.Strings(words)
+	return strings.Join(words, " | ")
+}
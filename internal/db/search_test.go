@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// requireFTS5 skips the test unless SQLite was built with FTS5, which needs `-tags sqlite_fts5`.
+func requireFTS5(t *testing.T, sqlDB *sql.DB) {
+	t.Helper()
+
+	var enabled bool
+	if err := sqlDB.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		t.Fatalf("Failed to read compile options: %v", err)
+	}
+	if !enabled {
+		t.Skip("SQLite built without FTS5, run with -tags sqlite_fts5")
+	}
+}
+
+func newSearchTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	sqlDB := newTestDB(t)
+	requireFTS5(t, sqlDB)
+
+	_, err := sqlDB.Exec(`INSERT INTO infinitive VALUES ('hablar', 'to speak'), ('oír', 'to hear'), ('decir', 'to say');
+		INSERT INTO verbs VALUES
+			('oír', 'Indicativo', 'Presente', 'I hear', 'oigo', 'oyes', 'oye', 'oímos', 'oís', 'oyen'),
+			('decir', 'Indicativo', 'Presente', 'I say, I speak', 'digo', 'dices', 'dice', 'decimos', 'decís', 'dicen');
+		INSERT INTO gerund VALUES ('hablar', 'hablando', 'speaking');
+		INSERT INTO pastparticiple VALUES ('hablar', 'hablado', 'spoken')`)
+	if err != nil {
+		t.Fatalf("Failed to insert reference data: %v", err)
+	}
+
+	n, err := BuildSearchIndex(context.Background(), sqlDB)
+	if err != nil {
+		t.Fatalf("BuildSearchIndex() returned an error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("BuildSearchIndex() indexed %d infinitives, want 3", n)
+	}
+	return sqlDB
+}
+
+func TestSearch(t *testing.T) {
+	sqlDB := newSearchTestDB(t)
+	searcher := NewFTSSearcher(sqlDB)
+	ctx := context.Background()
+
+	if err := CheckSearchIndex(ctx, sqlDB); err != nil {
+		t.Fatalf("CheckSearchIndex() returned an error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"infinitive", "hablar", []string{"hablar"}},
+		{"conjugated form", "oigo", []string{"oír"}},
+		{"form without accent", "oimos", []string{"oír"}},
+		{"prefix", "habla", []string{"hablar"}},
+		{"gerund", "hablando", []string{"hablar"}},
+		{"english gloss", "speak", []string{"hablar", "decir"}},
+		{"every word must match", "to hear", []string{"oír"}},
+		{"query syntax is literal", `"oyen" (-)`, []string{"oír"}},
+		{"no match", "nadar", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := searcher.Search(ctx, tt.query, 10, 0)
+			if err != nil {
+				t.Fatalf("Search(%q) returned an error: %v", tt.query, err)
+			}
+			if page.Total != len(tt.want) {
+				t.Errorf("Total = %d, want %d", page.Total, len(tt.want))
+			}
+			var got []string
+			for _, r := range page.Results {
+				got = append(got, r.Infinitive)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	searcher := NewFTSSearcher(newSearchTestDB(t))
+
+	page, err := searcher.Search(context.Background(), "to", 2, 2)
+	if err != nil {
+		t.Fatalf("Search() returned an error: %v", err)
+	}
+	if page.Total != 3 {
+		t.Errorf("Total = %d, want 3", page.Total)
+	}
+	if len(page.Results) != 1 {
+		t.Errorf("Expected 1 result on the second page, got %d", len(page.Results))
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	searcher := NewFTSSearcher(newSearchTestDB(t))
+
+	page, err := searcher.Search(context.Background(), "oyes", 1, 0)
+	if err != nil {
+		t.Fatalf("Search() returned an error: %v", err)
+	}
+	if len(page.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(page.Results))
+	}
+	if want := "**oyes**"; !strings.Contains(page.Results[0].Snippet, want) {
+		t.Errorf("Snippet = %q, want it to contain %q", page.Results[0].Snippet, want)
+	}
+}
+
+func TestSearchEmptyQuery(t *testing.T) {
+	searcher := NewFTSSearcher(newTestDB(t))
+
+	if _, err := searcher.Search(context.Background(), " ¿? ", 10, 0); !errors.Is(err, ErrEmptySearch) {
+		t.Errorf("Expected ErrEmptySearch, got %v", err)
+	}
+}
+
+func TestCheckSearchIndexMissing(t *testing.T) {
+	if err := CheckSearchIndex(context.Background(), newTestDB(t)); !errors.Is(err, ErrSearchUnavailable) {
+		t.Errorf("Expected ErrSearchUnavailable, got %v", err)
+	}
+}
+
+func TestMatchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"Hablar", `"hablar"*`},
+		{"  to   speak ", `"to"* "speak"*`},
+		{`"oír" OR x*`, `"oír"* "or"* "x"*`},
+		{"¿?", ""},
+	}
+
+	for _, tt := range tests {
+		if got := matchQuery(tt.query); got != tt.want {
+			t.Errorf("matchQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
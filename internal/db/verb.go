@@ -0,0 +1,37 @@
+package db
+
+import "fmt"
+
+// Person keys identify the form_* column of a verbs row.
+const (
+	Person1s = "1s"
+	Person2s = "2s"
+	Person3s = "3s"
+	Person1p = "1p"
+	Person2p = "2p"
+	Person3p = "3p"
+
+	errUnknownPerson = "unknown person %q"
+)
+
+// Persons lists the person keys in column order.
+var Persons = []string{Person1s, Person2s, Person3s, Person1p, Person2p, Person3p}
+
+// Form returns the conjugated form stored for the given person key.
+func (v Verb) Form(person string) (string, error) {
+	switch person {
+	case Person1s:
+		return NullStringToString(v.Form1s), nil
+	case Person2s:
+		return NullStringToString(v.Form2s), nil
+	case Person3s:
+		return NullStringToString(v.Form3s), nil
+	case Person1p:
+		return NullStringToString(v.Form1p), nil
+	case Person2p:
+		return NullStringToString(v.Form2p), nil
+	case Person3p:
+		return NullStringToString(v.Form3p), nil
+	}
+	return "", fmt.Errorf(errUnknownPerson, person)
+}
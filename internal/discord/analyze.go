@@ -0,0 +1,134 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+)
+
+const (
+	// analyzeCommandName is the name of the message command, shown under Apps in a message's context menu.
+	analyzeCommandName = "Analyze verbs"
+	// analyzeLimit is the number of verbs the analysis shows, one per embed field, the most an embed holds.
+	analyzeLimit = 25
+
+	// Discord rejects an embed whose text exceeds these limits, counted in characters.
+	embedTotalLimit      = 6000
+	embedFieldNameLimit  = 256
+	embedFieldValueLimit = 1024
+	// analyzeFooterRoom is the room kept for the footer, which is only written once the fields that fit are known.
+	analyzeFooterRoom = 100
+
+	errAnalyzeNoText  = "This message has no text to analyze."
+	errAnalyzeNoVerbs = "No conjugated verbs found in this message."
+	msgAnalyzeHidden  = "…and %d more."
+	msgAnalyzeMore    = "Only the first %d verbs are analyzed."
+)
+
+// analyzeCommand is the message command that lists the conjugated verbs of a message.
+func analyzeCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{Type: discordgo.MessageApplicationCommand, Name: analyzeCommandName}
+}
+
+func (h *Handlers) handleAnalyze(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	logger := logging.FromContext(ctx)
+
+	data := i.ApplicationCommandData()
+	var message *discordgo.Message
+	if data.Resolved != nil {
+		message = data.Resolved.Messages[data.TargetID]
+	}
+	if message == nil {
+		logger.WarnContext(ctx, "target message not resolved", "target_id", data.TargetID)
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errQueryingDatabase)
+		return
+	}
+	if strings.TrimSpace(message.Content) == "" {
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errAnalyzeNoText)
+		return
+	}
+
+	result, err := h.service.Analyze(ctx, message.Content, analyzeLimit)
+	if err != nil {
+		logger.ErrorContext(ctx, "analyzing message", "error", err)
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errQueryingDatabase)
+		return
+	}
+	if len(result.Verbs) == 0 {
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errAnalyzeNoVerbs)
+		return
+	}
+	sendInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{createAnalysisEmbed(result)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// createAnalysisEmbed shows one field per verb, named after the verb as the message writes it, listing the
+// infinitive, mood, tense and person of each place its form appears in verbs.db. Fields that would take the embed past
+// Discord's total of embedTotalLimit characters are left out, and the footer says how many.
+func createAnalysisEmbed(r core.AnalysisResult) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{Title: analyzeCommandName, Color: embedColor}
+	size := utf8.RuneCountInString(embed.Title) + analyzeFooterRoom
+	for _, verb := range r.Verbs {
+		field := analysisField(verb)
+		size += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		if size > embedTotalLimit {
+			break
+		}
+		embed.Fields = append(embed.Fields, field)
+	}
+
+	var footer []string
+	if hidden := len(r.Verbs) - len(embed.Fields); hidden > 0 {
+		footer = append(footer, fmt.Sprintf(msgAnalyzeHidden, hidden))
+	}
+	if r.More {
+		footer = append(footer, fmt.Sprintf(msgAnalyzeMore, analyzeLimit))
+	}
+	if len(footer) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: strings.Join(footer, " ")}
+	}
+	return embed
+}
+
+// analysisField shows verb as a field of createAnalysisEmbed, one line per match. Matches past the embedFieldValueLimit
+// characters of a field are left out, and its last line says how many.
+func analysisField(verb core.AnalyzedVerb) *discordgo.MessageEmbedField {
+	name := verb.Phrase
+	if verb.Form != verb.Phrase {
+		name = fmt.Sprintf("%s (%s)", verb.Phrase, verb.Form)
+	}
+	if runes := []rune(name); len(runes) > embedFieldNameLimit {
+		name = string(runes[:embedFieldNameLimit-1]) + "…"
+	}
+
+	lines := make([]string, len(verb.Matches))
+	for j, m := range verb.Matches {
+		lines[j] = fmt.Sprintf("**%s** · %s · %s · %s", m.Infinitive, m.Mood, m.Tense, m.Label)
+	}
+	return &discordgo.MessageEmbedField{Name: name, Value: joinFieldLines(lines)}
+}
+
+// joinFieldLines joins lines into an embed field value. Lines past the embedFieldValueLimit characters of a field are
+// left out, and the last line says how many.
+func joinFieldLines(lines []string) string {
+	value := strings.Join(lines, "\n")
+	for n := len(lines) - 1; n > 0 && utf8.RuneCountInString(value) > embedFieldValueLimit; n-- {
+		value = strings.Join(lines[:n], "\n") + "\n" + fmt.Sprintf(msgAnalyzeHidden, len(lines)-n)
+	}
+	return value
+}
+
+// sendEphemeralResponse answers an interaction with a message only the user who sent it sees.
+func sendEphemeralResponse(ctx context.Context, responder InteractionResponder, interaction *discordgo.Interaction, message string) {
+	sendInteractionResponse(ctx, responder, interaction, &discordgo.InteractionResponseData{
+		Content: message,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+}
@@ -0,0 +1,94 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+func TestAnalyzeCommandRegistration(t *testing.T) {
+	h := NewHandlers(db.NewMemoryRepository(db.MemoryData{}), db.NewInfinitiveIndex(nil), HandlerOptions{})
+	for _, m := range NewCommandRegistry(h) {
+		if m.Command.Name != analyzeCommandName {
+			continue
+		}
+		if m.Command.Type != discordgo.MessageApplicationCommand || m.Command.Description != "" {
+			t.Errorf("Command = %+v, want a message command without a description", m.Command)
+		}
+		return
+	}
+	t.Errorf("Expected a %q message command", analyzeCommandName)
+}
+
+func TestCreateAnalysisEmbed(t *testing.T) {
+	result := core.AnalysisResult{
+		Verbs: []core.AnalyzedVerb{
+			{Phrase: "lo hemos visto", Form: "hemos visto", Matches: []core.FormIdentity{
+				{Infinitive: "ver", Mood: "Indicativo", Tense: "Presente perfecto", Label: "nosotros"},
+			}},
+			{Phrase: "hable", Form: "hable", Matches: []core.FormIdentity{
+				{Infinitive: "hablar", Mood: "Subjuntivo", Tense: "Presente", Label: "yo"},
+				{Infinitive: "hablar", Mood: "Subjuntivo", Tense: "Presente", Label: "él/ella/Ud."},
+			}},
+		},
+		More: true,
+	}
+
+	embed := createAnalysisEmbed(result)
+
+	if len(embed.Fields) != 2 {
+		t.Fatalf("Expected 2 fields, got %d", len(embed.Fields))
+	}
+	if got, want := embed.Fields[0].Name, "lo hemos visto (hemos visto)"; got != want {
+		t.Errorf("Field name = %q, want %q", got, want)
+	}
+	if got, want := embed.Fields[1].Value, "**hablar** · Subjuntivo · Presente · yo\n**hablar** · Subjuntivo · Presente · él/ella/Ud."; got != want {
+		t.Errorf("Field value = %q, want %q", got, want)
+	}
+	if embed.Footer == nil || embed.Footer.Text != "Only the first 25 verbs are analyzed." {
+		t.Errorf("Footer = %+v, want it to say more verbs were found", embed.Footer)
+	}
+}
+
+func TestCreateAnalysisEmbedLimits(t *testing.T) {
+	match := core.FormIdentity{Infinitive: "hablar", Mood: "Subjuntivo", Tense: "Pluscuamperfecto", Label: "nosotros/nosotras"}
+	matches := make([]core.FormIdentity, 40)
+	for j := range matches {
+		matches[j] = match
+	}
+	var result core.AnalysisResult
+	for range analyzeLimit {
+		result.Verbs = append(result.Verbs, core.AnalyzedVerb{Phrase: "hablásemos", Form: "hablásemos", Matches: matches})
+	}
+
+	embed := createAnalysisEmbed(result)
+
+	total := utf8.RuneCountInString(embed.Title)
+	for _, f := range embed.Fields {
+		total += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+		if n := utf8.RuneCountInString(f.Value); n > embedFieldValueLimit {
+			t.Errorf("Field value has %d characters, want at most %d", n, embedFieldValueLimit)
+		}
+		if !strings.HasSuffix(f.Value, "more.") {
+			t.Errorf("Field value = %q, want it to say how many matches are left out", f.Value)
+		}
+	}
+	if embed.Footer == nil {
+		t.Fatal("Expected a footer saying how many verbs are left out")
+	}
+	total += utf8.RuneCountInString(embed.Footer.Text)
+	if total > embedTotalLimit {
+		t.Errorf("Embed has %d characters, want at most %d", total, embedTotalLimit)
+	}
+	if len(embed.Fields) == 0 || len(embed.Fields) == analyzeLimit {
+		t.Fatalf("Got %d fields, want only those that fit", len(embed.Fields))
+	}
+	if want := fmt.Sprintf(msgAnalyzeHidden, analyzeLimit-len(embed.Fields)); embed.Footer.Text != want {
+		t.Errorf("Footer = %q, want %q", embed.Footer.Text, want)
+	}
+}
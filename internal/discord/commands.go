@@ -1,56 +1,326 @@
 package discord
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
 )
 
 const (
 	errCmdCreate = "cannot create command '%s': %w"
+	errCmdList   = "cannot list commands of guild %s: %w"
+	errCmdDelete = "cannot delete command '%s': %w"
+
+	// interactionTimeout bounds the work done for one interaction. Discord invalidates an interaction that is not
+	// answered within three seconds, so the handler needs to be done with its lookups and have sent its response by then.
+	interactionTimeout = 2500 * time.Millisecond
 )
 
+// InteractionHandler is the signature of the handler attached to a command. ctx carries the request ID and logger of
+// the interaction.
+type InteractionHandler = func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate)
+
 // CommandMapping combines a Discord command with its handler function
 type CommandMapping struct {
 	Command *discordgo.ApplicationCommand
 	Handler interface{}
 }
 
-// List of CommandMappings to be registered
-var CommandRegistry = []CommandMapping{
-	{
-		Command: &discordgo.ApplicationCommand{
-			Name:        "conjugate",
-			Description: "Provides conjugation details for a given Spanish verb.",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "infinitive",
-					Description: "Verb to look up.",
-					Required:    true,
+// NewCommandRegistry returns the CommandMappings to be registered, bound to the given handlers.
+func NewCommandRegistry(h *Handlers) []CommandMapping {
+	mappings := []CommandMapping{
+		{
+			Command: &discordgo.ApplicationCommand{
+				Name:        "conjugate",
+				Description: "Provides conjugation details for a given Spanish verb.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "infinitive",
+						Description: "Verb to look up.",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "tense",
+						Description: "Tense and mood of the chosen verb.",
+						Required:    true,
+						Choices:     getTenseMoodChoices(),
+					},
 				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "tense",
-					Description: "Tense and mood of the chosen verb.",
-					Required:    true,
-					Choices:     getTenseMoodChoices(),
+			},
+			Handler: h.handleConjugate,
+		},
+		{
+			Command: &discordgo.ApplicationCommand{
+				Name:        "imperative",
+				Description: "Shows affirmative and negative commands of a Spanish verb side by side.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "infinitive",
+						Description: "Verb to look up.",
+						Required:    true,
+					},
 				},
 			},
+			Handler: h.handleImperative,
+		},
+		{
+			Command: analyzeCommand(),
+			Handler: h.handleAnalyze,
 		},
-		Handler: handleConjugate,
-	},
-	// Add more commands and handlers here as needed
+		{
+			Command: profileCommand(),
+			Handler: h.handleProfile,
+		},
+		{
+			Command: listCommand(),
+			Handler: h.handleList,
+		},
+		{
+			Command: practiceCommand(),
+			Handler: h.handlePractice,
+		},
+		// Add more commands and handlers here as needed
+	}
+
+	if h.search != nil {
+		mappings = append(mappings, CommandMapping{
+			Command: &discordgo.ApplicationCommand{
+				Name:        "search",
+				Description: "Searches Spanish verbs by infinitive, English meaning or any conjugated form.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "query",
+						Description: "Words to search for.",
+						Required:    true,
+						MaxLength:   searchQueryMaxLength,
+					},
+				},
+			},
+			Handler: h.handleSearch,
+		})
+	}
+	if h.guides != nil {
+		mappings = append(mappings, CommandMapping{Command: tenseInfoCommand(), Handler: h.handleTenseInfo})
+	}
+	return mappings
 }
 
-// SetupCommands registers commands and their handlers with the Discord session.
-func SetupCommands(s Session, guildID string, commandMappings []CommandMapping) error {
+// NewComponentRegistry maps the prefix of the custom ID of a message component or modal to its handler.
+func NewComponentRegistry(h *Handlers) map[string]InteractionHandler {
+	return map[string]InteractionHandler{
+		examplesCustomIDPrefix: h.handleMoreExamples,
+		searchCustomIDPrefix:   h.handleSearchPage,
+		practiceCustomIDPrefix: h.handlePracticeComponent,
+	}
+}
+
+// SetupCommands registers commands in every guild of guildIDs with the Discord session and hands every interaction to
+// router.
+func SetupCommands(s Session, guildIDs []string, commandMappings []CommandMapping, router *Router) error {
+	if err := createCommands(s, s.GetUserID(), guildIDs, commandMappings); err != nil {
+		return err
+	}
+	s.AddHandler(router.Handle)
+
+	return nil
+}
+
+// commandCreator creates application commands; both Session and CommandRegistrar do.
+type commandCreator interface {
+	ApplicationCommandCreate(appID string, guildID string, cmd *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+}
+
+func createCommands(c commandCreator, appID string, guildIDs []string, commandMappings []CommandMapping) error {
+	for _, guildID := range guildIDs {
+		for _, m := range commandMappings {
+			if _, err := c.ApplicationCommandCreate(appID, guildID, m.Command); err != nil {
+				return fmt.Errorf(errCmdCreate, m.Command.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CommandRegistrar manages the application commands of a guild. The session of NewCommandRegistrar does so over the
+// REST API, without a gateway connection.
+type CommandRegistrar interface {
+	commandCreator
+	ApplicationCommands(appID, guildID string, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error)
+	ApplicationCommandDelete(appID, guildID, cmdID string, options ...discordgo.RequestOption) error
+}
+
+// GuildCommand is an application command registered in a guild.
+type GuildCommand struct {
+	GuildID string
+	Command *discordgo.ApplicationCommand
+}
+
+// RegisterCommands creates or updates every command of commandMappings in every guild of guildIDs.
+func RegisterCommands(r CommandRegistrar, appID string, guildIDs []string, commandMappings []CommandMapping) error {
+	return createCommands(r, appID, guildIDs, commandMappings)
+}
+
+// UnregisterCommands deletes every application command of appID in the guilds of guildIDs and returns them. With
+// dryRun, the commands are only listed.
+func UnregisterCommands(r CommandRegistrar, appID string, guildIDs []string, dryRun bool) ([]GuildCommand, error) {
+	var removed []GuildCommand
+	for _, guildID := range guildIDs {
+		commands, err := r.ApplicationCommands(appID, guildID)
+		if err != nil {
+			return removed, fmt.Errorf(errCmdList, guildID, err)
+		}
+		for _, cmd := range commands {
+			if !dryRun {
+				if err := r.ApplicationCommandDelete(appID, guildID, cmd.ID); err != nil {
+					return removed, fmt.Errorf(errCmdDelete, cmd.Name, err)
+				}
+			}
+			removed = append(removed, GuildCommand{GuildID: guildID, Command: cmd})
+		}
+	}
+	return removed, nil
+}
+
+// Router dispatches application commands by name and message components by custom ID prefix. Discord delivers every
+// interaction to every registered handler, so without routing each handler would run for every command.
+type Router struct {
+	ctx        context.Context
+	commands   map[string]InteractionHandler
+	components map[string]InteractionHandler
+	inFlight   sync.WaitGroup
+	// mu guards closed and orders every inFlight.Add before the inFlight.Wait of Wait, as sync.WaitGroup requires.
+	mu sync.Mutex
+	// closed is set by Wait; interactions arriving after it are dropped.
+	closed bool
+}
+
+// NewRouter creates a Router for the given commands and component handlers. Interaction contexts derive from ctx, so
+// cancelling it cancels the work of every interaction in flight and stops new ones from being handled.
+func NewRouter(ctx context.Context, commandMappings []CommandMapping, componentHandlers map[string]InteractionHandler) *Router {
+	commands := make(map[string]InteractionHandler, len(commandMappings))
 	for _, m := range commandMappings {
-		if _, err := s.ApplicationCommandCreate(s.GetUserID(), guildID, m.Command); err != nil {
-			return fmt.Errorf(errCmdCreate, m.Command.Name, err)
+		if h, ok := m.Handler.(InteractionHandler); ok {
+			commands[m.Command.Name] = h
 		}
-		s.AddHandler(m.Handler)
 	}
+	return &Router{ctx: ctx, commands: commands, components: componentHandlers}
+}
 
-	return nil
+// Handle runs the handler of an interaction. The handler gets a context carrying a request ID and a logger describing
+// the interaction, which expires after interactionTimeout, and the interaction is logged with its latency once the
+// handler returns.
+func (r *Router) Handle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var (
+		h  InteractionHandler
+		ok bool
+	)
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		name := i.ApplicationCommandData().Name
+		if h, ok = r.commands[name]; ok {
+			metrics.CommandInvoked(name)
+		}
+	case discordgo.InteractionMessageComponent:
+		prefix, _, _ := strings.Cut(i.MessageComponentData().CustomID, customIDSeparator)
+		h, ok = r.components[prefix]
+	case discordgo.InteractionModalSubmit:
+		prefix, _, _ := strings.Cut(i.ModalSubmitData().CustomID, customIDSeparator)
+		h, ok = r.components[prefix]
+	}
+	if !ok || !r.begin() {
+		return
+	}
+	defer r.inFlight.Done()
+
+	logger := slog.Default().With(interactionAttrs(i)...)
+	ctx, cancel := context.WithTimeout(r.ctx, interactionTimeout)
+	defer cancel()
+	ctx = logging.WithLogger(logging.WithRequestID(ctx, logging.NewRequestID()), logger)
+
+	start := time.Now()
+	h(ctx, s, i)
+	logger.InfoContext(ctx, "interaction handled", "latency", time.Since(start))
+}
+
+// begin counts an interaction as in flight unless the router's context is cancelled or Wait was called, and reports
+// whether it did.
+func (r *Router) begin() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.ctx.Err() != nil {
+		return false
+	}
+	r.inFlight.Add(1)
+	return true
+}
+
+// Wait stops the router from taking new interactions and blocks until every interaction in flight has been handled or
+// ctx is done, whichever comes first.
+func (r *Router) Wait(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// interactionAttrs describes an interaction for its log records: where it came from, who sent it and what it asked for.
+func interactionAttrs(i *discordgo.InteractionCreate) []any {
+	attrs := []any{
+		slog.String("guild_id", i.GuildID),
+		slog.String("channel_id", i.ChannelID),
+	}
+	if user := interactionUser(i.Interaction); user != nil {
+		attrs = append(attrs, slog.String("user_id", user.ID))
+	}
+
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		data := i.ApplicationCommandData()
+		options := make(map[string]any, len(data.Options))
+		for _, opt := range data.Options {
+			options[opt.Name] = opt.Value
+		}
+		attrs = append(attrs, slog.String("command", data.Name), slog.Any("options", options))
+		// Message and user commands act on the message or user they were picked from.
+		if data.TargetID != "" {
+			attrs = append(attrs, slog.String("target_id", data.TargetID))
+		}
+	case discordgo.InteractionMessageComponent:
+		attrs = append(attrs, slog.String("custom_id", i.MessageComponentData().CustomID))
+	case discordgo.InteractionModalSubmit:
+		attrs = append(attrs, slog.String("custom_id", i.ModalSubmitData().CustomID))
+	}
+	return attrs
+}
+
+// interactionUser returns the user behind an interaction, which Discord sends as the member in guilds and as the user
+// in direct messages.
+func interactionUser(i *discordgo.Interaction) *discordgo.User {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User
+	}
+	return i.User
 }
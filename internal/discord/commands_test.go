@@ -1,10 +1,17 @@
 package discord
 
 import (
+	"context"
 	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
 )
 
 // Mock handler function for testing
@@ -85,7 +92,7 @@ func TestSetupCommandsSuccess(t *testing.T) {
 	mockSession := newMockSession("testUserID")
 	commandMappings := mockCommandRegistry
 
-	err := SetupCommands(mockSession, "testGuildID", commandMappings)
+	err := SetupCommands(mockSession, []string{"testGuildID"}, commandMappings, NewRouter(context.Background(), commandMappings, nil))
 	if err != nil {
 		t.Errorf("SetupCommands() returned an error: %v", err)
 	}
@@ -97,10 +104,233 @@ func TestSetupCommandsCreateError(t *testing.T) {
 	mockSession.createError = errors.New("create error")
 	commandMappings := mockCommandRegistry
 
-	err := SetupCommands(mockSession, "testGuildID", commandMappings)
+	err := SetupCommands(mockSession, []string{"testGuildID"}, commandMappings, NewRouter(context.Background(), commandMappings, nil))
 	if err == nil {
 		t.Errorf("SetupCommands() did not return an error")
 	} else if err.Error() != "cannot create command 'testCommand1': create error" {
 		t.Errorf("Expected error message 'cannot create command 'testCommand1': create error', got '%v'", err)
 	}
 }
+
+// fakeRegistrar is a CommandRegistrar keeping the commands of each guild in memory.
+type fakeRegistrar struct {
+	guilds map[string][]*discordgo.ApplicationCommand
+	nextID int
+}
+
+func (f *fakeRegistrar) ApplicationCommandCreate(appID string, guildID string, cmd *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error) {
+	f.nextID++
+	created := *cmd
+	created.ID = strconv.Itoa(f.nextID)
+	f.guilds[guildID] = append(f.guilds[guildID], &created)
+	return &created, nil
+}
+
+func (f *fakeRegistrar) ApplicationCommands(appID, guildID string, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error) {
+	return append([]*discordgo.ApplicationCommand(nil), f.guilds[guildID]...), nil
+}
+
+func (f *fakeRegistrar) ApplicationCommandDelete(appID, guildID, cmdID string, options ...discordgo.RequestOption) error {
+	commands := f.guilds[guildID]
+	for i, cmd := range commands {
+		if cmd.ID == cmdID {
+			f.guilds[guildID] = append(commands[:i], commands[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("unknown command")
+}
+
+func TestRegisterAndUnregisterCommands(t *testing.T) {
+	r := &fakeRegistrar{guilds: map[string][]*discordgo.ApplicationCommand{}}
+	guilds := []string{"guild1", "guild2"}
+
+	if err := RegisterCommands(r, "app", guilds, mockCommandRegistry); err != nil {
+		t.Fatalf("RegisterCommands() returned an error: %v", err)
+	}
+	for _, g := range guilds {
+		if got := len(r.guilds[g]); got != len(mockCommandRegistry) {
+			t.Errorf("Guild %s has %d commands, want %d", g, got, len(mockCommandRegistry))
+		}
+	}
+
+	listed, err := UnregisterCommands(r, "app", guilds, true)
+	if err != nil {
+		t.Fatalf("UnregisterCommands() dry run returned an error: %v", err)
+	}
+	if len(listed) != 4 || len(r.guilds["guild1"]) != 2 {
+		t.Errorf("Dry run listed %d commands and left %d in guild1, want 4 listed and 2 left", len(listed), len(r.guilds["guild1"]))
+	}
+
+	removed, err := UnregisterCommands(r, "app", guilds[:1], false)
+	if err != nil {
+		t.Fatalf("UnregisterCommands() returned an error: %v", err)
+	}
+	if len(removed) != 2 || len(r.guilds["guild1"]) != 0 || len(r.guilds["guild2"]) != 2 {
+		t.Errorf("Removed %d commands, leaving %d in guild1 and %d in guild2; want 2, 0 and 2",
+			len(removed), len(r.guilds["guild1"]), len(r.guilds["guild2"]))
+	}
+}
+
+func TestRouter(t *testing.T) {
+	var called []string
+	record := func(name string) InteractionHandler {
+		return func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+			if logging.RequestID(ctx) == "" {
+				t.Errorf("Handler %q got a context without a request ID", name)
+			}
+			called = append(called, name)
+		}
+	}
+	commandMappings := []CommandMapping{
+		{Command: &discordgo.ApplicationCommand{Name: "first"}, Handler: record("first")},
+		{Command: &discordgo.ApplicationCommand{Name: "second"}, Handler: record("second")},
+	}
+	componentHandlers := map[string]InteractionHandler{"button": record("button")}
+
+	router := NewRouter(context.Background(), commandMappings, componentHandlers)
+	router.Handle(nil, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: "second"},
+	}})
+	router.Handle(nil, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: "unknown"},
+	}})
+	router.Handle(nil, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: "button:hablar:Present"},
+	}})
+	router.Handle(nil, &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionModalSubmit,
+		Data: discordgo.ModalSubmitInteractionData{CustomID: "button:answer"},
+	}})
+
+	expected := []string{"second", "button", "button"}
+	if !slices.Equal(called, expected) {
+		t.Errorf("Expected handlers %v to run, got %v", expected, called)
+	}
+}
+
+func commandInteraction(name string) *discordgo.InteractionCreate {
+	return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: name},
+	}}
+}
+
+func TestRouterDeadline(t *testing.T) {
+	var deadline time.Time
+	handler := func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+		deadline, _ = ctx.Deadline()
+	}
+	router := NewRouter(context.Background(), []CommandMapping{
+		{Command: &discordgo.ApplicationCommand{Name: "slow"}, Handler: InteractionHandler(handler)},
+	}, nil)
+
+	start := time.Now()
+	router.Handle(nil, commandInteraction("slow"))
+
+	if deadline.IsZero() || deadline.Sub(start) > 3*time.Second {
+		t.Errorf("Expected a deadline below Discord's 3 second limit, got %v", deadline.Sub(start))
+	}
+}
+
+func TestRouterShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started, release := make(chan struct{}), make(chan struct{})
+	var calls int
+	handler := func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+		calls++
+		close(started)
+		<-ctx.Done()
+		<-release
+	}
+	router := NewRouter(ctx, []CommandMapping{
+		{Command: &discordgo.ApplicationCommand{Name: "wait"}, Handler: InteractionHandler(handler)},
+	}, nil)
+
+	go router.Handle(nil, commandInteraction("wait"))
+	<-started
+	cancel()
+
+	// The handler saw the cancellation but has not returned yet.
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelWait()
+	if err := router.Wait(waitCtx); err == nil {
+		t.Fatal("Wait() returned before the in-flight interaction finished")
+	}
+
+	close(release)
+	if err := router.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() returned an error: %v", err)
+	}
+
+	router.Handle(nil, commandInteraction("wait"))
+	if calls != 1 {
+		t.Errorf("Expected interactions after cancellation to be dropped, handler ran %d times", calls)
+	}
+}
+
+func TestRouterClosedByWait(t *testing.T) {
+	var calls int
+	handler := func(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) { calls++ }
+	router := NewRouter(context.Background(), []CommandMapping{
+		{Command: &discordgo.ApplicationCommand{Name: "late"}, Handler: InteractionHandler(handler)},
+	}, nil)
+
+	if err := router.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() returned an error: %v", err)
+	}
+	// The router's context is still live, but Wait has already begun: the interaction must not start.
+	router.Handle(nil, commandInteraction("late"))
+	if calls != 0 {
+		t.Errorf("Expected interactions after Wait to be dropped, handler ran %d times", calls)
+	}
+}
+
+func TestInteractionAttrs(t *testing.T) {
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type:      discordgo.InteractionApplicationCommand,
+		GuildID:   "guild",
+		ChannelID: "channel",
+		Member:    &discordgo.Member{User: &discordgo.User{ID: "user"}},
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name:    "conjugate",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{{Name: "infinitive", Value: "hablar"}},
+		},
+	}}
+
+	got := make(map[string]string)
+	for _, attr := range interactionAttrs(i) {
+		a := attr.(slog.Attr)
+		got[a.Key] = a.Value.String()
+	}
+
+	want := map[string]string{
+		"guild_id":   "guild",
+		"channel_id": "channel",
+		"user_id":    "user",
+		"command":    "conjugate",
+		"options":    "map[infinitive:hablar]",
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("Attribute %s = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestNewCommandRegistry(t *testing.T) {
+	h := NewHandlers(db.NewMemoryRepository(db.MemoryData{}), db.NewInfinitiveIndex(nil), HandlerOptions{})
+
+	for _, m := range NewCommandRegistry(h) {
+		if _, ok := m.Handler.(InteractionHandler); !ok {
+			t.Errorf("Handler of command %q has type %T, want InteractionHandler", m.Command.Name, m.Handler)
+		}
+	}
+	if _, ok := NewComponentRegistry(h)[examplesCustomIDPrefix]; !ok {
+		t.Errorf("Expected a component handler for %q", examplesCustomIDPrefix)
+	}
+}
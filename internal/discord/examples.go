@@ -0,0 +1,106 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+)
+
+const (
+	examplesCustomIDPrefix = "examples"
+	customIDSeparator      = ":"
+
+	// examplesShown is the number of examples included in the conjugation embed.
+	examplesShown = 2
+	// moreExamplesLimit is the number of examples shown after pressing "More examples".
+	moreExamplesLimit = 10
+
+	errExamplesCustomID = "malformed examples custom ID %q"
+	errExamplesData     = "Error getting examples."
+	errNoExamples       = "No examples available."
+)
+
+// addExamplesField appends up to examplesShown examples to the conjugation embed.
+func addExamplesField(embed *discordgo.MessageEmbed, examples []core.Example) {
+	if len(examples) == 0 {
+		return
+	}
+	if len(examples) > examplesShown {
+		examples = examples[:examplesShown]
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  "Ejemplos",
+		Value: formatExamples(examples),
+	})
+}
+
+// formatExamples renders examples as a bulleted list of sentences followed by their translations.
+func formatExamples(examples []core.Example) string {
+	lines := make([]string, len(examples))
+	for i, ex := range examples {
+		lines[i] = fmt.Sprintf("• *%s*", ex.Sentence)
+		if ex.English != "" {
+			lines[i] += " — " + ex.English
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// moreExamplesComponents returns the "More examples" button for a conjugation.
+func moreExamplesComponents(infinitive, tenseName string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "More examples",
+					Style:    discordgo.SecondaryButton,
+					CustomID: strings.Join([]string{examplesCustomIDPrefix, infinitive, tenseName}, customIDSeparator),
+				},
+			},
+		},
+	}
+}
+
+// parseExamplesCustomID extracts the infinitive and tense name from a "More examples" button ID.
+func parseExamplesCustomID(customID string) (infinitive string, tenseName string, err error) {
+	parts := strings.SplitN(customID, customIDSeparator, 3)
+	if len(parts) != 3 || parts[0] != examplesCustomIDPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf(errExamplesCustomID, customID)
+	}
+	return parts[1], parts[2], nil
+}
+
+func (h *Handlers) handleMoreExamples(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	logger := logging.FromContext(ctx)
+
+	infinitive, tenseName, err := parseExamplesCustomID(i.MessageComponentData().CustomID)
+	if err != nil {
+		logger.WarnContext(ctx, "invalid component", "error", err)
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errExamplesData)
+		return
+	}
+
+	result, err := h.service.Examples(ctx, infinitive, tenseName, moreExamplesLimit)
+	switch {
+	case errors.Is(err, core.ErrUnknownTense):
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errTenseData)
+		return
+	case err != nil:
+		logger.ErrorContext(ctx, "fetching examples", "error", err)
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errExamplesData)
+		return
+	case len(result.Examples) == 0:
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errNoExamples)
+		return
+	}
+
+	sendInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{h.render.Examples(result)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+}
@@ -0,0 +1,55 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+)
+
+func TestAddExamplesField(t *testing.T) {
+	examples := []core.Example{
+		{Sentence: "Yo hablo español.", English: "I speak Spanish."},
+		{Sentence: "Hablo poco."},
+		{Sentence: "Hablo mucho."},
+	}
+
+	embed := &discordgo.MessageEmbed{}
+	addExamplesField(embed, examples)
+
+	if len(embed.Fields) != 1 {
+		t.Fatalf("Expected 1 field, got %d", len(embed.Fields))
+	}
+	expected := "• *Yo hablo español.* — I speak Spanish.\n• *Hablo poco.*"
+	if embed.Fields[0].Name != "Ejemplos" || embed.Fields[0].Value != expected {
+		t.Errorf("Unexpected field %+v", embed.Fields[0])
+	}
+
+	empty := &discordgo.MessageEmbed{}
+	addExamplesField(empty, nil)
+	if len(empty.Fields) != 0 {
+		t.Errorf("Expected no fields without examples, got %d", len(empty.Fields))
+	}
+}
+
+func TestExamplesCustomIDRoundTrip(t *testing.T) {
+	components := moreExamplesComponents("hablar", "Present perfect")
+	row := components[0].(discordgo.ActionsRow)
+	button := row.Components[0].(discordgo.Button)
+
+	infinitive, tenseName, err := parseExamplesCustomID(button.CustomID)
+	if err != nil {
+		t.Fatalf("parseExamplesCustomID() returned an error: %v", err)
+	}
+	if infinitive != "hablar" || tenseName != "Present perfect" {
+		t.Errorf("Got (%q, %q), want (%q, %q)", infinitive, tenseName, "hablar", "Present perfect")
+	}
+}
+
+func TestParseExamplesCustomID_Invalid(t *testing.T) {
+	for _, id := range []string{"", "examples", "examples:hablar", "other:hablar:Present", "examples::Present"} {
+		if _, _, err := parseExamplesCustomID(id); err == nil {
+			t.Errorf("Expected an error for %q", id)
+		}
+	}
+}
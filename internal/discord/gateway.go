@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+)
+
+var errGatewayDisconnected = errors.New("gateway session is disconnected")
+
+// GatewayState tracks whether the gateway session is connected and counts reconnects.
+type GatewayState struct {
+	connected atomic.Bool
+}
+
+// TrackGateway follows the connection state of an open session, which starts out connected.
+func TrackGateway(s Session) *GatewayState {
+	g := &GatewayState{}
+	g.connected.Store(true)
+	s.AddHandler(g.onConnect)
+	s.AddHandler(g.onDisconnect)
+	return g
+}
+
+// onConnect runs whenever discordgo re-establishes the gateway connection. The first connection happened before
+// tracking started, so every connect seen here is a reconnect.
+func (g *GatewayState) onConnect(s *discordgo.Session, c *discordgo.Connect) {
+	g.connected.Store(true)
+	metrics.GatewayReconnected()
+}
+
+func (g *GatewayState) onDisconnect(s *discordgo.Session, d *discordgo.Disconnect) {
+	g.connected.Store(false)
+}
+
+// Check returns an error while the gateway session is disconnected. Its signature matches metrics.Check.
+func (g *GatewayState) Check(ctx context.Context) error {
+	if !g.connected.Load() {
+		return errGatewayDisconnected
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package discord
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestGatewayState(t *testing.T) {
+	session := newMockSession("testUserID")
+	g := TrackGateway(session)
+
+	if len(session.handlers) != 2 {
+		t.Fatalf("Expected 2 event handlers, got %d", len(session.handlers))
+	}
+	if err := g.Check(context.Background()); err != nil {
+		t.Errorf("Check() on a new session returned an error: %v", err)
+	}
+
+	g.onDisconnect(nil, &discordgo.Disconnect{})
+	if err := g.Check(context.Background()); err == nil {
+		t.Error("Check() after a disconnect returned no error")
+	}
+
+	g.onConnect(nil, &discordgo.Connect{})
+	if err := g.Check(context.Background()); err != nil {
+		t.Errorf("Check() after a reconnect returned an error: %v", err)
+	}
+}
@@ -2,54 +2,119 @@ package discord
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
-	"log"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
 	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/tenseinfo"
 )
 
 const (
 	errInfinitiveNotFound = "infinitive not found"
 	errTenseNotFound      = "tense not found"
 	errInfinitiveOrTense  = "Infinitive or tense not provided."
+	errInfinitiveMissing  = "Infinitive not provided."
 	errTenseData          = "Error getting tense data."
-	errVerbNotFound       = "Verb not found."
 	errQueryingDatabase   = "Error querying database."
 )
 
-func handleConjugate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+// Handlers holds the dependencies shared by the command handlers.
+type Handlers struct {
+	service  *core.Service
+	render   core.Renderer[*discordgo.MessageEmbed]
+	search   db.Searcher
+	practice PracticeStats
+	lists    VerbLists
+	guides   *tenseinfo.Library
+}
+
+// HandlerOptions are the dependencies of Handlers beyond verb data. A command whose dependency is nil is left out of
+// the registry, or, for /practice, Study profile and /list, registered to answer that it is unavailable, as where the
+// handlers only describe the commands in register-commands.
+type HandlerOptions struct {
+	// Search backs /search.
+	Search db.Searcher
+	// Practice backs /practice and Study profile.
+	Practice PracticeStats
+	// Lists backs /list.
+	Lists VerbLists
+	// Guides back /tense-info.
+	Guides *tenseinfo.Library
+}
+
+// NewHandlers creates command handlers that read verb data from the given repository, resolve the infinitives users
+// type through the given index and take the rest of their dependencies from opts.
+func NewHandlers(verbs db.VerbRepository, infinitives *db.InfinitiveIndex, opts HandlerOptions) *Handlers {
+	return &Handlers{
+		service:  core.NewService(verbs, infinitives),
+		render:   EmbedRenderer{},
+		search:   opts.Search,
+		practice: opts.Practice,
+		lists:    opts.Lists,
+		guides:   opts.Guides,
+	}
+}
+
+func (h *Handlers) handleConjugate(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	logger := logging.FromContext(ctx)
+
 	options := i.ApplicationCommandData().Options
 	optionMap := makeOptionMap(options)
 
 	infinitive, tense, err := extractInfinitiveAndTense(optionMap)
 	if err != nil {
-		log.Println("Missing required options:", err)
-		sendErrorInteractionResponse(&DiscordSession{s}, i.Interaction, errInfinitiveOrTense)
+		logger.WarnContext(ctx, "missing required options", "error", err)
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errInfinitiveOrTense)
 		return
 	}
 
-	tenseMoodObject, err := getValueByName(tense)
+	result, err := h.service.Conjugate(ctx, infinitive, tense, examplesShown)
+	if err != nil && result.Infinitive == "" {
+		respondLookupError(ctx, s, i, infinitive, err)
+		return
+	}
+	// Examples are optional, so a failed lookup still sends the conjugation.
 	if err != nil {
-		sendErrorInteractionResponse(&DiscordSession{s}, i.Interaction, errTenseData)
+		logger.ErrorContext(ctx, "fetching examples", "error", err)
+	}
+
+	var components []discordgo.MessageComponent
+	if result.MoreExamples {
+		components = moreExamplesComponents(result.Infinitive, result.TenseName)
+	}
+	sendConjugationResponse(ctx, &DiscordSession{s}, i.Interaction, h.render.Conjugation(result), components...)
+}
+
+func (h *Handlers) handleImperative(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	logger := logging.FromContext(ctx)
+
+	optionMap := makeOptionMap(i.ApplicationCommandData().Options)
+
+	opt, exists := optionMap["infinitive"]
+	if !exists {
+		logger.WarnContext(ctx, "missing required options", "error", errInfinitiveNotFound)
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errInfinitiveMissing)
 		return
 	}
 
-	verb, err := fetchVerbFromDB(infinitive, tenseMoodObject)
+	result, err := h.service.Imperative(ctx, opt.StringValue())
 	if err != nil {
-		if err == sql.ErrNoRows {
-			sendErrorInteractionResponse(&DiscordSession{s}, i.Interaction, errVerbNotFound)
-			return
-		}
-
-		log.Println("Error fetching verb:", err)
-		sendErrorInteractionResponse(&DiscordSession{s}, i.Interaction, errQueryingDatabase)
+		respondLookupError(ctx, s, i, opt.StringValue(), err)
 		return
 	}
+	sendConjugationResponse(ctx, &DiscordSession{s}, i.Interaction, h.render.Imperative(result))
+}
 
-	conjugationEmbed := createConjugationEmbed(infinitive, verb)
-	sendConjugationResponse(&DiscordSession{s}, i.Interaction, conjugationEmbed)
+// respondLookupError answers an interaction whose lookup of the verb the user typed as input failed with err.
+func respondLookupError(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, input string, err error) {
+	sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, core.LookupErrorMessage(ctx, input, err, bold))
+}
+
+// bold marks up s in bold.
+func bold(s string) string {
+	return "**" + s + "**"
 }
 
 func makeOptionMap(options []*discordgo.ApplicationCommandInteractionDataOption) map[string]*discordgo.ApplicationCommandInteractionDataOption {
@@ -75,20 +140,3 @@ func extractInfinitiveAndTense(optionMap map[string]*discordgo.ApplicationComman
 
 	return infinitive, tense, err
 }
-
-func fetchVerbFromDB(infinitive string, tenseMoodObject TenseMood) (*db.Verb, error) {
-	ctx := context.Background()
-	sqlDB, err := db.GetDB()
-	if err != nil {
-		return nil, err
-	}
-
-	queries := db.New(sqlDB)
-
-	verb, err := queries.GetVerbByInfinitiveMoodTense(ctx, db.GetVerbByInfinitiveMoodTenseParams{Infinitive: infinitive, Mood: tenseMoodObject.Mood, Tense: tenseMoodObject.Tense})
-	if err != nil {
-		return nil, err
-	}
-
-	return &verb, nil
-}
@@ -0,0 +1,29 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+)
+
+// createImperativeEmbed generates a Discord embed showing affirmative and negative commands side by side.
+func createImperativeEmbed(infinitive, english string, rows []core.ImperativeRow) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(rows))
+	for _, row := range rows {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   row.Person,
+			Value:  fmt.Sprintf("%s · %s\n↳ %s", row.Affirmative, row.Negative, row.Subjunctive),
+			Inline: true,
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:  fmt.Sprintf("%s - %s", infinitive, english),
+		Color:  embedColor,
+		Fields: fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "afirmativo · negativo\n↳ presente de subjuntivo",
+		},
+	}
+}
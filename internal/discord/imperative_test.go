@@ -0,0 +1,25 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+)
+
+func TestCreateImperativeEmbed(t *testing.T) {
+	rows := []core.ImperativeRow{
+		{Person: "tú", Affirmative: "habla", Negative: "no hables", Subjunctive: "hables"},
+	}
+
+	embed := createImperativeEmbed("hablar", "Speak. Don't speak.", rows)
+
+	if embed.Title != "hablar - Speak. Don't speak." {
+		t.Errorf("Unexpected title %q", embed.Title)
+	}
+	if len(embed.Fields) != 1 {
+		t.Fatalf("Expected 1 field, got %d", len(embed.Fields))
+	}
+	if embed.Fields[0].Name != "tú" || embed.Fields[0].Value != "habla · no hables\n↳ hables" {
+		t.Errorf("Unexpected field %+v", embed.Fields[0])
+	}
+}
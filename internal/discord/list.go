@@ -0,0 +1,386 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+const (
+	// listNameMaxLength keeps list names short enough to read in an embed title.
+	listNameMaxLength = 40
+	// listEditPermission is what a member needs to change the lists of a guild.
+	listEditPermission = discordgo.PermissionManageMessages
+
+	errListData         = "Error reading verb lists."
+	errListUnavailable  = "Verb lists are unavailable."
+	errListUpdate       = "Error updating the verb list."
+	errListNotFound     = "No verb list named %q."
+	errListCodeNotFound = "No verb list has the share code %q."
+	errListExists       = "A verb list named %q already exists."
+	errListFull         = "A verb list holds at most %d verbs."
+	errListNoVerbs      = "List at least one infinitive."
+	errListUnknownVerbs = "Not infinitives in verbs.db: %s. Nothing was added."
+	errListGuildOnly    = "Server lists can only be used in a server."
+	errListPermission   = "Only members who can manage messages can change the lists of this server."
+	errListShowArgs     = "Give either a list name or a share code, not both."
+	msgListCreated      = "Created %s with %d verbs. Share code: `%s`"
+	msgListAdded        = "Added %s to %s."
+	msgListNothingAdded = "%s already holds every verb given."
+	msgListRemoved      = "Removed **%s** from %s."
+	msgListNotInList    = "%s does not hold **%s**."
+	msgListShare        = "Share code of %s: `%s`\nAnyone can see it with `/list show code:%s` or copy it with `/list create from:%s`."
+	msgListNone         = "No verb lists yet. Create one with `/list create`."
+	msgListEmpty        = "No verbs yet."
+)
+
+// VerbLists keeps the named verb lists of users and guilds; store.ListStore does so in the app database.
+type VerbLists interface {
+	Create(ctx context.Context, owner store.ListOwner, name, createdBy string, infinitives []string) (store.VerbList, error)
+	Add(ctx context.Context, owner store.ListOwner, name string, infinitives []string) ([]string, error)
+	Remove(ctx context.Context, owner store.ListOwner, name, infinitive string) (bool, error)
+	Get(ctx context.Context, owner store.ListOwner, name string) (store.VerbList, error)
+	ByCode(ctx context.Context, code string) (store.VerbList, error)
+	Lists(ctx context.Context, owners ...store.ListOwner) ([]store.VerbList, error)
+}
+
+// listCommand is the /list command, whose subcommands manage verb lists.
+func listCommand() *discordgo.ApplicationCommand {
+	name := func(required bool) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "name",
+			Description: "Name of the list.",
+			Required:    required,
+			MaxLength:   listNameMaxLength,
+		}
+	}
+	server := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionBoolean,
+		Name:        "server",
+		Description: "Use the list of this server instead of your own.",
+	}
+	subcommand := func(name, description string, options ...*discordgo.ApplicationCommandOption) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        name,
+			Description: description,
+			Options:     options,
+		}
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "list",
+		Description: "Manages named lists of verbs.",
+		Options: []*discordgo.ApplicationCommandOption{
+			subcommand("create", "Creates a verb list, empty or copied from a share code.",
+				name(true),
+				server,
+				&discordgo.ApplicationCommandOption{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "from",
+					Description: "Share code of a list to copy.",
+				}),
+			subcommand("add", "Adds verbs to a list.",
+				name(true),
+				&discordgo.ApplicationCommandOption{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "verbs",
+					Description: "Infinitives to add, separated by commas or spaces.",
+					Required:    true,
+				},
+				server),
+			subcommand("remove", "Removes a verb from a list.",
+				name(true),
+				&discordgo.ApplicationCommandOption{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "verb",
+					Description: "Infinitive to remove.",
+					Required:    true,
+				},
+				server),
+			subcommand("show", "Shows a list, or every list of yours and of this server.",
+				name(false),
+				&discordgo.ApplicationCommandOption{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "code",
+					Description: "Share code of a list.",
+				},
+				server),
+			subcommand("share", "Shows the share code of a list.", name(true), server),
+		},
+	}
+}
+
+func (h *Handlers) handleList(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if h.lists == nil {
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errListUnavailable)
+		return
+	}
+	sendInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, h.runList(ctx, i.Interaction))
+}
+
+// runList carries out the /list subcommand of interaction and returns the response. Only /list show answers for the
+// whole channel to see.
+func (h *Handlers) runList(ctx context.Context, interaction *discordgo.Interaction) *discordgo.InteractionResponseData {
+	options := interaction.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return ephemeral(errListData)
+	}
+	sub := options[0]
+	optionMap := makeOptionMap(sub.Options)
+	stringOption := func(name string) string {
+		if opt, ok := optionMap[name]; ok {
+			return strings.TrimSpace(opt.StringValue())
+		}
+		return ""
+	}
+	name := stringOption("name")
+
+	owner, errMessage := listOwner(interaction, optionMap, sub.Name != "show" && sub.Name != "share")
+	if errMessage != "" {
+		return ephemeral(errMessage)
+	}
+
+	var (
+		message string
+		err     error
+	)
+	switch sub.Name {
+	case "create":
+		message, err = h.createList(ctx, interaction, owner, name, stringOption("from"))
+		if err != nil {
+			return ephemeral(listErrorMessage(ctx, err, name, stringOption("from")))
+		}
+	case "add":
+		message, err = h.addToList(ctx, owner, name, stringOption("verbs"))
+	case "remove":
+		message, err = h.removeFromList(ctx, owner, name, stringOption("verb"))
+	case "share":
+		var l store.VerbList
+		if l, err = h.lists.Get(ctx, owner, name); err == nil {
+			message = fmt.Sprintf(msgListShare, listTitle(l), l.ShareCode, l.ShareCode, l.ShareCode)
+		}
+	case "show":
+		data, err := h.showList(ctx, interaction, owner, name, stringOption("code"))
+		if err != nil {
+			return ephemeral(listErrorMessage(ctx, err, name, stringOption("code")))
+		}
+		return data
+	default:
+		return ephemeral(errListData)
+	}
+	if err != nil {
+		return ephemeral(listErrorMessage(ctx, err, name, ""))
+	}
+	return ephemeral(message)
+}
+
+// listOwner returns the owner of the list an interaction names: the guild when its server option is set, else the
+// user. With edit set, a guild's list also needs listEditPermission. When the list cannot be used it returns the
+// message to show instead.
+func listOwner(interaction *discordgo.Interaction, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption, edit bool) (store.ListOwner, string) {
+	if opt, ok := optionMap["server"]; !ok || !opt.BoolValue() {
+		return store.ListOwner{Scope: store.ScopeUser, ID: interactionUser(interaction).ID}, ""
+	}
+	if interaction.GuildID == "" {
+		return store.ListOwner{}, errListGuildOnly
+	}
+	if edit && (interaction.Member == nil || interaction.Member.Permissions&listEditPermission == 0) {
+		return store.ListOwner{}, errListPermission
+	}
+	return store.ListOwner{Scope: store.ScopeGuild, ID: interaction.GuildID}, ""
+}
+
+func (h *Handlers) createList(ctx context.Context, interaction *discordgo.Interaction, owner store.ListOwner, name, from string) (string, error) {
+	var infinitives []string
+	if from != "" {
+		source, err := h.lists.ByCode(ctx, from)
+		if err != nil {
+			return "", err
+		}
+		infinitives = source.Infinitives
+	}
+	l, err := h.lists.Create(ctx, owner, name, interactionUser(interaction).ID, infinitives)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(msgListCreated, listTitle(l), len(l.Infinitives), l.ShareCode), nil
+}
+
+func (h *Handlers) addToList(ctx context.Context, owner store.ListOwner, name, verbs string) (string, error) {
+	words := strings.FieldsFunc(verbs, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(words) == 0 {
+		return errListNoVerbs, nil
+	}
+	infinitives, invalid := h.resolveInfinitives(words)
+	if len(invalid) > 0 {
+		return fmt.Sprintf(errListUnknownVerbs, strings.Join(invalid, ", ")), nil
+	}
+
+	added, err := h.lists.Add(ctx, owner, name, infinitives)
+	if err != nil {
+		return "", err
+	}
+	title := listTitle(store.VerbList{Owner: owner, Name: name})
+	if len(added) == 0 {
+		return fmt.Sprintf(msgListNothingAdded, title), nil
+	}
+	return fmt.Sprintf(msgListAdded, boldJoin(added), title), nil
+}
+
+func (h *Handlers) removeFromList(ctx context.Context, owner store.ListOwner, name, verb string) (string, error) {
+	// A verb is removed as typed when it no longer resolves, so lists never get stuck with an entry.
+	infinitive, err := h.service.Resolve(verb)
+	if err != nil {
+		infinitive = verb
+	}
+	removed, err := h.lists.Remove(ctx, owner, name, infinitive)
+	if err != nil {
+		return "", err
+	}
+	title := listTitle(store.VerbList{Owner: owner, Name: name})
+	if !removed {
+		return fmt.Sprintf(msgListNotInList, title, infinitive), nil
+	}
+	return fmt.Sprintf(msgListRemoved, infinitive, title), nil
+}
+
+// showList shows the list named name or with the share code code or, with neither, the lists of the user and of the
+// guild of the interaction.
+func (h *Handlers) showList(ctx context.Context, interaction *discordgo.Interaction, owner store.ListOwner, name, code string) (*discordgo.InteractionResponseData, error) {
+	var (
+		l   store.VerbList
+		err error
+	)
+	switch {
+	case name != "" && code != "":
+		return ephemeral(errListShowArgs), nil
+	case code != "":
+		l, err = h.lists.ByCode(ctx, code)
+	case name != "":
+		l, err = h.lists.Get(ctx, owner, name)
+	default:
+		owners := []store.ListOwner{{Scope: store.ScopeUser, ID: interactionUser(interaction).ID}}
+		if interaction.GuildID != "" {
+			owners = append(owners, store.ListOwner{Scope: store.ScopeGuild, ID: interaction.GuildID})
+		}
+		lists, err := h.lists.Lists(ctx, owners...)
+		if err != nil {
+			return nil, err
+		}
+		if len(lists) == 0 {
+			return ephemeral(msgListNone), nil
+		}
+		return &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{createListIndexEmbed(lists)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &discordgo.InteractionResponseData{Embeds: []*discordgo.MessageEmbed{createListEmbed(l)}}, nil
+}
+
+// resolveInfinitives maps each word to its infinitive in verbs.db, as /conjugate does, dropping repeats. It returns
+// the words that are no infinitive, or match several, as invalid.
+func (h *Handlers) resolveInfinitives(words []string) (infinitives, invalid []string) {
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		infinitive, err := h.service.Resolve(word)
+		var ambiguous *db.AmbiguousInfinitiveError
+		switch {
+		case errors.As(err, &ambiguous):
+			invalid = append(invalid, fmt.Sprintf("%s (%s)", word, core.FormatAmbiguousInfinitive(ambiguous.Candidates, bold)))
+		case err != nil:
+			invalid = append(invalid, word)
+		case !seen[infinitive]:
+			seen[infinitive] = true
+			infinitives = append(infinitives, infinitive)
+		}
+	}
+	return infinitives, invalid
+}
+
+// listErrorMessage returns the message to show for an error of the list store; name and code are those the user gave.
+func listErrorMessage(ctx context.Context, err error, name, code string) string {
+	switch {
+	case errors.Is(err, store.ErrListNotFound) && code != "":
+		return fmt.Sprintf(errListCodeNotFound, code)
+	case errors.Is(err, store.ErrListNotFound):
+		return fmt.Sprintf(errListNotFound, name)
+	case errors.Is(err, store.ErrListExists):
+		return fmt.Sprintf(errListExists, name)
+	case errors.Is(err, store.ErrListFull):
+		return fmt.Sprintf(errListFull, store.MaxListSize)
+	default:
+		logging.FromContext(ctx).ErrorContext(ctx, "verb list", "error", err)
+		return errListUpdate
+	}
+}
+
+// listTitle names a list by its name and whose it is, e.g. "**Lesson 1** (server)".
+func listTitle(l store.VerbList) string {
+	if l.Owner.Scope == store.ScopeGuild {
+		return fmt.Sprintf("**%s** (server)", l.Name)
+	}
+	return fmt.Sprintf("**%s**", l.Name)
+}
+
+// createListEmbed shows the verbs of a list and its share code.
+func createListEmbed(l store.VerbList) *discordgo.MessageEmbed {
+	verbs := msgListEmpty
+	if len(l.Infinitives) > 0 {
+		verbs = strings.Join(l.Infinitives, ", ")
+	}
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s - %d verbs", l.Name, len(l.Infinitives)),
+		Description: verbs,
+		Color:       embedColor,
+		Footer:      &discordgo.MessageEmbedFooter{Text: "Share code: " + l.ShareCode},
+	}
+}
+
+// createListIndexEmbed lists verb lists by name with their share codes, the user's apart from the guild's. Lists past
+// the length of a field are counted rather than shown.
+func createListIndexEmbed(lists []store.VerbList) *discordgo.MessageEmbed {
+	var mine, server []string
+	for _, l := range lists {
+		line := fmt.Sprintf("%s · `%s`", l.Name, l.ShareCode)
+		if l.Owner.Scope == store.ScopeGuild {
+			server = append(server, line)
+		} else {
+			mine = append(mine, line)
+		}
+	}
+	embed := &discordgo.MessageEmbed{Title: "Verb lists", Color: embedColor}
+	if len(mine) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Yours", Value: joinFieldLines(mine)})
+	}
+	if len(server) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "This server", Value: joinFieldLines(server)})
+	}
+	return embed
+}
+
+// ephemeral is the response that shows message only to the user.
+func ephemeral(message string) *discordgo.InteractionResponseData {
+	return &discordgo.InteractionResponseData{Content: message, Flags: discordgo.MessageFlagsEphemeral}
+}
+
+// boldJoin joins words in bold, e.g. "**ser**, **estar**".
+func boldJoin(words []string) string {
+	marked := make([]string, len(words))
+	for j, w := range words {
+		marked[j] = bold(w)
+	}
+	return strings.Join(marked, ", ")
+}
@@ -0,0 +1,137 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+// listInteraction builds a /list interaction in guild g1 by user, with the subcommand sub and its options.
+func listInteraction(user string, permissions int64, sub string, options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.Interaction {
+	return &discordgo.Interaction{
+		Type:    discordgo.InteractionApplicationCommand,
+		GuildID: "g1",
+		Member:  &discordgo.Member{User: &discordgo.User{ID: user}, Permissions: permissions},
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "list",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: sub, Type: discordgo.ApplicationCommandOptionSubCommand, Options: options},
+			},
+		},
+	}
+}
+
+func stringOpt(name, value string) *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: discordgo.ApplicationCommandOptionString, Value: value}
+}
+
+func serverOpt() *discordgo.ApplicationCommandInteractionDataOption {
+	return &discordgo.ApplicationCommandInteractionDataOption{Name: "server", Type: discordgo.ApplicationCommandOptionBoolean, Value: true}
+}
+
+func TestListCommandRegistration(t *testing.T) {
+	h := NewHandlers(db.NewMemoryRepository(db.MemoryData{}), db.NewInfinitiveIndex(nil), HandlerOptions{})
+	for _, m := range NewCommandRegistry(h) {
+		if m.Command.Name != "list" {
+			continue
+		}
+		if len(m.Command.Options) != 5 {
+			t.Errorf("Options = %d, want the create, add, remove, show and share subcommands", len(m.Command.Options))
+		}
+		return
+	}
+	t.Error("Expected a /list command")
+}
+
+func TestCreateListIndexEmbed(t *testing.T) {
+	var lists []store.VerbList
+	for n := range 100 {
+		lists = append(lists, store.VerbList{
+			Owner:     store.ListOwner{Scope: store.ScopeUser, ID: "ana"},
+			Name:      fmt.Sprintf("Lesson %d with a rather long name", n),
+			ShareCode: "ABC234",
+		})
+	}
+	lists = append(lists, store.VerbList{Owner: store.ListOwner{Scope: store.ScopeGuild, ID: "g1"}, Name: "Unit 1", ShareCode: "XYZ789"})
+
+	embed := createListIndexEmbed(lists)
+	if len(embed.Fields) != 2 {
+		t.Fatalf("Fields = %d, want yours and the server's", len(embed.Fields))
+	}
+	mine := embed.Fields[0].Value
+	if n := utf8.RuneCountInString(mine); n > embedFieldValueLimit {
+		t.Errorf("Field value has %d characters, want at most %d", n, embedFieldValueLimit)
+	}
+	if !strings.HasPrefix(mine, "Lesson 0 with") || !strings.HasSuffix(mine, " more.") {
+		t.Errorf("Field value = %q, want the first lists and how many more there are", mine)
+	}
+	if got := embed.Fields[1].Value; got != "Unit 1 · `XYZ789`" {
+		t.Errorf("Server field = %q, want the one server list", got)
+	}
+}
+
+func TestRunList(t *testing.T) {
+	ctx := context.Background()
+	sqlDB, err := store.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("Open() returned an error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	lists := store.NewListStore(sqlDB)
+	infinitives := db.NewInfinitiveIndex([]db.Infinitive{{Infinitive: "ser"}, {Infinitive: "estar"}, {Infinitive: "oír"}})
+	h := NewHandlers(db.NewMemoryRepository(db.MemoryData{}), infinitives, HandlerOptions{Lists: lists})
+
+	teacher := int64(discordgo.PermissionManageMessages)
+	steps := []struct {
+		name        string
+		interaction *discordgo.Interaction
+		want        string
+		public      bool
+	}{
+		{"create", listInteraction("ana", 0, "create", stringOpt("name", "Lesson 1")), "Created **Lesson 1** with 0 verbs", false},
+		{"duplicate", listInteraction("ana", 0, "create", stringOpt("name", "lesson 1")), `A verb list named "lesson 1" already exists.`, false},
+		{"add resolves", listInteraction("ana", 0, "add", stringOpt("name", "Lesson 1"), stringOpt("verbs", "SER, oir estar ser")), "Added **ser**, **oír**, **estar** to **Lesson 1**.", false},
+		{"add unknown", listInteraction("ana", 0, "add", stringOpt("name", "Lesson 1"), stringOpt("verbs", "ser, xyz")), "Not infinitives in verbs.db: xyz. Nothing was added.", false},
+		{"add missing list", listInteraction("ana", 0, "add", stringOpt("name", "Other"), stringOpt("verbs", "ser")), `No verb list named "Other".`, false},
+		{"remove", listInteraction("ana", 0, "remove", stringOpt("name", "Lesson 1"), stringOpt("verb", "Estar")), "Removed **estar** from **Lesson 1**.", false},
+		{"show", listInteraction("ana", 0, "show", stringOpt("name", "Lesson 1")), "ser, oír", true},
+		{"server needs permission", listInteraction("ben", 0, "create", stringOpt("name", "Unit 2"), serverOpt()), errListPermission, false},
+		{"server create", listInteraction("ben", teacher, "create", stringOpt("name", "Unit 2"), serverOpt()), "Created **Unit 2** (server)", false},
+		{"server show without permission", listInteraction("ana", 0, "show", stringOpt("name", "Unit 2"), serverOpt()), "No verbs yet.", true},
+		{"index", listInteraction("ana", 0, "show"), "Lesson 1", false},
+		{"unknown code", listInteraction("ana", 0, "show", stringOpt("code", "NOPE")), `No verb list has the share code "NOPE".`, false},
+	}
+	for _, step := range steps {
+		data := h.runList(ctx, step.interaction)
+		text := data.Content
+		if len(data.Embeds) > 0 {
+			text = data.Embeds[0].Description
+			for _, f := range data.Embeds[0].Fields {
+				text += "\n" + f.Value
+			}
+		}
+		if !strings.Contains(text, step.want) {
+			t.Errorf("%s: response %q, want it to contain %q", step.name, text, step.want)
+		}
+		if public := data.Flags&discordgo.MessageFlagsEphemeral == 0; public != step.public {
+			t.Errorf("%s: public = %v, want %v", step.name, public, step.public)
+		}
+	}
+
+	// A list copied by share code starts with the verbs of the original.
+	original, err := lists.Get(ctx, store.ListOwner{Scope: store.ScopeUser, ID: "ana"}, "Lesson 1")
+	if err != nil {
+		t.Fatalf("Get() returned an error: %v", err)
+	}
+	data := h.runList(ctx, listInteraction("ben", 0, "create", stringOpt("name", "Copy"), stringOpt("from", original.ShareCode)))
+	if !strings.Contains(data.Content, "Created **Copy** with 2 verbs") {
+		t.Errorf("create from a share code: response %q, want a copy with 2 verbs", data.Content)
+	}
+}
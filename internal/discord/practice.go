@@ -0,0 +1,449 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+const (
+	practiceCustomIDPrefix = "practice"
+	// practiceActionAnswer marks the "Answer" button and the modal it opens, practiceActionNext the "Next" button.
+	practiceActionAnswer = "answer"
+	practiceActionNext   = "next"
+	// practiceAnswerInputID is the custom ID of the text input in the answer modal.
+	practiceAnswerInputID = "form"
+	// practiceFilterSeparator separates the fields of an encoded practiceFilter.
+	practiceFilterSeparator = "."
+	// practiceAnswerMaxLength leaves room for the longest forms, such as "hubiésemos hablado".
+	practiceAnswerMaxLength = 60
+
+	errPracticeUnavailable = "Practice is unavailable."
+	errPracticeData        = "Error drawing a practice question."
+	errPracticeNoQuestion  = "No conjugation to practice with the selected verbs and tenses."
+	errPracticeCustomID    = "malformed practice custom ID %q"
+	errPracticeLevel       = "Unknown level %q."
+	errPracticeNoLevel     = "None of the selected verbs is ranked at level %s or easier."
+	errPracticeNoList      = "No verb list of yours or of this server is named %q, and no list has it as share code."
+	msgPracticeFooter      = "Answers count toward your Study profile."
+	msgPracticeCorrect     = "Correct: %s."
+	msgPracticeAccents     = "Almost: mind the accents. It is %s."
+	msgPracticeWrong       = "No, it is %s."
+	msgPracticeNotSaved    = "Your answer could not be saved to your Study profile."
+)
+
+// practiceFilter is what /practice was asked to quiz. It is carried along in the custom IDs of the practice buttons,
+// so every question of a session is drawn from the same selection.
+type practiceFilter struct {
+	// tense is the index of the chosen spanish.TenseMoodChoices entry, or -1 for every tense.
+	tense int
+	// list is the share code of the verb list to practice, or empty for every verb. The share code stays short and
+	// finds the list whoever presses the buttons.
+	list string
+	// level is the hardest of db.CEFRLevels to practice verbs of, or empty for verbs of any level, ranked or not.
+	level string
+}
+
+// String encodes f for a custom ID, leaving out trailing fields that are not set.
+func (f practiceFilter) String() string {
+	tense := ""
+	if f.tense >= 0 {
+		tense = strconv.Itoa(f.tense)
+	}
+	fields := []string{tense, f.list, f.level}
+	for len(fields) > 1 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, practiceFilterSeparator)
+}
+
+// parsePracticeFilter decodes a filter encoded by practiceFilter.String.
+func parsePracticeFilter(s string) (practiceFilter, bool) {
+	fields := strings.SplitN(s, practiceFilterSeparator, 3)
+	fields = append(fields, make([]string, 3-len(fields))...)
+	filter := practiceFilter{tense: -1, list: fields[1], level: fields[2]}
+	if filter.level != "" && !slices.Contains(db.CEFRLevels, filter.level) {
+		return practiceFilter{}, false
+	}
+	if fields[0] == "" {
+		return filter, true
+	}
+	tense, err := strconv.Atoi(fields[0])
+	if err != nil || tense < 0 || tense >= len(spanish.TenseMoodChoices) {
+		return practiceFilter{}, false
+	}
+	filter.tense = tense
+	return filter, true
+}
+
+// tenses returns the tenses questions are drawn from.
+func (f practiceFilter) tenses() []spanish.TenseMood {
+	if f.tense >= 0 {
+		return []spanish.TenseMood{spanish.TenseMoodChoices[f.tense].Value}
+	}
+	tenses := make([]spanish.TenseMood, len(spanish.TenseMoodChoices))
+	for j, choice := range spanish.TenseMoodChoices {
+		tenses[j] = choice.Value
+	}
+	return tenses
+}
+
+// practiceCommand is the slash command that quizzes the user on conjugations.
+func practiceCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "practice",
+		Description: "Quizzes you on conjugations; your answers count toward your Study profile.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tense",
+				Description: "Tense to practice (default all).",
+				Choices:     getTenseMoodChoices(),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "list",
+				Description: "Your or the server's verb list, by name or share code, to practice (default all verbs).",
+				MaxLength:   listNameMaxLength,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "level",
+				Description: "Practice only verbs of this CEFR level or an easier one (default all).",
+				Choices:     levelChoices(),
+			},
+		},
+	}
+}
+
+// levelChoices offers every CEFR level of db.CEFRLevels.
+func levelChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(db.CEFRLevels))
+	for j, level := range db.CEFRLevels {
+		choices[j] = &discordgo.ApplicationCommandOptionChoice{Name: level, Value: level}
+	}
+	return choices
+}
+
+func (h *Handlers) handlePractice(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	sendInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, h.runPractice(ctx, i.Interaction))
+}
+
+// runPractice reads the options of a /practice interaction and returns its first question, or the message explaining
+// why there is none.
+func (h *Handlers) runPractice(ctx context.Context, interaction *discordgo.Interaction) *discordgo.InteractionResponseData {
+	if h.practice == nil {
+		return ephemeral(errPracticeUnavailable)
+	}
+
+	optionMap := makeOptionMap(interaction.ApplicationCommandData().Options)
+	filter := practiceFilter{tense: -1}
+	if opt, ok := optionMap["tense"]; ok {
+		choice, found := spanish.LookupChoice(opt.StringValue())
+		if !found {
+			return ephemeral(errTenseData)
+		}
+		filter.tense = choiceIndex(choice.Value)
+	}
+	if opt, ok := optionMap["list"]; ok {
+		if h.lists == nil {
+			return ephemeral(errListUnavailable)
+		}
+		l, err := h.findPracticeList(ctx, interaction, opt.StringValue())
+		if errors.Is(err, store.ErrListNotFound) {
+			return ephemeral(fmt.Sprintf(errPracticeNoList, opt.StringValue()))
+		}
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "reading verb list", "error", err)
+			return ephemeral(errListData)
+		}
+		filter.list = l.ShareCode
+	}
+	if opt, ok := optionMap["level"]; ok {
+		filter.level = strings.ToUpper(opt.StringValue())
+		if !slices.Contains(db.CEFRLevels, filter.level) {
+			return ephemeral(fmt.Sprintf(errPracticeLevel, opt.StringValue()))
+		}
+	}
+
+	data, errMessage := h.practiceQuestion(ctx, filter)
+	if errMessage != "" {
+		return ephemeral(errMessage)
+	}
+	return data
+}
+
+// findPracticeList finds the list /practice was given as ref: a personal list of the user by that name, else a list of
+// the guild by that name, else the list with the share code ref.
+func (h *Handlers) findPracticeList(ctx context.Context, interaction *discordgo.Interaction, ref string) (store.VerbList, error) {
+	owners := []store.ListOwner{{Scope: store.ScopeUser, ID: interactionUser(interaction).ID}}
+	if interaction.GuildID != "" {
+		owners = append(owners, store.ListOwner{Scope: store.ScopeGuild, ID: interaction.GuildID})
+	}
+	for _, owner := range owners {
+		l, err := h.lists.Get(ctx, owner, ref)
+		if !errors.Is(err, store.ErrListNotFound) {
+			return l, err
+		}
+	}
+	return h.lists.ByCode(ctx, ref)
+}
+
+// handlePracticeComponent handles the buttons of a practice question and the answer modal: "Answer" opens the modal,
+// submitting it grades and records the answer, and "Next" draws the next question.
+func (h *Handlers) handlePracticeComponent(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if h.practice == nil {
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errPracticeUnavailable)
+		return
+	}
+	logger := logging.FromContext(ctx)
+	var customID string
+	if i.Type == discordgo.InteractionModalSubmit {
+		customID = i.ModalSubmitData().CustomID
+	} else {
+		customID = i.MessageComponentData().CustomID
+	}
+	state, err := parsePracticeCustomID(customID)
+	if err != nil {
+		logger.WarnContext(ctx, "invalid component", "error", err)
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errPracticeData)
+		return
+	}
+
+	if state.action == practiceActionNext {
+		data, errMessage := h.practiceQuestion(ctx, state.filter)
+		if errMessage != "" {
+			sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errMessage)
+			return
+		}
+		sendUpdateMessageResponse(ctx, &DiscordSession{s}, i.Interaction, data)
+		return
+	}
+
+	q, err := h.service.QuestionFor(ctx, state.infinitive, state.tense, state.person)
+	if err != nil {
+		logger.ErrorContext(ctx, "reading practice question", "error", err)
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errPracticeData)
+		return
+	}
+	if i.Type != discordgo.InteractionModalSubmit {
+		respond(ctx, &DiscordSession{s}, i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseModal,
+			Data: practiceModal(customID, q),
+		})
+		return
+	}
+
+	answer := modalValue(i.ModalSubmitData().Components, practiceAnswerInputID)
+	grade := q.Grade(answer)
+	saved := true
+	user := interactionUser(i.Interaction)
+	err = h.practice.Record(ctx, store.PracticeResult{
+		UserID:     user.ID,
+		GuildID:    i.GuildID,
+		Infinitive: q.Infinitive,
+		Mood:       q.Tense.Mood,
+		Tense:      q.Tense.Tense,
+		Person:     q.Person,
+		Correct:    grade == core.GradeCorrect,
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "recording practice answer", "error", err)
+		saved = false
+	}
+	sendUpdateMessageResponse(ctx, &DiscordSession{s}, i.Interaction, &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{createPracticeResultEmbed(q, answer, grade, saved)},
+		Components: practiceComponents("Next", discordgo.SecondaryButton, practiceNextCustomID(state.filter)),
+	})
+}
+
+// practiceQuestion draws a question matching filter and renders it with its "Answer" button. When no question can be
+// drawn it returns the message to show the user instead.
+func (h *Handlers) practiceQuestion(ctx context.Context, filter practiceFilter) (*discordgo.InteractionResponseData, string) {
+	infinitives := h.service.Infinitives()
+	if filter.list != "" {
+		if h.lists == nil {
+			return nil, errListUnavailable
+		}
+		l, err := h.lists.ByCode(ctx, filter.list)
+		if errors.Is(err, store.ErrListNotFound) {
+			return nil, fmt.Sprintf(errListCodeNotFound, filter.list)
+		}
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "reading verb list", "error", err)
+			return nil, errListData
+		}
+		infinitives = l.Infinitives
+	}
+	if filter.level != "" {
+		infinitives = h.service.UpToLevel(infinitives, filter.level)
+		if len(infinitives) == 0 {
+			return nil, fmt.Sprintf(errPracticeNoLevel, filter.level)
+		}
+	}
+
+	q, err := h.service.Question(ctx, infinitives, filter.tenses(), rand.IntN)
+	if errors.Is(err, core.ErrNoQuestion) {
+		return nil, errPracticeNoQuestion
+	}
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "drawing practice question", "error", err)
+		return nil, errPracticeData
+	}
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{createPracticeEmbed(q)},
+		Components: practiceComponents("Answer", discordgo.PrimaryButton, practiceAnswerCustomID(filter, q)),
+		Flags:      discordgo.MessageFlagsEphemeral,
+	}, ""
+}
+
+// createPracticeEmbed asks for the form of q.
+func createPracticeEmbed(q core.Question) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Conjugate %s", q.Infinitive),
+		Description: fmt.Sprintf("%s · %s", spanish.TenseMoodName(q.Tense), bold(q.Label)),
+		Color:       embedColor,
+		Footer:      &discordgo.MessageEmbedFooter{Text: msgPracticeFooter},
+	}
+}
+
+// createPracticeResultEmbed shows the answer given to q next to its form. saved is false when the answer could not be
+// recorded.
+func createPracticeResultEmbed(q core.Question, answer string, grade core.Grade, saved bool) *discordgo.MessageEmbed {
+	embed := createPracticeEmbed(q)
+	verdict := fmt.Sprintf(msgPracticeWrong, bold(q.Answer))
+	switch grade {
+	case core.GradeCorrect:
+		verdict = fmt.Sprintf(msgPracticeCorrect, bold(q.Answer))
+	case core.GradeAccents:
+		verdict = fmt.Sprintf(msgPracticeAccents, bold(q.Answer))
+	}
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: "Your answer", Value: answer, Inline: true},
+		{Name: "Result", Value: verdict, Inline: true},
+	}
+	if !saved {
+		embed.Footer.Text = msgPracticeNotSaved
+	}
+	return embed
+}
+
+// practiceModal asks for the answer to q. Its custom ID is that of the "Answer" button that opened it.
+func practiceModal(customID string, q core.Question) *discordgo.InteractionResponseData {
+	return &discordgo.InteractionResponseData{
+		CustomID: customID,
+		Title:    fmt.Sprintf("Conjugate %s", q.Infinitive),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.TextInput{
+						CustomID:    practiceAnswerInputID,
+						Label:       q.Label,
+						Style:       discordgo.TextInputShort,
+						Placeholder: spanish.TenseMoodName(q.Tense),
+						Required:    true,
+						MaxLength:   practiceAnswerMaxLength,
+					},
+				},
+			},
+		},
+	}
+}
+
+// practiceComponents returns the single button of a practice message.
+func practiceComponents(label string, style discordgo.ButtonStyle, customID string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: label, Style: style, CustomID: customID},
+			},
+		},
+	}
+}
+
+// modalValue returns the value of the text input with the given custom ID among the submitted components of a modal.
+func modalValue(components []discordgo.MessageComponent, customID string) string {
+	for _, c := range components {
+		row, ok := c.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, rc := range row.Components {
+			if input, ok := rc.(*discordgo.TextInput); ok && input.CustomID == customID {
+				return input.Value
+			}
+		}
+	}
+	return ""
+}
+
+// practiceState is what a practice custom ID carries: the action, the filter of the session and, for an answer, the
+// question asked.
+type practiceState struct {
+	action     string
+	filter     practiceFilter
+	infinitive string
+	tense      spanish.TenseMood
+	person     string
+}
+
+// practiceAnswerCustomID encodes filter and q for the "Answer" button. The infinitive goes last, so the ID stays
+// parseable whatever it contains, and the tense is given by its choice index to stay within Discord's 100 characters.
+func practiceAnswerCustomID(filter practiceFilter, q core.Question) string {
+	return strings.Join([]string{
+		practiceCustomIDPrefix, practiceActionAnswer, filter.String(),
+		strconv.Itoa(choiceIndex(q.Tense)), q.Person, q.Infinitive,
+	}, customIDSeparator)
+}
+
+// practiceNextCustomID encodes filter for the "Next" button.
+func practiceNextCustomID(filter practiceFilter) string {
+	return strings.Join([]string{practiceCustomIDPrefix, practiceActionNext, filter.String()}, customIDSeparator)
+}
+
+// parsePracticeCustomID decodes an ID made by practiceAnswerCustomID or practiceNextCustomID.
+func parsePracticeCustomID(customID string) (practiceState, error) {
+	parts := strings.SplitN(customID, customIDSeparator, 6)
+	if len(parts) < 3 || parts[0] != practiceCustomIDPrefix {
+		return practiceState{}, fmt.Errorf(errPracticeCustomID, customID)
+	}
+	filter, ok := parsePracticeFilter(parts[2])
+	if !ok {
+		return practiceState{}, fmt.Errorf(errPracticeCustomID, customID)
+	}
+	state := practiceState{action: parts[1], filter: filter}
+
+	switch {
+	case state.action == practiceActionNext && len(parts) == 3:
+		return state, nil
+	case state.action == practiceActionAnswer && len(parts) == 6:
+		tense, err := strconv.Atoi(parts[3])
+		if err != nil || tense < 0 || tense >= len(spanish.TenseMoodChoices) || parts[4] == "" || parts[5] == "" {
+			break
+		}
+		state.tense, state.person, state.infinitive = spanish.TenseMoodChoices[tense].Value, parts[4], parts[5]
+		return state, nil
+	}
+	return practiceState{}, fmt.Errorf(errPracticeCustomID, customID)
+}
+
+// choiceIndex returns the index of the spanish.TenseMoodChoices entry offering tm, or -1.
+func choiceIndex(tm spanish.TenseMood) int {
+	for j, choice := range spanish.TenseMoodChoices {
+		if choice.Value == tm {
+			return j
+		}
+	}
+	return -1
+}
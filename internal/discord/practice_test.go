@@ -0,0 +1,260 @@
+package discord
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+func TestPracticeCommandRegistration(t *testing.T) {
+	h := NewHandlers(db.NewMemoryRepository(db.MemoryData{}), db.NewInfinitiveIndex(nil), HandlerOptions{})
+	for _, m := range NewCommandRegistry(h) {
+		if m.Command.Name != "practice" {
+			continue
+		}
+		if len(m.Command.Options) != 3 {
+			t.Fatalf("Options = %+v, want tense, list and level", m.Command.Options)
+		}
+		for _, opt := range m.Command.Options {
+			if opt.Required {
+				t.Errorf("Option %q is required, want it optional", opt.Name)
+			}
+		}
+		if _, ok := NewComponentRegistry(h)[practiceCustomIDPrefix]; !ok {
+			t.Errorf("Expected a component handler for %q", practiceCustomIDPrefix)
+		}
+		return
+	}
+	t.Error("Expected a practice command")
+}
+
+func TestPracticeCustomIDRoundTrip(t *testing.T) {
+	q := core.Question{
+		Infinitive: "hablar",
+		Tense:      spanish.TenseMood{Mood: "Subjuntivo", Tense: "Pluscuamperfecto"},
+		Person:     "3p",
+	}
+
+	tests := []struct {
+		name     string
+		customID string
+		want     practiceState
+	}{
+		{
+			name:     "answer, every tense",
+			customID: practiceAnswerCustomID(practiceFilter{tense: -1}, q),
+			want:     practiceState{action: practiceActionAnswer, filter: practiceFilter{tense: -1}, infinitive: "hablar", tense: q.Tense, person: "3p"},
+		},
+		{
+			name:     "answer, one tense",
+			customID: practiceAnswerCustomID(practiceFilter{tense: 14}, q),
+			want:     practiceState{action: practiceActionAnswer, filter: practiceFilter{tense: 14}, infinitive: "hablar", tense: q.Tense, person: "3p"},
+		},
+		{
+			name:     "answer, list",
+			customID: practiceAnswerCustomID(practiceFilter{tense: -1, list: "ABC234"}, q),
+			want:     practiceState{action: practiceActionAnswer, filter: practiceFilter{tense: -1, list: "ABC234"}, infinitive: "hablar", tense: q.Tense, person: "3p"},
+		},
+		{
+			name:     "next",
+			customID: practiceNextCustomID(practiceFilter{tense: 2}),
+			want:     practiceState{action: practiceActionNext, filter: practiceFilter{tense: 2}},
+		},
+		{
+			name:     "next, level",
+			customID: practiceNextCustomID(practiceFilter{tense: -1, level: "B1"}),
+			want:     practiceState{action: practiceActionNext, filter: practiceFilter{tense: -1, level: "B1"}},
+		},
+		{
+			name:     "answer, every filter",
+			customID: practiceAnswerCustomID(practiceFilter{tense: 14, list: "ABC234", level: "C2"}, q),
+			want:     practiceState{action: practiceActionAnswer, filter: practiceFilter{tense: 14, list: "ABC234", level: "C2"}, infinitive: "hablar", tense: q.Tense, person: "3p"},
+		},
+		{
+			name:     "next, tense and list",
+			customID: practiceNextCustomID(practiceFilter{tense: 17, list: "ABC234"}),
+			want:     practiceState{action: practiceActionNext, filter: practiceFilter{tense: 17, list: "ABC234"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.customID) > 100 {
+				t.Errorf("Custom ID %q is longer than 100 characters", tt.customID)
+			}
+			got, err := parsePracticeCustomID(tt.customID)
+			if err != nil {
+				t.Fatalf("parsePracticeCustomID(%q) returned an error: %v", tt.customID, err)
+			}
+			if got != tt.want {
+				t.Errorf("parsePracticeCustomID(%q) = %+v, want %+v", tt.customID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePracticeCustomID_Invalid(t *testing.T) {
+	for _, customID := range []string{
+		"examples:hablar:Present",
+		"practice",
+		"practice:next",
+		"practice:next:99",
+		"practice:next:x",
+		"practice:next:x.ABC234",
+		"practice:next:..Z9",
+		"practice:skip:",
+		"practice:answer::0:1s",
+		"practice:answer::99:1s:hablar",
+		"practice:answer::0::hablar",
+		"practice:answer::0:1s:",
+	} {
+		if _, err := parsePracticeCustomID(customID); err == nil {
+			t.Errorf("parsePracticeCustomID(%q) returned no error", customID)
+		}
+	}
+}
+
+func TestRunPractice(t *testing.T) {
+	ctx := context.Background()
+	sqlDB, err := store.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("Open() returned an error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	lists := store.NewListStore(sqlDB)
+	present := func(infinitive string, forms ...string) db.Verb {
+		ns := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+		return db.Verb{Infinitive: infinitive, Mood: "Indicativo", Tense: "Presente",
+			Form1s: ns(forms[0]), Form2s: ns(forms[1]), Form3s: ns(forms[2]), Form1p: ns(forms[3]), Form2p: ns(forms[4]), Form3p: ns(forms[5])}
+	}
+	infinitives := []db.Infinitive{{Infinitive: "ser"}, {Infinitive: "estar"}}
+	verbs := db.NewMemoryRepository(db.MemoryData{
+		Infinitives: infinitives,
+		Verbs: []db.Verb{
+			present("ser", "soy", "eres", "es", "somos", "sois", "son"),
+			present("estar", "estoy", "estás", "está", "estamos", "estáis", "están"),
+		},
+	})
+	index := db.NewInfinitiveIndex(infinitives).WithLevels(map[string]db.VerbLevel{"ser": {Rank: 1, Level: "A1"}, "estar": {Rank: 5, Level: "A2"}})
+	h := NewHandlers(verbs, index, HandlerOptions{Practice: store.NewPracticeStore(sqlDB), Lists: lists})
+	mine, err := lists.Create(ctx, store.ListOwner{Scope: store.ScopeUser, ID: "ana"}, "Irregulars", "ana", []string{"ser"})
+	if err != nil {
+		t.Fatalf("Create() returned an error: %v", err)
+	}
+	if _, err := lists.Create(ctx, store.ListOwner{Scope: store.ScopeGuild, ID: "g1"}, "Unit 1", "ben", []string{"estar"}); err != nil {
+		t.Fatalf("Create() returned an error: %v", err)
+	}
+
+	interaction := func(options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.Interaction {
+		return &discordgo.Interaction{
+			Type:    discordgo.InteractionApplicationCommand,
+			GuildID: "g1",
+			Member:  &discordgo.Member{User: &discordgo.User{ID: "ana"}},
+			Data:    discordgo.ApplicationCommandInteractionData{Name: "practice", Options: options},
+		}
+	}
+	tests := []struct {
+		name    string
+		h       *Handlers
+		options []*discordgo.ApplicationCommandInteractionDataOption
+		want    string
+	}{
+		{name: "own list by name", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("tense", "Present"), stringOpt("list", "irregulars")}, want: "Conjugate ser"},
+		{name: "server list by name", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("tense", "Present"), stringOpt("list", "Unit 1")}, want: "Conjugate estar"},
+		{name: "share code", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("tense", "Present"), stringOpt("list", strings.ToLower(mine.ShareCode))}, want: "Conjugate ser"},
+		{name: "unknown list", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("list", "Nope")}, want: fmt.Sprintf(errPracticeNoList, "Nope")},
+		{name: "level", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("tense", "Present"), stringOpt("level", "A1")}, want: "Conjugate ser"},
+		{name: "list above level", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("list", "Unit 1"), stringOpt("level", "A1")}, want: fmt.Sprintf(errPracticeNoLevel, "A1")},
+		{name: "unknown level", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("level", "Z9")}, want: fmt.Sprintf(errPracticeLevel, "Z9")},
+		{name: "no forms", h: h, options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("tense", "Preterite")}, want: errPracticeNoQuestion},
+		{name: "lists unavailable", h: NewHandlers(verbs, db.NewInfinitiveIndex(infinitives), HandlerOptions{Practice: store.NewPracticeStore(sqlDB)}), options: []*discordgo.ApplicationCommandInteractionDataOption{stringOpt("list", "Irregulars")}, want: errListUnavailable},
+		{name: "practice unavailable", h: NewHandlers(verbs, db.NewInfinitiveIndex(infinitives), HandlerOptions{}), want: errPracticeUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.h.runPractice(ctx, interaction(tt.options...))
+			got := data.Content
+			if len(data.Embeds) > 0 {
+				got = data.Embeds[0].Title
+			}
+			if got != tt.want {
+				t.Errorf("runPractice() = %q, want %q", got, tt.want)
+			}
+			if data.Flags&discordgo.MessageFlagsEphemeral == 0 {
+				t.Error("runPractice() answered publicly, want only to the user")
+			}
+		})
+	}
+}
+
+func TestPracticeFilterTenses(t *testing.T) {
+	if got := (practiceFilter{tense: -1}).tenses(); len(got) != len(spanish.TenseMoodChoices) {
+		t.Errorf("tenses() of every tense has %d tenses, want %d", len(got), len(spanish.TenseMoodChoices))
+	}
+	got := (practiceFilter{tense: 1}).tenses()
+	if len(got) != 1 || got[0] != spanish.TenseMoodChoices[1].Value {
+		t.Errorf("tenses() = %v, want %v", got, spanish.TenseMoodChoices[1].Value)
+	}
+}
+
+func TestCreatePracticeResultEmbed(t *testing.T) {
+	q := core.Question{
+		Infinitive: "tener",
+		Tense:      spanish.TenseMood{Mood: "Indicativo", Tense: "Pretérito"},
+		Person:     "1s",
+		Label:      "yo",
+		Answer:     "tuve",
+	}
+
+	tests := []struct {
+		name       string
+		grade      core.Grade
+		saved      bool
+		wantResult string
+		wantFooter string
+	}{
+		{name: "correct", grade: core.GradeCorrect, saved: true, wantResult: "Correct: **tuve**.", wantFooter: msgPracticeFooter},
+		{name: "accents", grade: core.GradeAccents, saved: true, wantResult: "Almost: mind the accents. It is **tuve**.", wantFooter: msgPracticeFooter},
+		{name: "wrong, not saved", grade: core.GradeWrong, wantResult: "No, it is **tuve**.", wantFooter: msgPracticeNotSaved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embed := createPracticeResultEmbed(q, "tube", tt.grade, tt.saved)
+			if embed.Title != "Conjugate tener" || !strings.Contains(embed.Description, "**yo**") {
+				t.Errorf("Embed asks %q / %q, want tener in the first person", embed.Title, embed.Description)
+			}
+			if len(embed.Fields) != 2 || embed.Fields[0].Value != "tube" || embed.Fields[1].Value != tt.wantResult {
+				t.Fatalf("Fields = %+v, want the answer and %q", embed.Fields, tt.wantResult)
+			}
+			if embed.Footer.Text != tt.wantFooter {
+				t.Errorf("Footer = %q, want %q", embed.Footer.Text, tt.wantFooter)
+			}
+		})
+	}
+}
+
+func TestModalValue(t *testing.T) {
+	components := []discordgo.MessageComponent{
+		&discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			&discordgo.TextInput{CustomID: "other", Value: "x"},
+			&discordgo.TextInput{CustomID: practiceAnswerInputID, Value: "tuve"},
+		}},
+	}
+	if got := modalValue(components, practiceAnswerInputID); got != "tuve" {
+		t.Errorf("modalValue() = %q, want %q", got, "tuve")
+	}
+	if got := modalValue(components, "missing"); got != "" {
+		t.Errorf("modalValue() of a missing input = %q, want empty", got)
+	}
+}
@@ -0,0 +1,109 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+const (
+	// profileCommandName is the name of the user command, shown under Apps in a member's context menu.
+	profileCommandName = "Study profile"
+
+	errProfileData        = "Error reading practice progress."
+	errProfileUnavailable = "Study profiles are unavailable."
+	msgProfileEmpty       = "%s has not practiced yet."
+	msgProfileNoWeak      = "Not enough answers per tense yet."
+	msgProfileStreak      = "%d days"
+	msgProfileStreak1     = "1 day"
+)
+
+// PracticeStats records the answers of /practice and reads the practice progress of users; store.PracticeStore does
+// both in the app database.
+type PracticeStats interface {
+	Record(ctx context.Context, result store.PracticeResult) error
+	Profile(ctx context.Context, userID string) (store.PracticeProfile, error)
+}
+
+// profileCommand is the user command that shows a member's practice progress.
+func profileCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{Type: discordgo.UserApplicationCommand, Name: profileCommandName}
+}
+
+func (h *Handlers) handleProfile(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if h.practice == nil {
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errProfileUnavailable)
+		return
+	}
+	data := i.ApplicationCommandData()
+	profile, err := h.practice.Profile(ctx, data.TargetID)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "reading practice profile", "error", err)
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, errProfileData)
+		return
+	}
+
+	name := targetName(data)
+	if profile.Answered == 0 {
+		sendEphemeralResponse(ctx, &DiscordSession{s}, i.Interaction, fmt.Sprintf(msgProfileEmpty, name))
+		return
+	}
+	sendInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{createProfileEmbed(name, profile)},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+}
+
+// targetName returns how the guild shows the target of a user command: the member's nickname, else the user's
+// display name, else the username.
+func targetName(data discordgo.ApplicationCommandInteractionData) string {
+	if data.Resolved == nil {
+		return "<@" + data.TargetID + ">"
+	}
+	if member := data.Resolved.Members[data.TargetID]; member != nil && member.Nick != "" {
+		return member.Nick
+	}
+	if user := data.Resolved.Users[data.TargetID]; user != nil {
+		if user.GlobalName != "" {
+			return user.GlobalName
+		}
+		return user.Username
+	}
+	return "<@" + data.TargetID + ">"
+}
+
+// createProfileEmbed shows the answers, accuracy, streak and last practice of a profile, and its weakest tenses.
+func createProfileEmbed(name string, p store.PracticeProfile) *discordgo.MessageEmbed {
+	streak := fmt.Sprintf(msgProfileStreak, p.Streak)
+	if p.Streak == 1 {
+		streak = msgProfileStreak1
+	}
+	overall := store.TenseStats{Answered: p.Answered, Correct: p.Correct}
+
+	weakest := msgProfileNoWeak
+	if len(p.WeakestTenses) > 0 {
+		lines := make([]string, len(p.WeakestTenses))
+		for j, t := range p.WeakestTenses {
+			name := spanish.TenseMoodName(spanish.TenseMood{Mood: t.Mood, Tense: t.Tense})
+			lines[j] = fmt.Sprintf("%s – %d/%d (%.0f%%)", name, t.Correct, t.Answered, 100*t.Accuracy())
+		}
+		weakest = strings.Join(lines, "\n")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("%s - %s", profileCommandName, name),
+		Color: embedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Answers", Value: fmt.Sprintf("%d", p.Answered), Inline: true},
+			{Name: "Accuracy", Value: fmt.Sprintf("%.0f%%", 100*overall.Accuracy()), Inline: true},
+			{Name: "Streak", Value: streak, Inline: true},
+			{Name: "Last practiced", Value: fmt.Sprintf("<t:%d:R>", p.LastPracticed.Unix())},
+			{Name: "Weakest tenses", Value: weakest},
+		},
+	}
+}
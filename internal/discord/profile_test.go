@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/store"
+)
+
+func TestProfileCommandRegistration(t *testing.T) {
+	h := NewHandlers(db.NewMemoryRepository(db.MemoryData{}), db.NewInfinitiveIndex(nil), HandlerOptions{})
+	for _, m := range NewCommandRegistry(h) {
+		if m.Command.Name != profileCommandName {
+			continue
+		}
+		if m.Command.Type != discordgo.UserApplicationCommand || m.Command.Description != "" {
+			t.Errorf("Command = %+v, want a user command without a description", m.Command)
+		}
+		return
+	}
+	t.Errorf("Expected a %q user command", profileCommandName)
+}
+
+func TestCreateProfileEmbed(t *testing.T) {
+	profile := store.PracticeProfile{
+		Answered:      8,
+		Correct:       6,
+		Streak:        1,
+		LastPracticed: time.Unix(1700000000, 0),
+		WeakestTenses: []store.TenseStats{{Mood: "Subjuntivo", Tense: "Imperfecto", Answered: 3, Correct: 1}},
+	}
+
+	embed := createProfileEmbed("Ana", profile)
+
+	want := map[string]string{
+		"Answers":        "8",
+		"Accuracy":       "75%",
+		"Streak":         "1 day",
+		"Last practiced": "<t:1700000000:R>",
+		"Weakest tenses": "Imperfect subjunctive – 1/3 (33%)",
+	}
+	for _, f := range embed.Fields {
+		if f.Value != want[f.Name] {
+			t.Errorf("Field %q = %q, want %q", f.Name, f.Value, want[f.Name])
+		}
+	}
+	if embed.Title != "Study profile - Ana" {
+		t.Errorf("Title = %q, want the member's name", embed.Title)
+	}
+
+	embed = createProfileEmbed("Ana", store.PracticeProfile{Answered: 1, Streak: 4})
+	if got := embed.Fields[2].Value; got != "4 days" {
+		t.Errorf("Streak = %q, want %q", got, "4 days")
+	}
+	if got := embed.Fields[4].Value; got != msgProfileNoWeak {
+		t.Errorf("Weakest tenses = %q, want %q", got, msgProfileNoWeak)
+	}
+}
+
+func TestTargetName(t *testing.T) {
+	resolved := &discordgo.ApplicationCommandInteractionDataResolved{
+		Users: map[string]*discordgo.User{
+			"1": {ID: "1", Username: "ana_b", GlobalName: "Ana"},
+			"2": {ID: "2", Username: "ben"},
+		},
+		Members: map[string]*discordgo.Member{"1": {Nick: "Profe Ana"}},
+	}
+	tests := []struct {
+		target   string
+		resolved *discordgo.ApplicationCommandInteractionDataResolved
+		want     string
+	}{
+		{"1", resolved, "Profe Ana"},
+		{"2", resolved, "ben"},
+		{"3", nil, "<@3>"},
+	}
+
+	for _, tt := range tests {
+		data := discordgo.ApplicationCommandInteractionData{TargetID: tt.target, Resolved: tt.resolved}
+		if got := targetName(data); got != tt.want {
+			t.Errorf("targetName(%s) = %q, want %q", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestInteractionAttrsTarget(t *testing.T) {
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: profileCommandName, TargetID: "42"},
+	}}
+
+	for _, attr := range interactionAttrs(i) {
+		if a := attr.(slog.Attr); a.Key == "target_id" && a.Value.String() == "42" {
+			return
+		}
+	}
+	t.Error("Expected a target_id attribute")
+}
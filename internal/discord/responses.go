@@ -1,59 +1,108 @@
 package discord
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
 	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
 )
 
-// createConjugationEmbed generates a Discord embed message for a verb's conjugation
-func createConjugationEmbed(infinitive string, verb *db.Verb) *discordgo.MessageEmbed {
+// embedColor is the color of every embed the bot sends.
+const embedColor = 16711807
+
+// EmbedRenderer renders core results as Discord embeds.
+type EmbedRenderer struct{}
+
+var _ core.Renderer[*discordgo.MessageEmbed] = EmbedRenderer{}
+
+// Conjugation shows the tense, the mood and one inline field per person, followed by the examples.
+func (EmbedRenderer) Conjugation(r core.ConjugationResult) *discordgo.MessageEmbed {
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Tiempo", Value: r.Tense},
+		{Name: "Modo", Value: r.Mood},
+	}
+	if r.Level != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Nivel", Value: fmt.Sprintf("%s · #%d", r.Level, r.Rank)})
+	}
+	for _, f := range r.Forms {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: f.Label, Value: f.Form, Inline: true})
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:  fmt.Sprintf("%s - %s", r.Infinitive, r.English),
+		Color:  embedColor,
+		Fields: fields,
+	}
+	addExamplesField(embed, r.Examples)
+	return embed
+}
+
+// Imperative shows the affirmative and negative command of each person side by side.
+func (EmbedRenderer) Imperative(r core.ImperativeResult) *discordgo.MessageEmbed {
+	return createImperativeEmbed(r.Infinitive, r.English, r.Rows)
+}
+
+// Examples lists the examples in the description.
+func (EmbedRenderer) Examples(r core.ExamplesResult) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{
-		Title: fmt.Sprintf("%s - %s", infinitive, db.NullStringToString(verb.VerbEnglish)),
-		Color: 16711807,
-		Fields: []*discordgo.MessageEmbedField{
-			{Name: "Tiempo", Value: verb.Tense},
-			{Name: "Modo", Value: verb.Mood},
-			{Name: "yo", Value: db.NullStringToString(verb.Form1s), Inline: true},
-			{Name: "tú", Value: db.NullStringToString(verb.Form2s), Inline: true},
-			{Name: "él/ella/Ud.", Value: db.NullStringToString(verb.Form3s), Inline: true},
-			{Name: "nosotros", Value: db.NullStringToString(verb.Form1p), Inline: true},
-			{Name: "vosotros", Value: db.NullStringToString(verb.Form2p), Inline: true},
-			{Name: "ellos/ellas/Uds.", Value: db.NullStringToString(verb.Form3p), Inline: true},
-		},
+		Title:       fmt.Sprintf("%s - %s", r.Infinitive, r.TenseName),
+		Color:       embedColor,
+		Description: formatExamples(r.Examples),
 	}
 }
 
+// createConjugationEmbed generates a Discord embed message for a verb's conjugation
+func createConjugationEmbed(infinitive string, verb *db.Verb) *discordgo.MessageEmbed {
+	result := core.NewConjugationResult(*verb)
+	result.Infinitive = infinitive
+	return EmbedRenderer{}.Conjugation(result)
+}
+
 // InteractionResponder defines an interface for sending interaction responses
 type InteractionResponder interface {
 	InteractionRespond(interaction *discordgo.Interaction, response *discordgo.InteractionResponse) error
 }
 
 // sendInteractionResponse sends a response to the interaction with the provided data
-func sendInteractionResponse(responder InteractionResponder, interaction *discordgo.Interaction, responseData *discordgo.InteractionResponseData) {
-	response := &discordgo.InteractionResponse{
+func sendInteractionResponse(ctx context.Context, responder InteractionResponder, interaction *discordgo.Interaction, responseData *discordgo.InteractionResponseData) {
+	respond(ctx, responder, interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: responseData,
-	}
+	})
+}
+
+// sendUpdateMessageResponse replaces the message a component belongs to with the provided data
+func sendUpdateMessageResponse(ctx context.Context, responder InteractionResponder, interaction *discordgo.Interaction, responseData *discordgo.InteractionResponseData) {
+	respond(ctx, responder, interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: responseData,
+	})
+}
 
+// respond sends response, logging and counting a failure
+func respond(ctx context.Context, responder InteractionResponder, interaction *discordgo.Interaction, response *discordgo.InteractionResponse) {
 	if err := responder.InteractionRespond(interaction, response); err != nil {
-		fmt.Printf("Error sending response: %v\n", err)
+		metrics.ResponseFailed()
+		logging.FromContext(ctx).ErrorContext(ctx, "sending interaction response", "error", err)
 	}
 }
 
-// sendConjugationResponse sends a response with the provided embed message
-func sendConjugationResponse(responder InteractionResponder, interaction *discordgo.Interaction, embed *discordgo.MessageEmbed) {
+// sendConjugationResponse sends a response with the provided embed message and optional message components
+func sendConjugationResponse(ctx context.Context, responder InteractionResponder, interaction *discordgo.Interaction, embed *discordgo.MessageEmbed, components ...discordgo.MessageComponent) {
 	responseData := &discordgo.InteractionResponseData{
-		Embeds: []*discordgo.MessageEmbed{embed},
+		Embeds:     []*discordgo.MessageEmbed{embed},
+		Components: components,
 	}
-	sendInteractionResponse(responder, interaction, responseData)
+	sendInteractionResponse(ctx, responder, interaction, responseData)
 }
 
 // sendErrorInteractionResponse sends an error message as a response to a Discord interaction
-func sendErrorInteractionResponse(responder InteractionResponder, interaction *discordgo.Interaction, errorMessage string) {
+func sendErrorInteractionResponse(ctx context.Context, responder InteractionResponder, interaction *discordgo.Interaction, errorMessage string) {
 	responseData := &discordgo.InteractionResponseData{
 		Content: errorMessage,
 	}
-	sendInteractionResponse(responder, interaction, responseData)
+	sendInteractionResponse(ctx, responder, interaction, responseData)
 }
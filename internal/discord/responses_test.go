@@ -1,11 +1,13 @@
 package discord
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"testing"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
 	"github.com/felipeantoniob/conjugador-bot/internal/db"
 )
 
@@ -56,6 +58,19 @@ func TestCreateConjugationEmbed(t *testing.T) {
 	}
 }
 
+func TestConjugationEmbedLevel(t *testing.T) {
+	result := core.ConjugationResult{Infinitive: "hablar", Tense: "Presente", Mood: "Indicativo"}
+	if embed := (EmbedRenderer{}).Conjugation(result); len(embed.Fields) != 2 {
+		t.Errorf("Expected no level field for an unranked verb, got %d fields", len(embed.Fields))
+	}
+
+	result.Level, result.Rank = "A2", 57
+	embed := EmbedRenderer{}.Conjugation(result)
+	if len(embed.Fields) != 3 || embed.Fields[2].Name != "Nivel" || embed.Fields[2].Value != "A2 · #57" {
+		t.Errorf("Expected a Nivel field with A2 · #57, got %+v", embed.Fields)
+	}
+}
+
 // Mock responder
 type mockResponder struct {
 	shouldFail bool
@@ -74,11 +89,11 @@ func TestSendInteractionResponse(t *testing.T) {
 	responseData := &discordgo.InteractionResponseData{}
 
 	// Test successful response
-	sendInteractionResponse(responder, interaction, responseData)
+	sendInteractionResponse(context.Background(), responder, interaction, responseData)
 
 	// Test error case
 	responder.shouldFail = true
-	sendInteractionResponse(responder, interaction, responseData)
+	sendInteractionResponse(context.Background(), responder, interaction, responseData)
 }
 
 func TestSendConjugationResponse(t *testing.T) {
@@ -88,7 +103,7 @@ func TestSendConjugationResponse(t *testing.T) {
 		Title: "Test Embed",
 	}
 
-	sendConjugationResponse(responder, interaction, embed)
+	sendConjugationResponse(context.Background(), responder, interaction, embed)
 
 	// Check that the response data contains the embed
 	// Note: This is a simplified test; more detailed checks can be added based on how sendInteractionResponse processes the response
@@ -99,7 +114,7 @@ func TestSendErrorInteractionResponse(t *testing.T) {
 	interaction := &discordgo.Interaction{}
 	errorMessage := "An error occurred"
 
-	sendErrorInteractionResponse(responder, interaction, errorMessage)
+	sendErrorInteractionResponse(context.Background(), responder, interaction, errorMessage)
 
 	// Check that the response data contains the error message
 	// Note: This is a simplified test; more detailed checks can be added based on how sendInteractionResponse processes the response
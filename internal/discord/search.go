@@ -0,0 +1,152 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+)
+
+const (
+	searchCustomIDPrefix = "search"
+
+	// searchPageSize is the number of results on one page of the search embed.
+	searchPageSize = 8
+	// searchQueryMaxLength keeps the query short enough to fit in a button's custom ID, which Discord caps at 100
+	// characters.
+	searchQueryMaxLength = 80
+
+	errSearchQueryMissing = "Search query not provided."
+	errSearchEmpty        = "Search for at least one word."
+	errSearchFailed       = "Error searching verbs."
+	errSearchNoResults    = "No verbs found for %q."
+	errSearchCustomID     = "malformed search custom ID %q"
+)
+
+func (h *Handlers) handleSearch(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	optionMap := makeOptionMap(i.ApplicationCommandData().Options)
+	opt, exists := optionMap["query"]
+	if !exists {
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errSearchQueryMissing)
+		return
+	}
+
+	data, errMessage := h.searchPage(ctx, opt.StringValue(), 0)
+	if errMessage != "" {
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errMessage)
+		return
+	}
+	sendInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, data)
+}
+
+func (h *Handlers) handleSearchPage(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	page, query, err := parseSearchCustomID(i.MessageComponentData().CustomID)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "invalid component", "error", err)
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errSearchFailed)
+		return
+	}
+
+	data, errMessage := h.searchPage(ctx, query, page)
+	if errMessage != "" {
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errMessage)
+		return
+	}
+	sendUpdateMessageResponse(ctx, &DiscordSession{s}, i.Interaction, data)
+}
+
+// searchPage runs the search and renders the requested zero-based page. When the search cannot be shown it returns
+// the message to show the user instead.
+func (h *Handlers) searchPage(ctx context.Context, query string, page int) (*discordgo.InteractionResponseData, string) {
+	results, err := h.search.Search(ctx, query, searchPageSize, page*searchPageSize)
+	if err != nil {
+		if errors.Is(err, db.ErrEmptySearch) {
+			return nil, errSearchEmpty
+		}
+		logging.FromContext(ctx).ErrorContext(ctx, "searching verbs", "error", err)
+		return nil, errSearchFailed
+	}
+	if results.Total == 0 {
+		return nil, fmt.Sprintf(errSearchNoResults, query)
+	}
+
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{createSearchEmbed(query, page, results)},
+		Components: searchPageComponents(query, page, pageCount(results.Total)),
+	}, ""
+}
+
+// createSearchEmbed lists one page of search results with the matching part of each verb.
+func createSearchEmbed(query string, page int, results db.SearchPage) *discordgo.MessageEmbed {
+	lines := make([]string, len(results.Results))
+	for n, r := range results.Results {
+		lines[n] = fmt.Sprintf("**%d. %s**", page*searchPageSize+n+1, r.Infinitive)
+		if english := db.NullStringToString(r.InfinitiveEnglish); english != "" {
+			lines[n] += " — " + english
+		}
+		if r.Snippet != "" {
+			lines[n] += "\n↳ " + r.Snippet
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Búsqueda: %s", query),
+		Color:       embedColor,
+		Description: strings.Join(lines, "\n"),
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d of %d · %d results", page+1, pageCount(results.Total), results.Total),
+		},
+	}
+}
+
+// searchPageComponents returns the Previous and Next buttons of the search embed, or nothing when there is one page.
+func searchPageComponents(query string, page, pages int) []discordgo.MessageComponent {
+	if pages <= 1 {
+		return nil
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					CustomID: searchCustomID(query, page-1),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					CustomID: searchCustomID(query, page+1),
+					Disabled: page >= pages-1,
+				},
+			},
+		},
+	}
+}
+
+func pageCount(total int) int {
+	return (total + searchPageSize - 1) / searchPageSize
+}
+
+// searchCustomID encodes the page a button leads to and the query, which goes last since it may contain the separator.
+func searchCustomID(query string, page int) string {
+	return strings.Join([]string{searchCustomIDPrefix, strconv.Itoa(page), query}, customIDSeparator)
+}
+
+// parseSearchCustomID extracts the page and query from a search pagination button ID.
+func parseSearchCustomID(customID string) (page int, query string, err error) {
+	parts := strings.SplitN(customID, customIDSeparator, 3)
+	if len(parts) != 3 || parts[0] != searchCustomIDPrefix || parts[2] == "" {
+		return 0, "", fmt.Errorf(errSearchCustomID, customID)
+	}
+	page, err = strconv.Atoi(parts[1])
+	if err != nil || page < 0 {
+		return 0, "", fmt.Errorf(errSearchCustomID, customID)
+	}
+	return page, parts[2], nil
+}
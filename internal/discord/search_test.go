@@ -0,0 +1,99 @@
+package discord
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+type stubSearcher struct{}
+
+func (stubSearcher) Search(ctx context.Context, query string, limit, offset int) (db.SearchPage, error) {
+	return db.SearchPage{}, nil
+}
+
+func TestSearchCommandRegistration(t *testing.T) {
+	hasSearch := func(h *Handlers) bool {
+		for _, m := range NewCommandRegistry(h) {
+			if m.Command.Name == "search" {
+				return true
+			}
+		}
+		return false
+	}
+
+	verbs := db.NewMemoryRepository(db.MemoryData{})
+	if hasSearch(NewHandlers(verbs, db.NewInfinitiveIndex(nil), HandlerOptions{})) {
+		t.Error("Expected no /search command without a searcher")
+	}
+	if !hasSearch(NewHandlers(verbs, db.NewInfinitiveIndex(nil), HandlerOptions{Search: stubSearcher{}})) {
+		t.Error("Expected a /search command with a searcher")
+	}
+}
+
+func TestSearchCustomID(t *testing.T) {
+	customID := searchCustomID("to be: or not", 3)
+
+	page, query, err := parseSearchCustomID(customID)
+	if err != nil {
+		t.Fatalf("parseSearchCustomID(%q) returned an error: %v", customID, err)
+	}
+	if page != 3 || query != "to be: or not" {
+		t.Errorf("parseSearchCustomID(%q) = %d, %q", customID, page, query)
+	}
+
+	for _, invalid := range []string{"search", "search:1:", "search:x:hablar", "search:-1:hablar", "examples:1:hablar"} {
+		if _, _, err := parseSearchCustomID(invalid); err == nil {
+			t.Errorf("parseSearchCustomID(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestSearchPageComponents(t *testing.T) {
+	if components := searchPageComponents("hablar", 0, 1); components != nil {
+		t.Errorf("Expected no buttons for a single page, got %v", components)
+	}
+
+	tests := []struct {
+		page             int
+		wantPrevDisabled bool
+		wantNextDisabled bool
+	}{
+		{0, true, false},
+		{1, false, false},
+		{2, false, true},
+	}
+
+	for _, tt := range tests {
+		row := searchPageComponents("hablar", tt.page, 3)[0].(discordgo.ActionsRow)
+		prev, next := row.Components[0].(discordgo.Button), row.Components[1].(discordgo.Button)
+		if prev.Disabled != tt.wantPrevDisabled || next.Disabled != tt.wantNextDisabled {
+			t.Errorf("page %d: Previous disabled = %v, Next disabled = %v", tt.page, prev.Disabled, next.Disabled)
+		}
+		if next.CustomID != searchCustomID("hablar", tt.page+1) {
+			t.Errorf("page %d: Next custom ID = %q", tt.page, next.CustomID)
+		}
+	}
+}
+
+func TestCreateSearchEmbed(t *testing.T) {
+	results := db.SearchPage{
+		Total: 9,
+		Results: []db.SearchResult{
+			{Infinitive: "oír", InfinitiveEnglish: sql.NullString{String: "to hear", Valid: true}, Snippet: "…**oigo** | oímos…"},
+		},
+	}
+
+	embed := createSearchEmbed("oigo", 1, results)
+
+	if want := "**9. oír** — to hear\n↳ …**oigo** | oímos…"; embed.Description != want {
+		t.Errorf("Description = %q, want %q", embed.Description, want)
+	}
+	if !strings.HasPrefix(embed.Footer.Text, "Page 2 of 2") {
+		t.Errorf("Footer = %q, want it to start with %q", embed.Footer.Text, "Page 2 of 2")
+	}
+}
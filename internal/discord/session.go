@@ -2,16 +2,16 @@ package discord
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 const (
 	errBotInit          = "error initializing bot"
-	errClosingSession   = "Error closing Discord session: %v"
 	errDiscordWSOpen    = "error opening websocket connection to Discord"
 	errRegisterCommands = "failed to register commands"
+	errApplicationID    = "error looking up the bot's application"
 )
 
 // Session represents a Discord session and provides methods for interacting with it.
@@ -81,6 +81,20 @@ func (s *DiscordSession) InteractionRespond(interaction *discordgo.Interaction,
 	return s.Session.InteractionRespond(interaction, response)
 }
 
+// NewCommandRegistrar creates a session that manages application commands over the REST API, without opening a
+// gateway connection, and returns it with the ID of the bot's application.
+func NewCommandRegistrar(token string) (CommandRegistrar, string, error) {
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", errBotInit, err)
+	}
+	user, err := session.User("@me")
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", errApplicationID, err)
+	}
+	return session, user.ID, nil
+}
+
 // CreateSession initializes a new Discord session with the provided factory and token.
 func CreateSession(factory SessionFactory, token string) (Session, error) {
 	session, err := createAndConfigureSession(factory, token)
@@ -117,6 +131,6 @@ func openSession(session Session) error {
 // CloseSession gracefully closes the given Discord session and logs any error.
 func CloseSession(s Session) {
 	if err := s.Close(); err != nil {
-		log.Printf(errClosingSession, err)
+		slog.Error("closing Discord session", "error", err)
 	}
 }
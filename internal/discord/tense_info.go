@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/tenseinfo"
+)
+
+const (
+	// tenseInfoDefaultVerb is the verb the guide conjugates when the user names none.
+	tenseInfoDefaultVerb = "hablar"
+
+	errTenseMissing = "Tense not provided."
+)
+
+// tenseInfoCommand is the /tense-info command, which explains a tense.
+func tenseInfoCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        "tense-info",
+		Description: "Explains how a tense is formed and when to use it.",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tense",
+				Description: "Tense to explain.",
+				Required:    true,
+				Choices:     getTenseMoodChoices(),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "verb",
+				Description: "Verb to conjugate in the tense; hablar by default.",
+			},
+		},
+	}
+}
+
+func (h *Handlers) handleTenseInfo(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	optionMap := makeOptionMap(i.ApplicationCommandData().Options)
+	opt, exists := optionMap["tense"]
+	if !exists {
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errTenseMissing)
+		return
+	}
+	guide, ok := h.guides.Lookup(opt.StringValue())
+	if !ok {
+		sendErrorInteractionResponse(ctx, &DiscordSession{s}, i.Interaction, errTenseData)
+		return
+	}
+
+	verb := tenseInfoDefaultVerb
+	if opt, exists := optionMap["verb"]; exists && strings.TrimSpace(opt.StringValue()) != "" {
+		verb = opt.StringValue()
+	}
+	result, err := h.service.Conjugate(ctx, verb, guide.Choice.Name, 0)
+	if err != nil {
+		respondLookupError(ctx, s, i, verb, err)
+		return
+	}
+	sendConjugationResponse(ctx, &DiscordSession{s}, i.Interaction, createTenseInfoEmbed(guide, result))
+}
+
+// createTenseInfoEmbed shows the mood and tense of a guide and its English name, then its formation, usage, signal
+// words and examples, and the conjugation of a verb in the tense.
+func createTenseInfoEmbed(guide tenseinfo.Guide, result core.ConjugationResult) *discordgo.MessageEmbed {
+	description := guide.Choice.Value.Mood + " " + guide.Choice.Value.Tense
+	if guide.English != "" {
+		description += " · " + guide.English
+	}
+
+	usage := make([]string, len(guide.Usage))
+	for j, u := range guide.Usage {
+		usage[j] = "• " + u
+	}
+	examples := make([]core.Example, len(guide.Examples))
+	for j, ex := range guide.Examples {
+		examples[j] = core.Example{Sentence: ex.Spanish, English: ex.English}
+	}
+
+	forms := make([]string, len(result.Forms))
+	for j, f := range result.Forms {
+		forms[j] = fmt.Sprintf("%s: %s", f.Label, f.Form)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       guide.Choice.Name,
+		Description: description,
+		Color:       embedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Formación", Value: guide.Formation},
+			{Name: "Uso", Value: strings.Join(usage, "\n")},
+			{Name: "Señales", Value: strings.Join(guide.SignalWords, ", ")},
+			{Name: "Ejemplos", Value: formatExamples(examples)},
+			{Name: fmt.Sprintf("%s - %s", result.Infinitive, result.English), Value: strings.Join(forms, "\n")},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"github.com/felipeantoniob/conjugador-bot/internal/tenseinfo"
+)
+
+func TestTenseInfoRegisteredWithGuides(t *testing.T) {
+	hasTenseInfo := func(h *Handlers) bool {
+		for _, m := range NewCommandRegistry(h) {
+			if m.Command.Name == "tense-info" {
+				return true
+			}
+		}
+		return false
+	}
+	guides, err := tenseinfo.Load(spanish.TenseMoodChoices, nil)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+
+	verbs := db.NewMemoryRepository(db.MemoryData{})
+	if hasTenseInfo(NewHandlers(verbs, db.NewInfinitiveIndex(nil), HandlerOptions{})) {
+		t.Error("Expected no /tense-info command without guides")
+	}
+	if !hasTenseInfo(NewHandlers(verbs, db.NewInfinitiveIndex(nil), HandlerOptions{Guides: guides})) {
+		t.Error("Expected a /tense-info command with guides")
+	}
+}
+
+func TestCreateTenseInfoEmbed(t *testing.T) {
+	moodTenses := make(map[spanish.TenseMood]string)
+	for _, c := range spanish.TenseMoodChoices {
+		moodTenses[c.Value] = ""
+	}
+	moodTenses[spanish.TenseMood{Mood: "Indicativo", Tense: "Pluscuamperfecto"}] = "Past Perfect"
+	guides, err := tenseinfo.Load(spanish.TenseMoodChoices, moodTenses)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+	result := core.ConjugationResult{
+		Infinitive: "hablar",
+		English:    "to speak",
+		Forms:      []core.Form{{Label: "yo", Form: "había hablado"}},
+	}
+
+	// Every guide must fit Discord's embed limits, with the conjugation of a verb added.
+	for _, c := range spanish.TenseMoodChoices {
+		guide, ok := guides.Lookup(c.Name)
+		if !ok {
+			t.Fatalf("Lookup(%q) found no guide", c.Name)
+		}
+		embed := createTenseInfoEmbed(guide, result)
+		if embed.Title != c.Name || len(embed.Fields) != 5 {
+			t.Errorf("%s: title %q with %d fields, want the choice name and 5 fields", c.Name, embed.Title, len(embed.Fields))
+		}
+		for _, f := range embed.Fields {
+			if f.Value == "" || len(f.Value) > 1024 {
+				t.Errorf("%s: field %q has %d characters, want 1 to 1024", c.Name, f.Name, len(f.Value))
+			}
+		}
+	}
+
+	guide, _ := guides.Lookup("Pluperfect (Past perfect)")
+	embed := createTenseInfoEmbed(guide, result)
+	if !strings.HasSuffix(embed.Description, "Past Perfect") {
+		t.Errorf("Description = %q, want the English name of the tense", embed.Description)
+	}
+}
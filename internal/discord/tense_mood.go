@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
 )
 
 // TenseMood represents a grammatical mood and tense.
@@ -22,27 +23,15 @@ const (
 	errTenseNameNotFound = "Tense name not found"
 )
 
-// tenseMoodChoices holds the available tense mood choices.
-var tenseMoodChoices = []TenseMoodChoice{
-	{"Present", TenseMood{"Indicativo", "Presente"}},
-	{"Preterite", TenseMood{"Indicativo", "Pretérito"}},
-	{"Imperfect", TenseMood{"Indicativo", "Imperfecto"}},
-	{"Conditional", TenseMood{"Indicativo", "Condicional"}},
-	{"Future", TenseMood{"Indicativo", "Futuro"}},
-	{"Present perfect", TenseMood{"Indicativo", "Presente"}},
-	{"Preterite perfect (Past anterior)", TenseMood{"Indicativo", "Pretérito anterior"}},
-	{"Pluperfect (Past perfect)", TenseMood{"Indicativo", "Pluscuamperfecto"}},
-	{"Conditional perfect", TenseMood{"Indicativo", "Condicional perfecto"}},
-	{"Future perfect", TenseMood{"Indicativo", "Futuro perfecto"}},
-	{"Present subjunctive", TenseMood{"Subjuntivo", "Presente"}},
-	{"Imperfect subjunctive", TenseMood{"Subjuntivo", "Imperfecto"}},
-	{"Future subjunctive", TenseMood{"Subjuntivo", "Futuro"}},
-	{"Present perfect subjunctive", TenseMood{"Subjuntivo", "Presente perfecto"}},
-	{"Pluperfect (Past perfect) subjunctive", TenseMood{"Subjuntivo", "Pluscuamperfecto"}},
-	{"Future perfect subjunctive", TenseMood{"Subjuntivo", "Pretérito anterior"}},
-	{"Imperative", TenseMood{"Imperativo Afirmativo", "Presente"}},
-	{"Negative Imperative", TenseMood{"Imperativo Negativo", "Presente"}},
-}
+// tenseMoodChoices holds the available tense mood choices, shared with the other front ends through
+// spanish.TenseMoodChoices.
+var tenseMoodChoices = func() []TenseMoodChoice {
+	choices := make([]TenseMoodChoice, len(spanish.TenseMoodChoices))
+	for i, choice := range spanish.TenseMoodChoices {
+		choices[i] = TenseMoodChoice{Name: choice.Name, Value: TenseMood(choice.Value)}
+	}
+	return choices
+}()
 
 // tenseMoodMap provides a quick lookup for tense moods by name.
 var tenseMoodMap = createTenseMoodMap()
@@ -11,7 +11,7 @@ func TestCreateTenseMoodMap(t *testing.T) {
 		"Imperfect":                             {"Indicativo", "Imperfecto"},
 		"Conditional":                           {"Indicativo", "Condicional"},
 		"Future":                                {"Indicativo", "Futuro"},
-		"Present perfect":                       {"Indicativo", "Presente"},
+		"Present perfect":                       {"Indicativo", "Presente perfecto"},
 		"Preterite perfect (Past anterior)":     {"Indicativo", "Pretérito anterior"},
 		"Pluperfect (Past perfect)":             {"Indicativo", "Pluscuamperfecto"},
 		"Conditional perfect":                   {"Indicativo", "Condicional perfecto"},
@@ -21,7 +21,7 @@ func TestCreateTenseMoodMap(t *testing.T) {
 		"Future subjunctive":                    {"Subjuntivo", "Futuro"},
 		"Present perfect subjunctive":           {"Subjuntivo", "Presente perfecto"},
 		"Pluperfect (Past perfect) subjunctive": {"Subjuntivo", "Pluscuamperfecto"},
-		"Future perfect subjunctive":            {"Subjuntivo", "Pretérito anterior"},
+		"Future perfect subjunctive":            {"Subjuntivo", "Futuro perfecto"},
 		"Imperative":                            {"Imperativo Afirmativo", "Presente"},
 		"Negative Imperative":                   {"Imperativo Negativo", "Presente"},
 	}
@@ -1,20 +1,21 @@
 package env
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
 
 	"github.com/joho/godotenv"
 )
 
 const (
-	defaultEnvFilePath = ".env.local"
-
-	botTokenKey = "BOT_TOKEN"
-	guildIDKey  = "GUILD_ID"
+	// DefaultEnvFile is the file LoadEnv loads first.
+	DefaultEnvFile = ".env"
+	// LegacyEnvFile is the file the bot loaded before DefaultEnvFile. LoadEnv still loads it after DefaultEnvFile, so
+	// existing deployments keep working; a variable set in both comes from DefaultEnvFile.
+	LegacyEnvFile = ".env.local"
 
 	errLoadEnvFile = "failed to load environment file %s: %w"
-	errMissingVars = "required environment variables are missing: %s"
 )
 
 // EnvLoader is an interface that abstracts the loading of environment variables.
@@ -29,36 +30,22 @@ func (l *GodotenvLoader) Load(filePath string) error {
 	return godotenv.Load(filePath)
 }
 
-// LoadEnv loads environment variables from the default file path using the provided EnvLoader.
+// LoadEnv loads environment variables from DefaultEnvFile and then LegacyEnvFile using the provided EnvLoader. A
+// missing file is not an error, since deployments may set the variables directly.
 func LoadEnv(loader EnvLoader) error {
-	return LoadEnvFromFile(defaultEnvFilePath, loader)
+	for _, path := range []string{DefaultEnvFile, LegacyEnvFile} {
+		if err := LoadEnvFromFile(path, loader); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// LoadEnvFromFile loads environment variables from the specified file using the provided EnvLoader.
+// LoadEnvFromFile loads environment variables from the specified file using the provided EnvLoader. Variables already
+// set in the environment are kept, and a missing file is not an error.
 func LoadEnvFromFile(envFilePath string, loader EnvLoader) error {
-	if err := loader.Load(envFilePath); err != nil {
+	if err := loader.Load(envFilePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return fmt.Errorf(errLoadEnvFile, envFilePath, err)
 	}
 	return nil
 }
-
-// GetRequiredEnvVars retrieves required environment variables, returning an error if any are missing.
-func GetRequiredEnvVars() (string, string, error) {
-	botToken := os.Getenv(botTokenKey)
-	guildID := os.Getenv(guildIDKey)
-
-	var missingVars []string
-	if botToken == "" {
-		missingVars = append(missingVars, botTokenKey)
-	}
-
-	if guildID == "" {
-		missingVars = append(missingVars, guildIDKey)
-	}
-
-	if len(missingVars) > 0 {
-		return botToken, guildID, fmt.Errorf(errMissingVars, missingVars)
-	}
-
-	return botToken, guildID, nil
-}
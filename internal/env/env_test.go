@@ -6,11 +6,6 @@ import (
 	"testing"
 )
 
-const (
-	botTokenValue = "test-bot-token"
-	guildIDValue  = "test-guild-id"
-)
-
 // MockEnvLoader is a simple mock implementation of the EnvLoader interface for testing purposes.
 type MockEnvLoader struct {
 	LoadFunc func(filePath string) error
@@ -23,14 +18,13 @@ func (m *MockEnvLoader) Load(filePath string) error {
 	return nil
 }
 
-// TestLoadEnv tests the LoadEnv function.
+// TestLoadEnv tests that LoadEnv loads the default file, then the legacy one.
 func TestLoadEnv(t *testing.T) {
+	var loaded []string
 	mockLoader := &MockEnvLoader{
 		LoadFunc: func(filePath string) error {
-			if filePath == defaultEnvFilePath {
-				return nil
-			}
-			return fmt.Errorf("unexpected file path: %s", filePath)
+			loaded = append(loaded, filePath)
+			return nil
 		},
 	}
 
@@ -38,6 +32,9 @@ func TestLoadEnv(t *testing.T) {
 	if err != nil {
 		t.Fatalf("LoadEnv() returned an error: %v", err)
 	}
+	if len(loaded) != 2 || loaded[0] != DefaultEnvFile || loaded[1] != LegacyEnvFile {
+		t.Errorf("LoadEnv() loaded %v, want %s then %s", loaded, DefaultEnvFile, LegacyEnvFile)
+	}
 }
 
 // TestLoadEnv_ErrorHandling checks error handling in LoadEnv.
@@ -52,23 +49,30 @@ func TestLoadEnv_ErrorHandling(t *testing.T) {
 	if err == nil {
 		t.Fatal("LoadEnv() expected an error, got nil")
 	}
-	if !contains(err.Error(), "failed to load environment file .env.local") {
+	if !contains(err.Error(), "failed to load environment file .env") {
 		t.Fatalf("unexpected error message: %v", err)
 	}
 }
 
+// TestLoadEnv_MissingFile checks that a missing file is not an error, for deployments that set the variables directly.
+func TestLoadEnv_MissingFile(t *testing.T) {
+	if err := LoadEnvFromFile("does-not-exist.env", &GodotenvLoader{}); err != nil {
+		t.Fatalf("LoadEnvFromFile() returned an error for a missing file: %v", err)
+	}
+}
+
 // TestLoadEnvFromFile tests the LoadEnvFromFile function.
 func TestLoadEnvFromFile(t *testing.T) {
 	mockLoader := &MockEnvLoader{
 		LoadFunc: func(filePath string) error {
-			if filePath == defaultEnvFilePath {
+			if filePath == DefaultEnvFile {
 				return nil
 			}
 			return fmt.Errorf("unexpected file path: %s", filePath)
 		},
 	}
 
-	err := LoadEnvFromFile(defaultEnvFilePath, mockLoader)
+	err := LoadEnvFromFile(DefaultEnvFile, mockLoader)
 	if err != nil {
 		t.Fatalf("LoadEnvFromFile() returned an error: %v", err)
 	}
@@ -97,82 +101,6 @@ func TestGodotenvLoader(t *testing.T) {
 	}
 }
 
-// clearEnvVars clears specific environment variables for testing.
-func clearEnvVars() {
-	os.Unsetenv(botTokenKey)
-	os.Unsetenv(guildIDKey)
-}
-
-func TestGetRequiredEnvVars(t *testing.T) {
-	tests := []struct {
-		name             string
-		setEnvVars       func()
-		expectedBotToken string
-		expectedGuildID  string
-		expectedError    string
-	}{
-		{
-			name: "All environment variables set",
-			setEnvVars: func() {
-				os.Setenv(botTokenKey, botTokenValue)
-				os.Setenv(guildIDKey, guildIDValue)
-			},
-			expectedBotToken: botTokenValue,
-			expectedGuildID:  guildIDValue,
-			expectedError:    "",
-		},
-		{
-			name: "Bot token missing",
-			setEnvVars: func() {
-				os.Unsetenv(botTokenKey)
-				os.Setenv(guildIDKey, guildIDValue)
-			},
-			expectedBotToken: "",
-			expectedGuildID:  guildIDValue,
-			expectedError:    "required environment variables are missing: [BOT_TOKEN]",
-		},
-		{
-			name: "Guild ID missing",
-			setEnvVars: func() {
-				os.Setenv(botTokenKey, botTokenValue)
-				os.Unsetenv(guildIDKey)
-			},
-			expectedBotToken: botTokenValue,
-			expectedGuildID:  "",
-			expectedError:    "required environment variables are missing: [GUILD_ID]",
-		},
-		{
-			name: "Both environment variables missing",
-			setEnvVars: func() {
-				os.Unsetenv(botTokenKey)
-				os.Unsetenv(guildIDKey)
-			},
-			expectedBotToken: "",
-			expectedGuildID:  "",
-			expectedError:    "required environment variables are missing: [BOT_TOKEN GUILD_ID]",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.setEnvVars()
-			defer clearEnvVars()
-
-			botToken, guildID, err := GetRequiredEnvVars()
-
-			if botToken != tt.expectedBotToken {
-				t.Errorf("expected botToken %q, got %q", tt.expectedBotToken, botToken)
-			}
-			if guildID != tt.expectedGuildID {
-				t.Errorf("expected guildID %q, got %q", tt.expectedGuildID, guildID)
-			}
-			if (err != nil && err.Error() != tt.expectedError) || (err == nil && tt.expectedError != "") {
-				t.Errorf("expected error %q, got %v", tt.expectedError, err)
-			}
-		})
-	}
-}
-
 // contains checks if a substring is present in a string.
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s[:len(substr)] == substr || contains(s[1:], substr))
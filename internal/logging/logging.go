@@ -0,0 +1,125 @@
+// Package logging configures the structured logger and carries per-request logging state in a context.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	// LevelKey and FormatKey are the environment variables New reads its settings from in the bot.
+	LevelKey  = "LOG_LEVEL"
+	FormatKey = "LOG_FORMAT"
+
+	FormatText = "text"
+	FormatJSON = "json"
+
+	// RequestIDKey is the attribute every record logged with a request context carries.
+	RequestIDKey = "request_id"
+
+	errUnknownLevel  = "unknown log level %q, expected debug, info, warn or error"
+	errUnknownFormat = "unknown log format %q, expected text or json"
+)
+
+// New creates a logger writing to w at the given level ("debug", "info", "warn" or "error") in the given format
+// ("text" or "json"). Empty values default to info and text. Records logged with a context from WithRequestID carry
+// its request ID.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		h = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf(errUnknownFormat, format)
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+// ParseLevel parses a level name, defaulting to info when it is empty.
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf(errUnknownLevel, level)
+	}
+}
+
+type contextKey int
+
+const (
+	requestIDContextKey contextKey = iota
+	loggerContextKey
+)
+
+// NewRequestID returns a random ID to tell the records of one request apart from others.
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a context carrying the given request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// WithLogger returns a context carrying a logger, typically one with attributes describing the current request.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// contextHandler adds the request ID of the context a record is logged with.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
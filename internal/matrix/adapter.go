@@ -0,0 +1,224 @@
+// Package matrix connects core.ChatBot to Matrix through the client-server API of a homeserver. The bot answers
+// commands such as "!conjugate hablar" in the rooms it is in, joins the rooms it is invited to, and, as Matrix has no
+// buttons, offers choices as a numbered list answered by sending the number.
+package matrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+)
+
+const (
+	// syncTimeout is how long a sync waits for events before returning none.
+	syncTimeout = 30 * time.Second
+	// retryDelay is how long the adapter waits after a failed sync.
+	retryDelay = 5 * time.Second
+	// handleTimeout bounds the handling of one event.
+	handleTimeout = 10 * time.Second
+	// choiceTTL is how long a bare number picks one of the choices offered last in a room. A number replying to the
+	// message offering them picks one as long as no other message offers choices.
+	choiceTTL = 2 * time.Minute
+
+	errWhoami      = "failed to identify the Matrix user"
+	errSync        = "failed to sync"
+	errJoinRoom    = "failed to join room"
+	errHandleEvent = "failed to answer event"
+
+	relReplace = "m.replace"
+)
+
+// choices are the buttons of the bot's latest message offering some in a room, and when it offered them.
+type choices struct {
+	eventID string
+	buttons []core.Button
+	offered time.Time
+}
+
+// Adapter is a core.ChatAdapter for Matrix.
+type Adapter struct {
+	client *Client
+	prefix string
+	userID string
+
+	mu      sync.Mutex
+	choices map[string]choices
+	// retryDelay and choiceTTL are fields so tests need not wait for them.
+	retryDelay time.Duration
+	choiceTTL  time.Duration
+}
+
+var _ core.ChatAdapter = (*Adapter)(nil)
+
+// NewAdapter creates an adapter that receives the commands starting with prefix, such as "!", through client.
+func NewAdapter(client *Client, prefix string) *Adapter {
+	return &Adapter{client: client, prefix: prefix, choices: map[string]choices{}, retryDelay: retryDelay, choiceTTL: choiceTTL}
+}
+
+// Receive syncs with the homeserver and calls handle for each command and choice sent after it starts, one at a time,
+// until ctx is done. Messages sent before it started are left unanswered. It returns an error when the access token is
+// rejected; other failed syncs are retried.
+func (a *Adapter) Receive(ctx context.Context, handle func(ctx context.Context, event core.ChatEvent) error) error {
+	logger := logging.FromContext(ctx)
+	userID, err := a.client.Whoami(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errWhoami, err)
+	}
+	a.userID = userID
+
+	since := ""
+	for ctx.Err() == nil {
+		timeout := syncTimeout
+		if since == "" {
+			timeout = 0
+		}
+		resp, err := a.client.Sync(ctx, since, timeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized {
+				return err
+			}
+			logger.WarnContext(ctx, errSync, "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(a.retryDelay):
+			}
+			continue
+		}
+
+		for roomID := range resp.Rooms.Invite {
+			if err := a.client.JoinRoom(ctx, roomID); err != nil {
+				logger.WarnContext(ctx, errJoinRoom, "room", roomID, "error", err)
+			}
+		}
+		// The first sync returns recent history, which was sent before the bot started.
+		if since != "" {
+			for roomID, room := range resp.Rooms.Join {
+				for _, ev := range room.Timeline.Events {
+					if event, ok := a.chatEvent(roomID, ev); ok {
+						a.handle(ctx, handle, event)
+					}
+				}
+			}
+		}
+		since = resp.NextBatch
+	}
+	return nil
+}
+
+// handle answers event without letting the end of ctx cut it short, so replies under way are sent when receiving stops.
+func (a *Adapter) handle(ctx context.Context, handle func(ctx context.Context, event core.ChatEvent) error, event core.ChatEvent) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), handleTimeout)
+	defer cancel()
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	if err := handle(ctx, event); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, errHandleEvent, "room", event.Message.Conversation, "event_id", event.Message.ID, "error", err)
+	}
+}
+
+// chatEvent reads a command, or a number picking one of the choices offered last in the room, from ev. The number must
+// reply to the message offering the choices, or be sent within choiceTTL of it, so that a number said in conversation
+// later is not taken for a choice. ok is false for every other event, such as the bot's own messages and edits.
+func (a *Adapter) chatEvent(roomID string, ev Event) (event core.ChatEvent, ok bool) {
+	if ev.Type != "m.room.message" || ev.Sender == a.userID || ev.Content.MsgType != msgTypeText {
+		return event, false
+	}
+	if ev.Content.RelatesTo != nil && ev.Content.RelatesTo.RelType == relReplace {
+		return event, false
+	}
+	body := strings.TrimSpace(stripReplyFallback(ev.Content))
+	event = core.ChatEvent{Message: core.MessageRef{Conversation: roomID, ID: ev.EventID}, Sender: ev.Sender}
+
+	if command, found := strings.CutPrefix(body, a.prefix); found && command != "" {
+		fields := strings.Fields(command)
+		event.Command, event.Args = fields[0], fields[1:]
+		return event, true
+	}
+
+	n, err := strconv.Atoi(body)
+	if err != nil {
+		return event, false
+	}
+	a.mu.Lock()
+	offered, exists := a.choices[roomID]
+	a.mu.Unlock()
+	if !exists || n < 1 || n > len(offered.buttons) {
+		return event, false
+	}
+	if replyTo := ev.Content.RelatesTo; replyTo != nil && replyTo.InReplyTo != nil {
+		if replyTo.InReplyTo.EventID != offered.eventID {
+			return event, false
+		}
+	} else if time.Since(offered.offered) > a.choiceTTL {
+		return event, false
+	}
+	event.Message.ID = offered.eventID
+	event.Button = offered.buttons[n-1].Data
+	return event, true
+}
+
+// stripReplyFallback drops the quote of the replied-to message that clients put before the body of a reply.
+func stripReplyFallback(content MessageContent) string {
+	if content.RelatesTo == nil || content.RelatesTo.InReplyTo == nil {
+		return content.Body
+	}
+	lines := strings.Split(content.Body, "\n")
+	i := 0
+	for i < len(lines) && strings.HasPrefix(lines[i], ">") {
+		i++
+	}
+	return strings.Join(lines[i:], "\n")
+}
+
+// Reply sends reply to the room of event, as a reply to its message.
+func (a *Adapter) Reply(ctx context.Context, event core.ChatEvent, reply core.ChatReply) (core.MessageRef, error) {
+	roomID := event.Message.Conversation
+	content := render(reply)
+	content.RelatesTo = &RelatesTo{InReplyTo: &InReplyTo{EventID: event.Message.ID}}
+
+	eventID, err := a.client.SendMessage(ctx, roomID, content)
+	if err != nil {
+		return core.MessageRef{}, err
+	}
+	a.offer(roomID, eventID, reply.Buttons)
+	return core.MessageRef{Conversation: roomID, ID: eventID}, nil
+}
+
+// Edit replaces the content of msg, a message the bot sent, with reply.
+func (a *Adapter) Edit(ctx context.Context, msg core.MessageRef, reply core.ChatReply) error {
+	content := render(reply)
+	edit := MessageContent{
+		MsgType:       content.MsgType,
+		Body:          "* " + content.Body,
+		Format:        content.Format,
+		FormattedBody: "* " + content.FormattedBody,
+		RelatesTo:     &RelatesTo{RelType: relReplace, EventID: msg.ID},
+		NewContent:    &content,
+	}
+	if _, err := a.client.SendMessage(ctx, msg.Conversation, edit); err != nil {
+		return err
+	}
+	a.offer(msg.Conversation, msg.ID, reply.Buttons)
+	return nil
+}
+
+// offer remembers buttons as the choices of the room, answered by their number, unless there are none.
+func (a *Adapter) offer(roomID, eventID string, buttons []core.Button) {
+	if len(buttons) == 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.choices[roomID] = choices{eventID: eventID, buttons: buttons, offered: time.Now()}
+}
@@ -0,0 +1,187 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	errEncodeRequest  = "failed to encode request"
+	errRequest        = "failed to call %s"
+	errDecodeResponse = "failed to decode %s response"
+
+	// syncFilter limits syncs to room messages, leaving out presence, account data and other room events.
+	syncFilter = `{"presence":{"types":[]},"account_data":{"types":[]},` +
+		`"room":{"timeline":{"types":["m.room.message"]},"state":{"types":[]},"ephemeral":{"types":[]},"account_data":{"types":[]}}}`
+)
+
+// APIError is an error response of the homeserver, such as M_UNKNOWN_TOKEN or M_FORBIDDEN.
+type APIError struct {
+	Status  int
+	Code    string `json:"errcode"`
+	Message string `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// Event is a room event of a sync. The bot only reads messages, so Content is decoded as one.
+type Event struct {
+	Type    string         `json:"type"`
+	EventID string         `json:"event_id"`
+	Sender  string         `json:"sender"`
+	Content MessageContent `json:"content"`
+}
+
+// MessageContent is the content of an m.room.message event.
+type MessageContent struct {
+	MsgType       string     `json:"msgtype"`
+	Body          string     `json:"body"`
+	Format        string     `json:"format,omitempty"`
+	FormattedBody string     `json:"formatted_body,omitempty"`
+	RelatesTo     *RelatesTo `json:"m.relates_to,omitempty"`
+	// NewContent is the replacement content of an edit.
+	NewContent *MessageContent `json:"m.new_content,omitempty"`
+}
+
+// RelatesTo relates a message to another: as a reply when InReplyTo is set, or as an edit with RelType m.replace.
+type RelatesTo struct {
+	RelType   string     `json:"rel_type,omitempty"`
+	EventID   string     `json:"event_id,omitempty"`
+	InReplyTo *InReplyTo `json:"m.in_reply_to,omitempty"`
+}
+
+// InReplyTo names the event a reply answers.
+type InReplyTo struct {
+	EventID string `json:"event_id"`
+}
+
+// SyncResponse holds the parts of a /sync response the bot reads.
+type SyncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []Event `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+		Invite map[string]json.RawMessage `json:"invite"`
+	} `json:"rooms"`
+}
+
+// Client calls the client-server API, https://spec.matrix.org/latest/client-server-api/, of a homeserver with an
+// access token.
+type Client struct {
+	homeserver string
+	token      string
+	http       *http.Client
+	// txnPrefix and txn make the transaction IDs of sent events unique across restarts, so the homeserver only
+	// deduplicates retries of one send.
+	txnPrefix string
+	txn       atomic.Int64
+}
+
+// NewClient creates a client for the user whose access token is token on the homeserver at homeserver, e.g.
+// "https://matrix.example.org".
+func NewClient(homeserver, token string) *Client {
+	return &Client{
+		homeserver: strings.TrimSuffix(homeserver, "/"),
+		token:      token,
+		// Syncs are held open for syncTimeout, so the client allows a little more.
+		http:      &http.Client{Timeout: syncTimeout + 10*time.Second},
+		txnPrefix: strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+}
+
+// do calls the endpoint at path, under /_matrix/client/v3, with body as JSON unless it is nil, and decodes the
+// response into result unless it is nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("%s: %w", errEncodeRequest, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	endpoint := c.homeserver + "/_matrix/client/v3" + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf(errRequest+": %w", path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf(errRequest+": %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{Status: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf(errDecodeResponse+": %w", path, err)
+	}
+	return nil
+}
+
+// Whoami returns the user ID the access token belongs to.
+func (c *Client) Whoami(ctx context.Context) (string, error) {
+	var resp struct {
+		UserID string `json:"user_id"`
+	}
+	err := c.do(ctx, http.MethodGet, "/account/whoami", nil, nil, &resp)
+	return resp.UserID, err
+}
+
+// Sync returns the room messages and invites since the batch token since, or the latest state when it is empty,
+// waiting up to timeout for something to arrive.
+func (c *Client) Sync(ctx context.Context, since string, timeout time.Duration) (SyncResponse, error) {
+	query := url.Values{"timeout": {strconv.FormatInt(timeout.Milliseconds(), 10)}, "filter": {syncFilter}}
+	if since != "" {
+		query.Set("since", since)
+	}
+	var resp SyncResponse
+	err := c.do(ctx, http.MethodGet, "/sync", query, nil, &resp)
+	return resp, err
+}
+
+// JoinRoom joins the room with the given ID, e.g. one the bot was invited to.
+func (c *Client) JoinRoom(ctx context.Context, roomID string) error {
+	return c.do(ctx, http.MethodPost, "/join/"+url.PathEscape(roomID), nil, struct{}{}, nil)
+}
+
+// SendMessage sends a message to a room and returns its event ID.
+func (c *Client) SendMessage(ctx context.Context, roomID string, content MessageContent) (string, error) {
+	txnID := c.txnPrefix + "." + strconv.FormatInt(c.txn.Add(1), 10)
+	var resp struct {
+		EventID string `json:"event_id"`
+	}
+	path := "/rooms/" + url.PathEscape(roomID) + "/send/m.room.message/" + url.PathEscape(txnID)
+	err := c.do(ctx, http.MethodPut, path, nil, content, &resp)
+	return resp.EventID, err
+}
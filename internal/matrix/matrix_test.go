@@ -0,0 +1,301 @@
+package matrix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const (
+	testToken  = "syt_Y29uanVnYWRvcg_fake"
+	testUserID = "@conjugador:example.org"
+	testRoom   = "!lesson:example.org"
+)
+
+func ns(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// sentEvent is an event the bot sent to the fake homeserver.
+type sentEvent struct {
+	Room    string
+	TxnID   string
+	Content MessageContent
+}
+
+// fakeHomeserver implements the client-server endpoints the adapter calls. The first sync returns history and an
+// invite; later ones return the queued timeline batches of testRoom, one per sync.
+type fakeHomeserver struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	history []Event
+	batches [][]Event
+	batch   int
+	sent    []sentEvent
+	joined  []string
+	synced  chan struct{}
+}
+
+func newFakeHomeserver(t *testing.T) *fakeHomeserver {
+	t.Helper()
+	hs := &fakeHomeserver{synced: make(chan struct{}, 100)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /_matrix/client/v3/account/whoami", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"user_id": testUserID})
+	})
+	mux.HandleFunc("GET /_matrix/client/v3/sync", hs.sync)
+	mux.HandleFunc("POST /_matrix/client/v3/join/{room}", func(w http.ResponseWriter, r *http.Request) {
+		hs.mu.Lock()
+		hs.joined = append(hs.joined, r.PathValue("room"))
+		hs.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]string{"room_id": r.PathValue("room")})
+	})
+	mux.HandleFunc("PUT /_matrix/client/v3/rooms/{room}/send/m.room.message/{txn}", func(w http.ResponseWriter, r *http.Request) {
+		var content MessageContent
+		if err := json.NewDecoder(r.Body).Decode(&content); err != nil {
+			writeJSON(w, http.StatusBadRequest, APIError{Code: "M_NOT_JSON", Message: err.Error()})
+			return
+		}
+		hs.mu.Lock()
+		hs.sent = append(hs.sent, sentEvent{Room: r.PathValue("room"), TxnID: r.PathValue("txn"), Content: content})
+		eventID := "$bot" + strconv.Itoa(len(hs.sent))
+		hs.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]string{"event_id": eventID})
+	})
+
+	hs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			writeJSON(w, http.StatusUnauthorized, APIError{Code: "M_UNKNOWN_TOKEN", Message: "Invalid access token passed."})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(hs.Close)
+	return hs
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (hs *fakeHomeserver) sync(w http.ResponseWriter, r *http.Request) {
+	defer func() { hs.synced <- struct{}{} }()
+	resp := map[string]any{"next_batch": "s" + strconv.Itoa(hs.batch+1)}
+	if r.URL.Query().Get("since") == "" {
+		resp["rooms"] = map[string]any{
+			"join":   map[string]any{testRoom: map[string]any{"timeline": map[string]any{"events": hs.history}}},
+			"invite": map[string]any{"!invited:example.org": map[string]any{"invite_state": map[string]any{"events": []any{}}}},
+		}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	hs.mu.Lock()
+	var events []Event
+	if hs.batch < len(hs.batches) {
+		events = hs.batches[hs.batch]
+		hs.batch++
+		resp["next_batch"] = "s" + strconv.Itoa(hs.batch+1)
+	}
+	hs.mu.Unlock()
+	if events == nil {
+		// Long polling with nothing new: wait a little, as a homeserver waits for the timeout.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	resp["rooms"] = map[string]any{"join": map[string]any{testRoom: map[string]any{"timeline": map[string]any{"events": events}}}}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (hs *fakeHomeserver) sentEvents() []sentEvent {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	return append([]sentEvent(nil), hs.sent...)
+}
+
+func message(id, sender, body string) Event {
+	return Event{Type: "m.room.message", EventID: id, Sender: sender, Content: MessageContent{MsgType: msgTypeText, Body: body}}
+}
+
+func newTestChatBot(adapter *Adapter) *core.ChatBot {
+	infinitives := []db.Infinitive{{Infinitive: "hablar"}}
+	verbs := db.NewMemoryRepository(db.MemoryData{
+		Infinitives: infinitives,
+		Verbs: []db.Verb{
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", VerbEnglish: ns("I speak"),
+				Form1s: ns("hablo"), Form2s: ns("hablas"), Form3s: ns("habla"), Form1p: ns("hablamos"), Form2p: ns("habláis"), Form3p: ns("hablan")},
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Pretérito", VerbEnglish: ns("I spoke"),
+				Form1s: ns("hablé"), Form2s: ns("hablaste"), Form3s: ns("habló"), Form1p: ns("hablamos"), Form2p: ns("hablasteis"), Form3p: ns("hablaron")},
+		},
+	})
+	return core.NewChatBot(core.NewService(verbs, db.NewInfinitiveIndex(infinitives)), adapter)
+}
+
+func TestAdapter(t *testing.T) {
+	hs := newFakeHomeserver(t)
+	hs.history = []Event{message("$old", "@ana:example.org", "!conjugate hablar")}
+	reply := message("$reply", "@ana:example.org", "> <@conjugador:example.org> Choose a tense for hablar:\n\n2")
+	reply.Content.RelatesTo = &RelatesTo{InReplyTo: &InReplyTo{EventID: "$bot1"}}
+	hs.batches = [][]Event{
+		{
+			message("$cmd", "@ana:example.org", "!conjugate hablar"),
+			message("$echo", testUserID, "!conjugate hablar"),
+			message("$chat", "@ana:example.org", "¿Qué tal?"),
+		},
+		{reply, message("$out", "@ana:example.org", "19")},
+		{message("$unknown", "@ana:example.org", "!conjugate blorp")},
+	}
+
+	adapter := NewAdapter(NewClient(hs.URL, testToken), "!")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- newTestChatBot(adapter).Run(ctx) }()
+
+	deadline := time.After(5 * time.Second)
+	for len(hs.sentEvents()) < 3 {
+		select {
+		case <-hs.synced:
+		case <-deadline:
+			t.Fatalf("Sent %+v before the deadline, want 3 events", hs.sentEvents())
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+
+	sent := hs.sentEvents()
+	if len(sent) != 3 {
+		t.Fatalf("Sent %d events, want the picker, the edit and the not found reply: %+v", len(sent), sent)
+	}
+
+	picker := sent[0].Content
+	if picker.RelatesTo == nil || picker.RelatesTo.InReplyTo == nil || picker.RelatesTo.InReplyTo.EventID != "$cmd" {
+		t.Errorf("Picker relates to %+v, want a reply to $cmd", picker.RelatesTo)
+	}
+	if !strings.HasPrefix(picker.Body, "Choose a tense for hablar:\n\n"+msgChoices+"\n1 Present · 2 Preterite") {
+		t.Errorf("Picker body = %q, want the numbered tenses", picker.Body)
+	}
+	if picker.Format != formatHTML || !strings.Contains(picker.FormattedBody, "<ol><li>Present</li><li>Preterite</li>") {
+		t.Errorf("Picker formatted body = %q, want an ordered list of tenses", picker.FormattedBody)
+	}
+
+	edit := sent[1].Content
+	if edit.RelatesTo == nil || edit.RelatesTo.RelType != relReplace || edit.RelatesTo.EventID != "$bot1" || edit.NewContent == nil {
+		t.Fatalf("Edit = %+v, want it to replace $bot1", edit)
+	}
+	for _, want := range []string{"<strong>hablar – I spoke</strong>", "<td>yo</td><td>hablé</td>", "<li>✓ Preterite</li>"} {
+		if !strings.Contains(edit.NewContent.FormattedBody, want) {
+			t.Errorf("Edit formatted body = %q, want it to contain %q", edit.NewContent.FormattedBody, want)
+		}
+	}
+	if !strings.Contains(edit.NewContent.Body, "persona           forma\nyo                hablé\n") {
+		t.Errorf("Edit body = %q, want an aligned table", edit.NewContent.Body)
+	}
+	if !strings.HasPrefix(edit.Body, "* ") {
+		t.Errorf("Edit fallback body = %q, want it marked as an edit", edit.Body)
+	}
+
+	if got := sent[2].Content.Body; got != "Verb not found." {
+		t.Errorf("Body = %q, want the verb not found", got)
+	}
+	if sent[0].TxnID == sent[1].TxnID || sent[0].Room != testRoom {
+		t.Errorf("Sent %+v, want unique transaction IDs in %s", sent, testRoom)
+	}
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if len(hs.joined) != 1 || hs.joined[0] != "!invited:example.org" {
+		t.Errorf("Joined %v, want the room the bot was invited to", hs.joined)
+	}
+}
+
+func TestChatEventChoices(t *testing.T) {
+	adapter := NewAdapter(NewClient("http://localhost", testToken), "!")
+	adapter.userID = testUserID
+	adapter.offer(testRoom, "$bot1", core.TenseButtons("hablar", ""))
+	replyTo := func(ev Event, eventID string) Event {
+		ev.Content.RelatesTo = &RelatesTo{InReplyTo: &InReplyTo{EventID: eventID}}
+		return ev
+	}
+
+	tests := []struct {
+		name    string
+		ev      Event
+		expired bool
+		want    bool
+	}{
+		{"bare number", message("$a", "@ana:example.org", "2"), false, true},
+		{"reply", replyTo(message("$b", "@ana:example.org", "2"), "$bot1"), false, true},
+		{"reply to another message", replyTo(message("$c", "@ana:example.org", "2"), "$bot0"), false, false},
+		{"out of range", message("$d", "@ana:example.org", "19"), false, false},
+		{"bare number after expiry", message("$e", "@ana:example.org", "2"), true, false},
+		{"reply after expiry", replyTo(message("$f", "@ana:example.org", "2"), "$bot1"), true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter.choiceTTL = choiceTTL
+			if tt.expired {
+				adapter.choiceTTL = -time.Second
+			}
+			event, ok := adapter.chatEvent(testRoom, tt.ev)
+			if ok != tt.want {
+				t.Fatalf("chatEvent() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && (event.Button != "t:1:hablar" || event.Message.ID != "$bot1") {
+				t.Errorf("chatEvent() = %+v, want the Preterite button of $bot1", event)
+			}
+		})
+	}
+}
+
+func TestAdapterRejectedToken(t *testing.T) {
+	hs := newFakeHomeserver(t)
+	adapter := NewAdapter(NewClient(hs.URL, "expired"), "!")
+
+	err := newTestChatBot(adapter).Run(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "M_UNKNOWN_TOKEN" {
+		t.Errorf("Run() error = %v, want M_UNKNOWN_TOKEN", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	reply := core.ChatReply{
+		Text: "tener – I have\nPresent (Indicativo Presente)",
+		Table: &core.Table{Header: []string{"persona", "forma"}, Rows: [][]core.Cell{
+			{{Text: "yo"}, {Text: "tengo", Strong: true}},
+			{{Text: "nosotros"}, {Text: "tenemos"}},
+		}},
+		Notes: []string{"Tengo <dos> perros. — I have two dogs."},
+	}
+	content := render(reply)
+
+	wantBody := "tener – I have\nPresent (Indicativo Presente)\n\npersona   forma\nyo        *tengo*\nnosotros  tenemos\n\n• Tengo <dos> perros. — I have two dogs."
+	if content.Body != wantBody {
+		t.Errorf("Body = %q, want %q", content.Body, wantBody)
+	}
+	wantHTML := "<p><strong>tener – I have</strong><br>Present (Indicativo Presente)</p>" +
+		"<table><thead><tr><th>persona</th><th>forma</th></tr></thead><tbody>" +
+		"<tr><td>yo</td><td><strong>tengo</strong></td></tr><tr><td>nosotros</td><td>tenemos</td></tr></tbody></table>" +
+		"<ul><li>Tengo &lt;dos&gt; perros. — I have two dogs.</li></ul>"
+	if content.FormattedBody != wantHTML {
+		t.Errorf("FormattedBody = %q, want %q", content.FormattedBody, wantHTML)
+	}
+}
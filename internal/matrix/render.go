@@ -0,0 +1,131 @@
+package matrix
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+)
+
+const (
+	msgTypeText = "m.text"
+	formatHTML  = "org.matrix.custom.html"
+
+	// msgChoices introduces the numbered choices Matrix offers instead of buttons.
+	msgChoices = "Reply with a number to choose:"
+)
+
+// render turns reply into a message with a plain body, where tables have aligned columns and strong cells are marked
+// with asterisks, and an HTML body for clients that show it. Buttons become numbered choices.
+func render(reply core.ChatReply) MessageContent {
+	var plain, rich strings.Builder
+
+	lines := strings.Split(reply.Text, "\n")
+	plain.WriteString(reply.Text + "\n")
+	for i, line := range lines {
+		line = html.EscapeString(line)
+		if i == 0 && reply.Table != nil {
+			line = "<strong>" + line + "</strong>"
+		}
+		lines[i] = line
+	}
+	rich.WriteString("<p>" + strings.Join(lines, "<br>") + "</p>")
+
+	if reply.Table != nil {
+		writePlainTable(&plain, reply.Table)
+		writeHTMLTable(&rich, reply.Table)
+	}
+
+	if len(reply.Notes) > 0 {
+		plain.WriteByte('\n')
+		rich.WriteString("<ul>")
+		for _, note := range reply.Notes {
+			plain.WriteString("• " + note + "\n")
+			rich.WriteString("<li>" + html.EscapeString(note) + "</li>")
+		}
+		rich.WriteString("</ul>")
+	}
+
+	if len(reply.Buttons) > 0 {
+		labels := make([]string, len(reply.Buttons))
+		rich.WriteString("<p>" + msgChoices + "</p><ol>")
+		for i, button := range reply.Buttons {
+			labels[i] = strconv.Itoa(i+1) + " " + button.Label
+			rich.WriteString("<li>" + html.EscapeString(button.Label) + "</li>")
+		}
+		rich.WriteString("</ol>")
+		plain.WriteString("\n" + msgChoices + "\n" + strings.Join(labels, " · ") + "\n")
+	}
+
+	return MessageContent{
+		MsgType:       msgTypeText,
+		Body:          strings.TrimSuffix(plain.String(), "\n"),
+		Format:        formatHTML,
+		FormattedBody: rich.String(),
+	}
+}
+
+func cellText(c core.Cell) string {
+	if c.Strong {
+		return "*" + c.Text + "*"
+	}
+	return c.Text
+}
+
+func writePlainTable(b *strings.Builder, t *core.Table) {
+	widths := make([]int, len(t.Header))
+	for i, h := range t.Header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range t.Rows {
+		for i, c := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cellText(c)))
+			}
+		}
+	}
+
+	b.WriteByte('\n')
+	writePlainRow(b, widths, t.Header)
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			cells[i] = cellText(c)
+		}
+		writePlainRow(b, widths, cells)
+	}
+}
+
+func writePlainRow(b *strings.Builder, widths []int, cells []string) {
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(cell)
+			break
+		}
+		fmt.Fprintf(b, "%-*s  ", widths[i], cell)
+	}
+	b.WriteByte('\n')
+}
+
+func writeHTMLTable(b *strings.Builder, t *core.Table) {
+	b.WriteString("<table><thead><tr>")
+	for _, h := range t.Header {
+		b.WriteString("<th>" + html.EscapeString(h) + "</th>")
+	}
+	b.WriteString("</tr></thead><tbody>")
+	for _, row := range t.Rows {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			text := html.EscapeString(c.Text)
+			if c.Strong {
+				text = "<strong>" + text + "</strong>"
+			}
+			b.WriteString("<td>" + text + "</td>")
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</tbody></table>")
+}
@@ -0,0 +1,110 @@
+// Package metrics defines the Prometheus metrics the bot exports and the HTTP server exposing them along with health
+// checks.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const (
+	namespace = "conjugador"
+
+	// maxNotFoundLabels bounds the number of distinct infinitives verb_not_found_total tracks, since its label comes
+	// from user input. Infinitives beyond it are counted under otherLabel.
+	maxNotFoundLabels = 500
+	otherLabel        = "other"
+)
+
+// Registry holds every metric of the bot along with the Go runtime and process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	commandsInvoked = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "commands_invoked_total",
+		Help:      "Application commands invoked, by command name.",
+	}, []string{"command"})
+
+	lookupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "lookup_duration_seconds",
+		Help:      "Latency of verb data lookups, by repository operation.",
+		Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5},
+	}, []string{"operation"})
+
+	dbErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_errors_total",
+		Help:      "Verb data lookups that failed with an error other than not found, by repository operation.",
+	}, []string{"operation"})
+
+	verbNotFound = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "verb_not_found_total",
+		Help:      "Lookups of an infinitive with no matching data, by infinitive.",
+	}, []string{"infinitive"})
+
+	gatewayReconnects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gateway_reconnects_total",
+		Help:      "Times the Discord gateway connection was re-established.",
+	})
+
+	responseFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "interaction_response_failures_total",
+		Help:      "Interaction responses Discord did not accept.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		commandsInvoked,
+		lookupDuration,
+		dbErrors,
+		verbNotFound,
+		gatewayReconnects,
+		responseFailures,
+	)
+}
+
+// CommandInvoked counts an invocation of the named application command.
+func CommandInvoked(command string) {
+	commandsInvoked.WithLabelValues(command).Inc()
+}
+
+// GatewayReconnected counts a re-established gateway connection.
+func GatewayReconnected() {
+	gatewayReconnects.Inc()
+}
+
+// ResponseFailed counts an interaction response Discord did not accept.
+func ResponseFailed() {
+	responseFailures.Inc()
+}
+
+// notFoundLabels tracks which infinitives verb_not_found_total already has a series for.
+var notFoundLabels = struct {
+	sync.Mutex
+	seen map[string]bool
+}{seen: make(map[string]bool)}
+
+// VerbNotFound counts a lookup of an infinitive with no matching data.
+func VerbNotFound(infinitive string) {
+	notFoundLabels.Lock()
+	if !notFoundLabels.seen[infinitive] {
+		if len(notFoundLabels.seen) < maxNotFoundLabels {
+			notFoundLabels.seen[infinitive] = true
+		} else {
+			infinitive = otherLabel
+		}
+	}
+	notFoundLabels.Unlock()
+
+	verbNotFound.WithLabelValues(infinitive).Inc()
+}
@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// failingRepository is a db.VerbRepository whose lookups fail with err.
+type failingRepository struct {
+	db.VerbRepository
+	err error
+}
+
+func (r failingRepository) GetVerb(ctx context.Context, infinitive, mood, tense string) (db.Verb, error) {
+	return db.Verb{}, r.err
+}
+
+func TestRepositoryCountsErrors(t *testing.T) {
+	ctx := context.Background()
+
+	notFound := NewRepository(failingRepository{err: db.ErrNotFound})
+	before := testutil.ToFloat64(verbNotFound.WithLabelValues("blorp"))
+	notFound.GetVerb(ctx, "blorp", "Indicativo", "Presente")
+	if got := testutil.ToFloat64(verbNotFound.WithLabelValues("blorp")) - before; got != 1 {
+		t.Errorf("verb_not_found_total{infinitive=blorp} increased by %v, want 1", got)
+	}
+
+	broken := NewRepository(failingRepository{err: errors.New("disk I/O error")})
+	before = testutil.ToFloat64(dbErrors.WithLabelValues("get_verb"))
+	broken.GetVerb(ctx, "hablar", "Indicativo", "Presente")
+	if got := testutil.ToFloat64(dbErrors.WithLabelValues("get_verb")) - before; got != 1 {
+		t.Errorf("db_errors_total{operation=get_verb} increased by %v, want 1", got)
+	}
+
+	if testutil.CollectAndCount(lookupDuration) == 0 {
+		t.Error("Expected lookup_duration_seconds to have observations")
+	}
+}
+
+func TestVerbNotFoundBoundsLabels(t *testing.T) {
+	for i := 0; i < maxNotFoundLabels+10; i++ {
+		VerbNotFound("unknown" + strconv.Itoa(i))
+	}
+
+	if got := testutil.CollectAndCount(verbNotFound); got > maxNotFoundLabels+1 {
+		t.Errorf("verb_not_found_total has %d series, want at most %d", got, maxNotFoundLabels+1)
+	}
+	if got := testutil.ToFloat64(verbNotFound.WithLabelValues(otherLabel)); got < 10 {
+		t.Errorf("verb_not_found_total{infinitive=other} = %v, want at least 10", got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ok := Check{Name: "database", Check: func(ctx context.Context) error { return nil }}
+	down := Check{Name: "gateway", Check: func(ctx context.Context) error { return errors.New("disconnected") }}
+	CommandInvoked("conjugate")
+
+	server := httptest.NewServer(NewHandler([]Check{ok}, []Check{down}))
+	defer server.Close()
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"/healthz", http.StatusOK, "database: ok\n"},
+		{"/readyz", http.StatusServiceUnavailable, "database: ok\ngateway: disconnected\n"},
+		{"/metrics", http.StatusOK, `conjugador_commands_invoked_total{command="conjugate"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("GET %s returned an error: %v", tt.path, err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GET %s status = %d, want %d", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("GET %s body = %q, want it to contain %q", tt.path, body, tt.wantBody)
+			}
+		})
+	}
+}
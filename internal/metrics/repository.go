@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+// Repository is a db.VerbRepository that records the latency, errors and not-found lookups of another repository.
+type Repository struct {
+	next db.VerbRepository
+}
+
+// NewRepository instruments next.
+func NewRepository(next db.VerbRepository) *Repository {
+	return &Repository{next: next}
+}
+
+// observe records a lookup that started at start. A not-found error is counted against infinitive unless it is empty.
+func observe(operation, infinitive string, start time.Time, err error) {
+	lookupDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	switch {
+	case err == nil:
+	case errors.Is(err, db.ErrNotFound):
+		if infinitive != "" {
+			VerbNotFound(infinitive)
+		}
+	default:
+		dbErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+func (r *Repository) GetVerb(ctx context.Context, infinitive, mood, tense string) (db.Verb, error) {
+	start := time.Now()
+	verb, err := r.next.GetVerb(ctx, infinitive, mood, tense)
+	observe("get_verb", infinitive, start, err)
+	return verb, err
+}
+
+func (r *Repository) GetVerbs(ctx context.Context, infinitive string) ([]db.Verb, error) {
+	start := time.Now()
+	verbs, err := r.next.GetVerbs(ctx, infinitive)
+	observe("get_verbs", infinitive, start, err)
+	return verbs, err
+}
+
+func (r *Repository) SearchInfinitives(ctx context.Context, prefix string, limit int) ([]db.Infinitive, error) {
+	start := time.Now()
+	infinitives, err := r.next.SearchInfinitives(ctx, prefix, limit)
+	observe("search_infinitives", "", start, err)
+	return infinitives, err
+}
+
+func (r *Repository) FindForm(ctx context.Context, form string) ([]db.FormMatch, error) {
+	start := time.Now()
+	matches, err := r.next.FindForm(ctx, form)
+	observe("find_form", "", start, err)
+	return matches, err
+}
+
+func (r *Repository) GetGerund(ctx context.Context, infinitive string) (db.Gerund, error) {
+	start := time.Now()
+	gerund, err := r.next.GetGerund(ctx, infinitive)
+	observe("get_gerund", infinitive, start, err)
+	return gerund, err
+}
+
+func (r *Repository) GetPastparticiple(ctx context.Context, infinitive string) (db.Pastparticiple, error) {
+	start := time.Now()
+	participle, err := r.next.GetPastparticiple(ctx, infinitive)
+	observe("get_pastparticiple", infinitive, start, err)
+	return participle, err
+}
+
+func (r *Repository) GetExamples(ctx context.Context, infinitive, mood, tense string, limit int) ([]db.Example, error) {
+	start := time.Now()
+	examples, err := r.next.GetExamples(ctx, infinitive, mood, tense, limit)
+	observe("get_examples", "", start, err)
+	return examples, err
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// checkTimeout bounds how long /healthz and /readyz wait for their checks.
+const checkTimeout = 2 * time.Second
+
+// Check reports whether one dependency of the bot is usable.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// NewHandler serves the metrics in Registry on /metrics. /healthz runs the health checks, such as database
+// connectivity, and /readyz runs those and the readiness checks, such as the gateway session being connected. Both
+// answer 503 when a check fails and list the result of every check.
+func NewHandler(health, ready []Check) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	mux.Handle("/healthz", checksHandler(health))
+	mux.Handle("/readyz", checksHandler(append(append([]Check(nil), health...), ready...)))
+	return mux
+}
+
+// NewServer creates the HTTP server of NewHandler on addr.
+func NewServer(addr string, health, ready []Check) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           NewHandler(health, ready),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func checksHandler(checks []Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		status := http.StatusOK
+		body := ""
+		for _, c := range checks {
+			if err := c.Check(ctx); err != nil {
+				status = http.StatusServiceUnavailable
+				body += fmt.Sprintf("%s: %v\n", c.Name, err)
+				continue
+			}
+			body += fmt.Sprintf("%s: ok\n", c.Name)
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	})
+}
@@ -0,0 +1,31 @@
+// Package spanish holds the handling of Spanish words and grammar shared by every front end: normalizing what users
+// type, the tenses offered and the forms of regular verbs.
+package spanish
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize trims s, collapses runs of whitespace to a single space, composes it to Unicode NFC and lowercases it.
+// Two spellings of the same word that differ only in case or in how their accents are encoded normalize to the same
+// string.
+func Normalize(s string) string {
+	return strings.ToLower(norm.NFC.String(strings.Join(strings.Fields(s), " ")))
+}
+
+// Fold normalizes s and removes its diacritics, so "Oír", "OIR" and "oir" all fold to "oir". Folding also turns ñ into
+// n and ü into u, which lets users without a Spanish keyboard type any word but means distinct words can fold to the
+// same key.
+func Fold(s string) string {
+	folder := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(folder, Normalize(s))
+	if err != nil {
+		return Normalize(s)
+	}
+	return folded
+}
@@ -0,0 +1,49 @@
+package spanish
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"already normalized", "hablar", "hablar"},
+		{"uppercase", "OÍR", "oír"},
+		{"surrounding whitespace", "  oír\t", "oír"},
+		{"inner whitespace", "no  hables", "no hables"},
+		{"decomposed accent", "oi\u0301r", "oír"},
+		{"empty", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"acute accent", "reír", "reir"},
+		{"uppercase accent", "OÍR", "oir"},
+		{"decomposed accent", "oi\u0301r", "oir"},
+		{"tilde", "añadir", "anadir"},
+		{"diaeresis", "averigüar", "averiguar"},
+		{"no diacritics", " Hablar ", "hablar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fold(tt.input); got != tt.want {
+				t.Errorf("Fold(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package spanish
+
+import "strings"
+
+// Verb classes, named after the infinitive ending.
+const (
+	classAr = "ar"
+	classEr = "er"
+	classIr = "ir"
+)
+
+// regularEndings holds the endings of the simple tenses by verb class, in the order of the form_* columns of verbs.db.
+// The future and conditional endings are added to the whole infinitive and are the same for every class.
+var regularEndings = map[TenseMood]map[string][6]string{
+	{"Indicativo", "Presente"}: {
+		classAr: {"o", "as", "a", "amos", "áis", "an"},
+		classEr: {"o", "es", "e", "emos", "éis", "en"},
+		classIr: {"o", "es", "e", "imos", "ís", "en"},
+	},
+	{"Indicativo", "Pretérito"}: {
+		classAr: {"é", "aste", "ó", "amos", "asteis", "aron"},
+		classEr: {"í", "iste", "ió", "imos", "isteis", "ieron"},
+		classIr: {"í", "iste", "ió", "imos", "isteis", "ieron"},
+	},
+	{"Indicativo", "Imperfecto"}: {
+		classAr: {"aba", "abas", "aba", "ábamos", "abais", "aban"},
+		classEr: {"ía", "ías", "ía", "íamos", "íais", "ían"},
+		classIr: {"ía", "ías", "ía", "íamos", "íais", "ían"},
+	},
+	{"Subjuntivo", "Presente"}: {
+		classAr: {"e", "es", "e", "emos", "éis", "en"},
+		classEr: {"a", "as", "a", "amos", "áis", "an"},
+		classIr: {"a", "as", "a", "amos", "áis", "an"},
+	},
+	{"Subjuntivo", "Imperfecto"}: {
+		classAr: {"ara", "aras", "ara", "áramos", "arais", "aran"},
+		classEr: {"iera", "ieras", "iera", "iéramos", "ierais", "ieran"},
+		classIr: {"iera", "ieras", "iera", "iéramos", "ierais", "ieran"},
+	},
+	{"Subjuntivo", "Futuro"}: {
+		classAr: {"are", "ares", "are", "áremos", "areis", "aren"},
+		classEr: {"iere", "ieres", "iere", "iéremos", "iereis", "ieren"},
+		classIr: {"iere", "ieres", "iere", "iéremos", "iereis", "ieren"},
+	},
+}
+
+var infinitiveEndings = map[TenseMood][6]string{
+	{"Indicativo", "Futuro"}:      {"é", "ás", "á", "emos", "éis", "án"},
+	{"Indicativo", "Condicional"}: {"ía", "ías", "ía", "íamos", "íais", "ían"},
+}
+
+// haberForms holds the auxiliary of each compound tense.
+var haberForms = map[TenseMood][6]string{
+	{"Indicativo", "Presente perfecto"}:    {"he", "has", "ha", "hemos", "habéis", "han"},
+	{"Indicativo", "Pretérito anterior"}:   {"hube", "hubiste", "hubo", "hubimos", "hubisteis", "hubieron"},
+	{"Indicativo", "Pluscuamperfecto"}:     {"había", "habías", "había", "habíamos", "habíais", "habían"},
+	{"Indicativo", "Futuro perfecto"}:      {"habré", "habrás", "habrá", "habremos", "habréis", "habrán"},
+	{"Indicativo", "Condicional perfecto"}: {"habría", "habrías", "habría", "habríamos", "habríais", "habrían"},
+	{"Subjuntivo", "Presente perfecto"}:    {"haya", "hayas", "haya", "hayamos", "hayáis", "hayan"},
+	{"Subjuntivo", "Pluscuamperfecto"}:     {"hubiera", "hubieras", "hubiera", "hubiéramos", "hubierais", "hubieran"},
+	{"Subjuntivo", "Futuro perfecto"}:      {"hubiere", "hubieres", "hubiere", "hubiéremos", "hubiereis", "hubieren"},
+}
+
+var (
+	imperativeAffirmative = TenseMood{"Imperativo Afirmativo", "Presente"}
+	imperativeNegative    = TenseMood{"Imperativo Negativo", "Presente"}
+	subjunctivePresent    = TenseMood{"Subjuntivo", "Presente"}
+	indicativePresent     = TenseMood{"Indicativo", "Presente"}
+)
+
+// RegularForms returns the forms a regular verb conjugated like infinitive has in tm, laid out like the form_* columns
+// of verbs.db: the imperative rows keep tú, vosotros, Ud. and Uds. in form_2s, form_3s, form_2p and form_3p and leave
+// the other two empty. Spelling changes that keep the sound of the stem, such as busqué, llegué, empiece, cojo and
+// venzo, count as regular. ok is false when infinitive does not end in -ar, -er or -ir, or tm is not a tense of verbs.db.
+//
+// Comparing a verb's forms with its regular forms tells its irregular forms apart.
+func RegularForms(infinitive string, tm TenseMood) (forms [6]string, ok bool) {
+	infinitive = Normalize(infinitive)
+	stem, class, ok := splitInfinitive(infinitive)
+	if !ok {
+		return forms, false
+	}
+
+	if endings, ok := regularEndings[tm]; ok {
+		for i, ending := range endings[class] {
+			forms[i] = join(stem, class, ending)
+		}
+		return forms, true
+	}
+	if endings, ok := infinitiveEndings[tm]; ok {
+		// oír and reír lose the accent before an ending: oiré.
+		future := strings.TrimSuffix(infinitive, "ír") + "ir"
+		if !strings.HasSuffix(infinitive, "ír") {
+			future = infinitive
+		}
+		for i, ending := range endings {
+			forms[i] = future + ending
+		}
+		return forms, true
+	}
+	if haber, ok := haberForms[tm]; ok {
+		participle := join(stem, class, "ado")
+		if class != classAr {
+			participle = join(stem, class, "ido")
+		}
+		for i, aux := range haber {
+			forms[i] = aux + " " + participle
+		}
+		return forms, true
+	}
+
+	switch tm {
+	case imperativeAffirmative:
+		present, _ := RegularForms(infinitive, indicativePresent)
+		subjunctive, _ := RegularForms(infinitive, subjunctivePresent)
+		return [6]string{"", present[2], stem + class[:1] + "d", "", subjunctive[2], subjunctive[5]}, true
+	case imperativeNegative:
+		s, _ := RegularForms(infinitive, subjunctivePresent)
+		return [6]string{"", "no " + s[1], "no " + s[4], "", "no " + s[2], "no " + s[5]}, true
+	}
+	return forms, false
+}
+
+// splitInfinitive splits a normalized infinitive into its stem and class. Reflexive infinitives are not split.
+func splitInfinitive(infinitive string) (stem, class string, ok bool) {
+	if strings.HasSuffix(infinitive, "ír") {
+		return strings.TrimSuffix(infinitive, "ír"), classIr, true
+	}
+	for _, class := range []string{classAr, classEr, classIr} {
+		if stem, found := strings.CutSuffix(infinitive, class); found && stem != "" {
+			return stem, class, true
+		}
+	}
+	return "", "", false
+}
+
+// join adds ending to stem with the spelling changes Spanish orthography requires to keep the sound of the stem.
+func join(stem, class, ending string) string {
+	front := strings.HasPrefix(ending, "e") || strings.HasPrefix(ending, "é")
+	back := strings.HasPrefix(ending, "a") || strings.HasPrefix(ending, "á") || strings.HasPrefix(ending, "o")
+
+	switch {
+	case class == classAr && front && strings.HasSuffix(stem, "c"):
+		stem = strings.TrimSuffix(stem, "c") + "qu"
+	case class == classAr && front && strings.HasSuffix(stem, "g"):
+		stem += "u"
+	case class == classAr && front && strings.HasSuffix(stem, "z"):
+		stem = strings.TrimSuffix(stem, "z") + "c"
+	case class != classAr && back && strings.HasSuffix(stem, "gu"):
+		stem = strings.TrimSuffix(stem, "u")
+	case class != classAr && back && strings.HasSuffix(stem, "g"):
+		stem = strings.TrimSuffix(stem, "g") + "j"
+	case class != classAr && back && strings.HasSuffix(stem, "c") && !endsInVowel(strings.TrimSuffix(stem, "c")):
+		stem = strings.TrimSuffix(stem, "c") + "z"
+	}
+
+	// After a vowel, an unstressed i before another vowel is written y (leyó, leyera) and a stressed i carries an
+	// accent to break the diphthong (leíste, leído).
+	if class != classAr && endsInVowel(stem) && !strings.HasSuffix(stem, "qu") && !strings.HasSuffix(stem, "gu") {
+		switch rest, found := strings.CutPrefix(ending, "i"); {
+		case found && startsWithVowel(rest):
+			ending = "y" + rest
+		case found && rest != "" && !strings.HasSuffix(stem, "i") && !strings.HasSuffix(stem, "u"):
+			ending = "í" + rest
+		}
+	}
+	return stem + ending
+}
+
+func startsWithVowel(s string) bool {
+	return strings.IndexAny(s, "aeiouáéíóú") == 0
+}
+
+func endsInVowel(s string) bool {
+	return strings.HasSuffix(s, "a") || strings.HasSuffix(s, "e") || strings.HasSuffix(s, "i") ||
+		strings.HasSuffix(s, "o") || strings.HasSuffix(s, "u")
+}
@@ -0,0 +1,46 @@
+package spanish
+
+import "testing"
+
+func TestRegularForms(t *testing.T) {
+	tests := []struct {
+		infinitive string
+		tm         TenseMood
+		want       [6]string
+	}{
+		{"hablar", TenseMood{"Indicativo", "Presente"}, [6]string{"hablo", "hablas", "habla", "hablamos", "habláis", "hablan"}},
+		{"comer", TenseMood{"Indicativo", "Pretérito"}, [6]string{"comí", "comiste", "comió", "comimos", "comisteis", "comieron"}},
+		{"vivir", TenseMood{"Indicativo", "Futuro"}, [6]string{"viviré", "vivirás", "vivirá", "viviremos", "viviréis", "vivirán"}},
+		{"vivir", TenseMood{"Subjuntivo", "Imperfecto"}, [6]string{"viviera", "vivieras", "viviera", "viviéramos", "vivierais", "vivieran"}},
+		{"hablar", TenseMood{"Indicativo", "Presente perfecto"}, [6]string{"he hablado", "has hablado", "ha hablado", "hemos hablado", "habéis hablado", "han hablado"}},
+		{"comer", TenseMood{"Imperativo Afirmativo", "Presente"}, [6]string{"", "come", "comed", "", "coma", "coman"}},
+		{"hablar", TenseMood{"Imperativo Negativo", "Presente"}, [6]string{"", "no hables", "no habléis", "", "no hable", "no hablen"}},
+		{"buscar", TenseMood{"Indicativo", "Pretérito"}, [6]string{"busqué", "buscaste", "buscó", "buscamos", "buscasteis", "buscaron"}},
+		{"llegar", TenseMood{"Subjuntivo", "Presente"}, [6]string{"llegue", "llegues", "llegue", "lleguemos", "lleguéis", "lleguen"}},
+		{"coger", TenseMood{"Indicativo", "Presente"}, [6]string{"cojo", "coges", "coge", "cogemos", "cogéis", "cogen"}},
+		{"vencer", TenseMood{"Subjuntivo", "Presente"}, [6]string{"venza", "venzas", "venza", "venzamos", "venzáis", "venzan"}},
+		{"distinguir", TenseMood{"Indicativo", "Presente"}, [6]string{"distingo", "distingues", "distingue", "distinguimos", "distinguís", "distinguen"}},
+		{"leer", TenseMood{"Indicativo", "Pretérito"}, [6]string{"leí", "leíste", "leyó", "leímos", "leísteis", "leyeron"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.infinitive+" "+tt.tm.Mood+" "+tt.tm.Tense, func(t *testing.T) {
+			got, ok := RegularForms(tt.infinitive, tt.tm)
+			if !ok {
+				t.Fatalf("RegularForms(%q, %v) was not ok", tt.infinitive, tt.tm)
+			}
+			if got != tt.want {
+				t.Errorf("RegularForms(%q, %v) = %q, want %q", tt.infinitive, tt.tm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegularFormsUnknown(t *testing.T) {
+	if _, ok := RegularForms("levantarse", TenseMood{"Indicativo", "Presente"}); ok {
+		t.Error("Expected no regular forms for a reflexive infinitive")
+	}
+	if _, ok := RegularForms("hablar", TenseMood{"Indicativo", "Pasado"}); ok {
+		t.Error("Expected no regular forms for an unknown tense")
+	}
+}
@@ -0,0 +1,83 @@
+package spanish
+
+import "strings"
+
+// TenseMood represents a grammatical mood and tense as they are named in verbs.db.
+type TenseMood struct {
+	Mood  string `json:"mood"`
+	Tense string `json:"tense"`
+}
+
+// TenseMoodChoice is a tense offered to users under an English name.
+type TenseMoodChoice struct {
+	Name  string    `json:"name"`
+	Value TenseMood `json:"value"`
+}
+
+// TenseMoodChoices lists the tenses every front end offers, in the order they are offered.
+var TenseMoodChoices = []TenseMoodChoice{
+	{"Present", TenseMood{"Indicativo", "Presente"}},
+	{"Preterite", TenseMood{"Indicativo", "Pretérito"}},
+	{"Imperfect", TenseMood{"Indicativo", "Imperfecto"}},
+	{"Conditional", TenseMood{"Indicativo", "Condicional"}},
+	{"Future", TenseMood{"Indicativo", "Futuro"}},
+	{"Present perfect", TenseMood{"Indicativo", "Presente perfecto"}},
+	{"Preterite perfect (Past anterior)", TenseMood{"Indicativo", "Pretérito anterior"}},
+	{"Pluperfect (Past perfect)", TenseMood{"Indicativo", "Pluscuamperfecto"}},
+	{"Conditional perfect", TenseMood{"Indicativo", "Condicional perfecto"}},
+	{"Future perfect", TenseMood{"Indicativo", "Futuro perfecto"}},
+	{"Present subjunctive", TenseMood{"Subjuntivo", "Presente"}},
+	{"Imperfect subjunctive", TenseMood{"Subjuntivo", "Imperfecto"}},
+	{"Future subjunctive", TenseMood{"Subjuntivo", "Futuro"}},
+	{"Present perfect subjunctive", TenseMood{"Subjuntivo", "Presente perfecto"}},
+	{"Pluperfect (Past perfect) subjunctive", TenseMood{"Subjuntivo", "Pluscuamperfecto"}},
+	{"Future perfect subjunctive", TenseMood{"Subjuntivo", "Futuro perfecto"}},
+	{"Imperative", TenseMood{"Imperativo Afirmativo", "Presente"}},
+	{"Negative Imperative", TenseMood{"Imperativo Negativo", "Presente"}},
+}
+
+// LookupChoice returns the choice named name, ignoring case and surrounding whitespace, so "present subjunctive"
+// finds "Present subjunctive".
+func LookupChoice(name string) (TenseMoodChoice, bool) {
+	name = strings.TrimSpace(name)
+	for _, choice := range TenseMoodChoices {
+		if strings.EqualFold(choice.Name, name) {
+			return choice, true
+		}
+	}
+	return TenseMoodChoice{}, false
+}
+
+// LookupTenseMood returns the tense of the choice named name, matched as by LookupChoice.
+func LookupTenseMood(name string) (TenseMood, bool) {
+	choice, ok := LookupChoice(name)
+	return choice.Value, ok
+}
+
+// FindTenseMood returns the tense offered in TenseMoodChoices with the Spanish mood and tense names given, ignoring
+// case, accents and whether words are separated by spaces, hyphens or underscores, so "imperativo-afirmativo" and
+// "presente" find Imperativo Afirmativo Presente.
+func FindTenseMood(mood, tense string) (TenseMood, bool) {
+	mood, tense = foldName(mood), foldName(tense)
+	for _, choice := range TenseMoodChoices {
+		if foldName(choice.Value.Mood) == mood && foldName(choice.Value.Tense) == tense {
+			return choice.Value, true
+		}
+	}
+	return TenseMood{}, false
+}
+
+// foldName folds a mood or tense name, reading hyphens and underscores as spaces.
+func foldName(name string) string {
+	return Fold(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+}
+
+// TenseMoodName returns the name of the first choice for tm, or "Mood Tense" when no choice offers it.
+func TenseMoodName(tm TenseMood) string {
+	for _, choice := range TenseMoodChoices {
+		if choice.Value == tm {
+			return choice.Name
+		}
+	}
+	return tm.Mood + " " + tm.Tense
+}
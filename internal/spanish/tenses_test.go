@@ -0,0 +1,48 @@
+package spanish
+
+import "testing"
+
+func TestLookupTenseMood(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   TenseMood
+		wantOK bool
+	}{
+		{"Present subjunctive", TenseMood{"Subjuntivo", "Presente"}, true},
+		{"  negative imperative ", TenseMood{"Imperativo Negativo", "Presente"}, true},
+		{"Presente", TenseMood{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LookupTenseMood(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("LookupTenseMood(%q) = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFindTenseMood(t *testing.T) {
+	tests := []struct {
+		mood, tense string
+		want        TenseMood
+		wantOK      bool
+	}{
+		{"Indicativo", "Pretérito", TenseMood{"Indicativo", "Pretérito"}, true},
+		{"indicativo", "preterito", TenseMood{"Indicativo", "Pretérito"}, true},
+		{"SUBJUNTIVO", "presente_perfecto", TenseMood{"Subjuntivo", "Presente perfecto"}, true},
+		{"imperativo-afirmativo", "presente", TenseMood{"Imperativo Afirmativo", "Presente"}, true},
+		{"Subjuntivo", "Condicional", TenseMood{}, false},
+		{"Present", "subjunctive", TenseMood{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mood+" "+tt.tense, func(t *testing.T) {
+			got, ok := FindTenseMood(tt.mood, tt.tense)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FindTenseMood(%q, %q) = %v, %v, want %v, %v", tt.mood, tt.tense, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
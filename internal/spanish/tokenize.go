@@ -0,0 +1,165 @@
+package spanish
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// VerbPhrase is a word of a text that may be a conjugated verb, with the words that belong to it: the clitic pronouns
+// before it, a preceding no, and the participle after a form of haber.
+type VerbPhrase struct {
+	// Text is the phrase as it appears in the text, normalized, e.g. "lo hemos visto".
+	Text string
+	// Candidates are the spellings verbs.db may store the phrase's form under, most specific first, e.g.
+	// "hemos visto" for "lo hemos visto", "te levantas" and then "levantas" for "te levantas", or "di" for "dímelo".
+	Candidates []string
+}
+
+var (
+	// cliticList holds the unstressed object pronouns, which come before a conjugated verb or are attached to the end
+	// of an affirmative command. The longer ones come first, so "hablarles" loses "les" rather than "es".
+	cliticList = []string{"nos", "los", "las", "les", "me", "te", "se", "os", "lo", "la", "le"}
+	clitics    = func() map[string]bool {
+		set := make(map[string]bool, len(cliticList))
+		for _, c := range cliticList {
+			set[c] = true
+		}
+		return set
+	}()
+	// reflexiveClitics are the clitics verbs.db keeps in the forms of reflexive verbs, as in "te levantas".
+	reflexiveClitics = map[string]bool{"me": true, "te": true, "se": true, "nos": true, "os": true}
+	// functionWords are spelled like a verb form but are nearly always a preposition or adverb in running text.
+	functionWords = map[string]bool{"como": true, "para": true, "sobre": true, "entre": true, "bajo": true, "nada": true}
+	// auxiliaries are the forms of haber that make compound tenses.
+	auxiliaries = func() map[string]bool {
+		aux := make(map[string]bool)
+		for _, forms := range haberForms {
+			for _, form := range forms {
+				aux[form] = true
+			}
+		}
+		return aux
+	}()
+)
+
+// Tokenize splits text into its words, normalized as by Normalize. Anything but letters separates words.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(Normalize(text), func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// VerbPhrases returns the words of text that may be conjugated verbs, in order, grouped with their clitics, a
+// preceding no and, for compound tenses, the participle, so "no lo hemos visto" is one phrase. Which words are verbs
+// is left to a lookup of the candidates.
+func VerbPhrases(text string) []VerbPhrase {
+	words := Tokenize(text)
+	var phrases []VerbPhrase
+	start := 0
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if word == "no" || clitics[word] {
+			continue
+		}
+		if functionWords[word] {
+			start = i + 1
+			continue
+		}
+
+		// Clitics, and a no before them, only belong to the verb they directly precede.
+		first := i
+		for first > start && clitics[words[first-1]] {
+			first--
+		}
+		if first > start && words[first-1] == "no" {
+			first--
+		}
+		form := word
+		if auxiliaries[word] && i+1 < len(words) && isParticiple(words[i+1]) {
+			form += " " + words[i+1]
+		}
+		phrases = append(phrases, VerbPhrase{
+			Text:       strings.Join(append(words[first:i:i], form), " "),
+			Candidates: candidates(words[first:i], form),
+		})
+		i += strings.Count(form, " ")
+		start = i + 1
+	}
+	return phrases
+}
+
+// isParticiple reports whether word ends like the past participles of verbs.db, such as hablado, visto, dicho and
+// impreso, so "ha de venir" is no compound tense.
+func isParticiple(word string) bool {
+	for _, ending := range []string{"do", "to", "cho", "so"} {
+		if strings.HasSuffix(word, ending) {
+			return true
+		}
+	}
+	return false
+}
+
+// candidates returns the spellings to look up form, the verb of a phrase, under, given the words before it: with no
+// and the reflexive clitic verbs.db keeps in negative commands and reflexive verbs, on its own, and with attached
+// clitics removed.
+func candidates(before []string, form string) []string {
+	negated := len(before) > 0 && before[0] == "no"
+	reflexive := ""
+	for _, word := range before {
+		if reflexiveClitics[word] {
+			reflexive = word
+		}
+	}
+
+	var found []string
+	if negated && reflexive != "" {
+		found = append(found, "no "+reflexive+" "+form)
+	}
+	if negated {
+		found = append(found, "no "+form)
+	}
+	if reflexive != "" {
+		found = append(found, reflexive+" "+form)
+	}
+	found = append(found, form)
+	if !strings.Contains(form, " ") {
+		found = append(found, withoutEnclitics(form)...)
+	}
+	return found
+}
+
+// withoutEnclitics returns word without the one or two clitics attached to its end, as in "dímelo" or "hazlo",
+// spelled with and without the written accent the clitics made it take. It returns nothing when word ends in no clitic.
+func withoutEnclitics(word string) []string {
+	var stems []string
+	rest := word
+	for range 2 {
+		stem := ""
+		for _, clitic := range cliticList {
+			if s, ok := strings.CutSuffix(rest, clitic); ok {
+				stem = s
+				break
+			}
+		}
+		if len([]rune(stem)) < 2 {
+			break
+		}
+		stems = append(stems, stem)
+		if unaccented := removeAccents(stem); unaccented != stem {
+			stems = append(stems, unaccented)
+		}
+		rest = stem
+	}
+	return stems
+}
+
+// removeAccents drops the acute accents of word, keeping ñ and ü.
+func removeAccents(word string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(word) {
+		if r != '\u0301' {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(b.String())
+}
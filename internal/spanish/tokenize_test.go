@@ -0,0 +1,42 @@
+package spanish
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("¿Ya  lo HEMOS visto?—¡Sí, ayer!")
+	want := []string{"ya", "lo", "hemos", "visto", "sí", "ayer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestVerbPhrases(t *testing.T) {
+	tests := []struct {
+		text string
+		want []VerbPhrase
+	}{
+		{"Lo hemos visto.", []VerbPhrase{{"lo hemos visto", []string{"hemos visto"}}}},
+		{"No te levantes", []VerbPhrase{{"no te levantes", []string{"no te levantes", "no levantes", "te levantes", "levantes"}}}},
+		{"se lo dije", []VerbPhrase{{"se lo dije", []string{"se dije", "dije"}}}},
+		{"nos hemos levantado", []VerbPhrase{{"nos hemos levantado", []string{"nos hemos levantado", "hemos levantado"}}}},
+		{"Dímelo", []VerbPhrase{{"dímelo", []string{"dímelo", "díme", "dime", "dí", "di"}}}},
+		{"ha de venir", []VerbPhrase{
+			{"ha", []string{"ha"}},
+			{"de", []string{"de"}},
+			{"venir", []string{"venir"}},
+		}},
+		{"como para hablar", []VerbPhrase{{"hablar", []string{"hablar"}}}},
+		{"yo no", []VerbPhrase{{"yo", []string{"yo"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := VerbPhrases(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VerbPhrases(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
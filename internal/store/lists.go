@@ -0,0 +1,289 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+var (
+	// ErrListNotFound is returned when no verb list has the name or share code asked for.
+	ErrListNotFound = errors.New("verb list not found")
+	// ErrListExists is returned when the owner already has a verb list of that name.
+	ErrListExists = errors.New("verb list already exists")
+	// ErrListFull is returned when adding verbs would take a list past MaxListSize.
+	ErrListFull = errors.New("verb list is full")
+)
+
+const (
+	errCreateList = "error creating verb list"
+	errUpdateList = "error updating verb list"
+	errReadList   = "error reading verb list"
+
+	// MaxListSize is the number of verbs a list can hold.
+	MaxListSize = 100
+
+	// shareCodeAlphabet leaves out letters and digits easily mistaken for one another, such as O and 0.
+	shareCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	shareCodeLength   = 6
+	// shareCodeAttempts is how many codes Create draws before giving up on finding one not taken.
+	shareCodeAttempts = 5
+)
+
+// ListScope tells whether a verb list belongs to a user or to a guild.
+type ListScope string
+
+const (
+	ScopeUser  ListScope = "user"
+	ScopeGuild ListScope = "guild"
+)
+
+// ListOwner is the user or guild a verb list belongs to.
+type ListOwner struct {
+	Scope ListScope
+	// ID is the ID of the user or of the guild.
+	ID string
+}
+
+// VerbList is a named list of infinitives.
+type VerbList struct {
+	Owner ListOwner
+	Name  string
+	// ShareCode finds the list from any guild, see ListStore.ByCode.
+	ShareCode string
+	// CreatedBy is the ID of the user who created the list.
+	CreatedBy string
+	// Infinitives are in the order they were added.
+	Infinitives []string
+}
+
+// ListStore keeps verb lists in the app database. List names are matched regardless of case.
+type ListStore struct {
+	db *sql.DB
+}
+
+// NewListStore creates a store over an app database opened by Open.
+func NewListStore(db *sql.DB) *ListStore {
+	return &ListStore{db: db}
+}
+
+// Create creates the list name of owner holding infinitives, which callers validate, and gives it a share code. It
+// returns ErrListExists when owner already has a list of that name.
+func (s *ListStore) Create(ctx context.Context, owner ListOwner, name, createdBy string, infinitives []string) (VerbList, error) {
+	if len(infinitives) > MaxListSize {
+		return VerbList{}, ErrListFull
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return VerbList{}, fmt.Errorf("%s: %w", errCreateList, err)
+	}
+	defer tx.Rollback()
+
+	var (
+		id   int64
+		code string
+	)
+	for range shareCodeAttempts {
+		code = newShareCode()
+		err = tx.QueryRowContext(ctx,
+			`INSERT INTO verb_lists (scope, owner_id, name, share_code, created_by) VALUES (?, ?, ?, ?, ?) RETURNING id`,
+			owner.Scope, owner.ID, name, code, createdBy).Scan(&id)
+		if !isUniqueViolation(err, "verb_lists.share_code") {
+			break
+		}
+	}
+	switch {
+	case isUniqueViolation(err, "verb_lists.name"):
+		return VerbList{}, ErrListExists
+	case err != nil:
+		return VerbList{}, fmt.Errorf("%s: %w", errCreateList, err)
+	}
+	if _, err := addEntries(ctx, tx, id, infinitives); err != nil {
+		return VerbList{}, fmt.Errorf("%s: %w", errCreateList, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return VerbList{}, fmt.Errorf("%s: %w", errCreateList, err)
+	}
+	return s.Get(ctx, owner, name)
+}
+
+// Add adds infinitives to the list name of owner and returns those it did not hold yet. It returns ErrListFull,
+// adding nothing, when the list would hold more than MaxListSize verbs.
+func (s *ListStore) Add(ctx context.Context, owner ListOwner, name string, infinitives []string) ([]string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errUpdateList, err)
+	}
+	defer tx.Rollback()
+
+	id, err := listID(ctx, tx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	added, err := addEntries(ctx, tx, id, infinitives)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errUpdateList, err)
+	}
+	var size int
+	if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM verb_list_entries WHERE list_id = ?`, id).Scan(&size); err != nil {
+		return nil, fmt.Errorf("%s: %w", errUpdateList, err)
+	}
+	if size > MaxListSize {
+		return nil, ErrListFull
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errUpdateList, err)
+	}
+	return added, nil
+}
+
+// Remove removes infinitive from the list name of owner and reports whether the list held it.
+func (s *ListStore) Remove(ctx context.Context, owner ListOwner, name, infinitive string) (bool, error) {
+	id, err := listID(ctx, s.db, owner, name)
+	if err != nil {
+		return false, err
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM verb_list_entries WHERE list_id = ? AND infinitive = ?`, id, infinitive)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", errUpdateList, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", errUpdateList, err)
+	}
+	return n > 0, nil
+}
+
+// Get returns the list name of owner, or ErrListNotFound.
+func (s *ListStore) Get(ctx context.Context, owner ListOwner, name string) (VerbList, error) {
+	return s.get(ctx, `WHERE scope = ? AND owner_id = ? AND name = ?`, owner.Scope, owner.ID, name)
+}
+
+// ByCode returns the list with the share code code, matched regardless of case, or ErrListNotFound. It is how a list
+// is read outside its owner's reach, by another user or from another guild.
+func (s *ListStore) ByCode(ctx context.Context, code string) (VerbList, error) {
+	return s.get(ctx, `WHERE share_code = ?`, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// Lists returns the lists of every owner in owners, by name, without their infinitives.
+func (s *ListStore) Lists(ctx context.Context, owners ...ListOwner) ([]VerbList, error) {
+	var lists []VerbList
+	for _, owner := range owners {
+		rows, err := s.db.QueryContext(ctx,
+			`SELECT name, share_code, created_by FROM verb_lists WHERE scope = ? AND owner_id = ? ORDER BY name`,
+			owner.Scope, owner.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errReadList, err)
+		}
+		for rows.Next() {
+			l := VerbList{Owner: owner}
+			if err := rows.Scan(&l.Name, &l.ShareCode, &l.CreatedBy); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("%s: %w", errReadList, err)
+			}
+			lists = append(lists, l)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errReadList, err)
+		}
+	}
+	return lists, nil
+}
+
+func (s *ListStore) get(ctx context.Context, where string, args ...any) (VerbList, error) {
+	var (
+		l  VerbList
+		id int64
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, scope, owner_id, name, share_code, created_by FROM verb_lists `+where, args...).
+		Scan(&id, &l.Owner.Scope, &l.Owner.ID, &l.Name, &l.ShareCode, &l.CreatedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return VerbList{}, ErrListNotFound
+	}
+	if err != nil {
+		return VerbList{}, fmt.Errorf("%s: %w", errReadList, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT infinitive FROM verb_list_entries WHERE list_id = ? ORDER BY added_at, rowid`, id)
+	if err != nil {
+		return VerbList{}, fmt.Errorf("%s: %w", errReadList, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var infinitive string
+		if err := rows.Scan(&infinitive); err != nil {
+			return VerbList{}, fmt.Errorf("%s: %w", errReadList, err)
+		}
+		l.Infinitives = append(l.Infinitives, infinitive)
+	}
+	if err := rows.Err(); err != nil {
+		return VerbList{}, fmt.Errorf("%s: %w", errReadList, err)
+	}
+	return l, nil
+}
+
+// queryer is what listID and addEntries need of a *sql.DB or *sql.Tx.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// listID returns the row ID of the list name of owner, or ErrListNotFound.
+func listID(ctx context.Context, q queryer, owner ListOwner, name string) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx,
+		`SELECT id FROM verb_lists WHERE scope = ? AND owner_id = ? AND name = ?`, owner.Scope, owner.ID, name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrListNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", errReadList, err)
+	}
+	return id, nil
+}
+
+// addEntries adds infinitives to the list with row ID id, skipping those it holds, and returns the ones added.
+func addEntries(ctx context.Context, q queryer, id int64, infinitives []string) ([]string, error) {
+	var added []string
+	for _, infinitive := range infinitives {
+		res, err := q.ExecContext(ctx,
+			`INSERT OR IGNORE INTO verb_list_entries (list_id, infinitive) VALUES (?, ?)`, id, infinitive)
+		if err != nil {
+			return nil, err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if n > 0 {
+			added = append(added, infinitive)
+		}
+	}
+	return added, nil
+}
+
+// newShareCode draws a random share code from shareCodeAlphabet.
+func newShareCode() string {
+	b := make([]byte, shareCodeLength)
+	rand.Read(b)
+	for j := range b {
+		b[j] = shareCodeAlphabet[int(b[j])%len(shareCodeAlphabet)]
+	}
+	return string(b)
+}
+
+// isUniqueViolation reports whether err is SQLite rejecting a row that repeats the unique column, named as
+// table.column, of another.
+func isUniqueViolation(err error, column string) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) &&
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique &&
+		strings.Contains(sqliteErr.Error(), column)
+}
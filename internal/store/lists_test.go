@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestListStore(t *testing.T) *ListStore {
+	t.Helper()
+	sqlDB, err := Open(context.Background(), filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("Open() returned an error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return NewListStore(sqlDB)
+}
+
+func TestListStore(t *testing.T) {
+	ctx := context.Background()
+	s := newTestListStore(t)
+	ana := ListOwner{Scope: ScopeUser, ID: "ana"}
+	guild := ListOwner{Scope: ScopeGuild, ID: "g1"}
+
+	created, err := s.Create(ctx, ana, "Lesson 1", "ana", []string{"ser", "estar"})
+	if err != nil {
+		t.Fatalf("Create() returned an error: %v", err)
+	}
+	if len(created.ShareCode) != shareCodeLength || !reflect.DeepEqual(created.Infinitives, []string{"ser", "estar"}) {
+		t.Errorf("Create() = %+v, want a share code and the infinitives given", created)
+	}
+	if _, err := s.Create(ctx, ana, "lesson 1", "ana", nil); !errors.Is(err, ErrListExists) {
+		t.Errorf("Create() of a name taken in another case returned %v, want ErrListExists", err)
+	}
+	// The same name is free for another owner.
+	if _, err := s.Create(ctx, guild, "Lesson 1", "ana", nil); err != nil {
+		t.Fatalf("Create() returned an error: %v", err)
+	}
+
+	added, err := s.Add(ctx, ana, "LESSON 1", []string{"estar", "ir", "tener"})
+	if err != nil {
+		t.Fatalf("Add() returned an error: %v", err)
+	}
+	if want := []string{"ir", "tener"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("Add() = %v, want %v", added, want)
+	}
+	if removed, err := s.Remove(ctx, ana, "Lesson 1", "ser"); err != nil || !removed {
+		t.Errorf("Remove() = %v, %v, want true", removed, err)
+	}
+	if removed, _ := s.Remove(ctx, ana, "Lesson 1", "ser"); removed {
+		t.Error("Remove() of a verb not in the list = true, want false")
+	}
+
+	got, err := s.ByCode(ctx, " "+strings.ToLower(created.ShareCode))
+	if err != nil {
+		t.Fatalf("ByCode() returned an error: %v", err)
+	}
+	want := VerbList{Owner: ana, Name: "Lesson 1", ShareCode: created.ShareCode, CreatedBy: "ana", Infinitives: []string{"estar", "ir", "tener"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ByCode() = %+v, want %+v", got, want)
+	}
+
+	lists, err := s.Lists(ctx, ana, guild)
+	if err != nil {
+		t.Fatalf("Lists() returned an error: %v", err)
+	}
+	if len(lists) != 2 || lists[0].Owner != ana || lists[1].Owner != guild {
+		t.Errorf("Lists() = %+v, want the list of ana, then that of the guild", lists)
+	}
+
+	for name, err := range map[string]error{
+		"Get":    func() error { _, err := s.Get(ctx, ana, "missing"); return err }(),
+		"Add":    func() error { _, err := s.Add(ctx, ana, "missing", []string{"ser"}); return err }(),
+		"Remove": func() error { _, err := s.Remove(ctx, ana, "missing", "ser"); return err }(),
+		"ByCode": func() error { _, err := s.ByCode(ctx, "NOCODE"); return err }(),
+	} {
+		if !errors.Is(err, ErrListNotFound) {
+			t.Errorf("%s() of a missing list returned %v, want ErrListNotFound", name, err)
+		}
+	}
+}
+
+func TestListStoreFull(t *testing.T) {
+	ctx := context.Background()
+	s := newTestListStore(t)
+	owner := ListOwner{Scope: ScopeUser, ID: "ana"}
+
+	verbs := make([]string, MaxListSize)
+	for j := range verbs {
+		verbs[j] = strings.Repeat("a", j+1) + "r"
+	}
+	if _, err := s.Create(ctx, owner, "big", "ana", verbs); err != nil {
+		t.Fatalf("Create() returned an error: %v", err)
+	}
+	if _, err := s.Add(ctx, owner, "big", []string{"ser"}); !errors.Is(err, ErrListFull) {
+		t.Errorf("Add() past MaxListSize returned %v, want ErrListFull", err)
+	}
+	if l, _ := s.Get(ctx, owner, "big"); len(l.Infinitives) != MaxListSize {
+		t.Errorf("Expected a rejected Add() to add nothing, got %d verbs", len(l.Infinitives))
+	}
+}
@@ -0,0 +1,31 @@
+-- Per-user preferences, stored as key/value pairs.
+CREATE TABLE user_settings (
+    user_id character varying NOT NULL,
+    key character varying NOT NULL,
+    value character varying NOT NULL,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+
+-- Per-guild configuration, stored as key/value pairs.
+CREATE TABLE guild_config (
+    guild_id character varying NOT NULL,
+    key character varying NOT NULL,
+    value character varying NOT NULL,
+    updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (guild_id, key)
+);
+
+-- One row per answered practice question.
+CREATE TABLE practice_results (
+    id integer NOT NULL PRIMARY KEY,
+    user_id character varying NOT NULL,
+    guild_id character varying,
+    infinitive character varying NOT NULL,
+    mood character varying NOT NULL,
+    tense character varying NOT NULL,
+    person character varying NOT NULL,
+    correct boolean NOT NULL,
+    answered_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX practice_results_user_idx ON practice_results (user_id, answered_at);
@@ -0,0 +1,19 @@
+-- Named verb lists, owned by a user or by a guild. The share code finds a list from anywhere.
+CREATE TABLE verb_lists (
+    id integer NOT NULL PRIMARY KEY,
+    scope character varying NOT NULL CHECK (scope IN ('user', 'guild')),
+    owner_id character varying NOT NULL,
+    name character varying NOT NULL COLLATE NOCASE,
+    share_code character varying NOT NULL UNIQUE,
+    created_by character varying NOT NULL,
+    created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scope, owner_id, name)
+);
+
+-- The infinitives of each verb list, in the order they were added.
+CREATE TABLE verb_list_entries (
+    list_id integer NOT NULL REFERENCES verb_lists (id) ON DELETE CASCADE,
+    infinitive character varying NOT NULL,
+    added_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (list_id, infinitive)
+);
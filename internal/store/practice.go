@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	errRecordPractice = "error recording practice result"
+	errReadPractice   = "error reading practice results"
+
+	// dateLayout is how SQLite's date() spells a day, and timestampLayout how CURRENT_TIMESTAMP spells a time, both in
+	// UTC.
+	dateLayout      = "2006-01-02"
+	timestampLayout = "2006-01-02 15:04:05"
+
+	// weakTenseMinAnswers is how many answers a tense needs before it can count among the weakest, so one slip in a
+	// tense barely practiced does not top the list.
+	weakTenseMinAnswers = 3
+	// weakTenseLimit is the number of weakest tenses a profile lists.
+	weakTenseLimit = 3
+)
+
+// PracticeResult is one answered practice question.
+type PracticeResult struct {
+	UserID string
+	// GuildID is empty for questions answered in direct messages.
+	GuildID    string
+	Infinitive string
+	Mood       string
+	Tense      string
+	// Person is the db.Persons key of the form asked for.
+	Person  string
+	Correct bool
+	// AnsweredAt defaults to the time of recording.
+	AnsweredAt time.Time
+}
+
+// TenseStats counts the answers given in one mood and tense.
+type TenseStats struct {
+	Mood     string
+	Tense    string
+	Answered int
+	Correct  int
+}
+
+// Accuracy returns the share of correct answers, from 0 to 1.
+func (s TenseStats) Accuracy() float64 {
+	if s.Answered == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Answered)
+}
+
+// PracticeProfile sums up the practice of one user.
+type PracticeProfile struct {
+	Answered int
+	Correct  int
+	// Streak is the number of consecutive days, in UTC, up to today or yesterday, with at least one answer.
+	Streak int
+	// LastPracticed is the time of the latest answer, zero when there is none.
+	LastPracticed time.Time
+	// WeakestTenses lists the tenses with the lowest accuracy, lowest first, among those answered often enough.
+	WeakestTenses []TenseStats
+}
+
+// PracticeStore keeps practice results in the app database.
+type PracticeStore struct {
+	db *sql.DB
+	// now is a field so tests can fix the day streaks are counted up to.
+	now func() time.Time
+}
+
+// NewPracticeStore creates a store over an app database opened by Open.
+func NewPracticeStore(db *sql.DB) *PracticeStore {
+	return &PracticeStore{db: db, now: time.Now}
+}
+
+// Record saves the result of one answered question.
+func (s *PracticeStore) Record(ctx context.Context, r PracticeResult) error {
+	if r.AnsweredAt.IsZero() {
+		r.AnsweredAt = s.now()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO practice_results (user_id, guild_id, infinitive, mood, tense, person, correct, answered_at)
+		VALUES (?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?)`,
+		r.UserID, r.GuildID, r.Infinitive, r.Mood, r.Tense, r.Person, r.Correct, r.AnsweredAt.UTC().Format(timestampLayout))
+	if err != nil {
+		return fmt.Errorf("%s: %w", errRecordPractice, err)
+	}
+	return nil
+}
+
+// Profile sums up the practice of userID across every guild. A user who never practiced gets an empty profile.
+func (s *PracticeStore) Profile(ctx context.Context, userID string) (PracticeProfile, error) {
+	var (
+		profile PracticeProfile
+		last    sql.NullString
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT count(*), coalesce(sum(correct), 0), max(answered_at) FROM practice_results WHERE user_id = ?`,
+		userID).Scan(&profile.Answered, &profile.Correct, &last)
+	if err != nil {
+		return PracticeProfile{}, fmt.Errorf("%s: %w", errReadPractice, err)
+	}
+	if profile.Answered == 0 {
+		return profile, nil
+	}
+	if t, err := time.Parse(timestampLayout, last.String); err == nil {
+		profile.LastPracticed = t
+	}
+
+	if profile.Streak, err = s.streak(ctx, userID); err != nil {
+		return PracticeProfile{}, err
+	}
+	if profile.WeakestTenses, err = s.weakestTenses(ctx, userID); err != nil {
+		return PracticeProfile{}, err
+	}
+	return profile, nil
+}
+
+// streak counts the consecutive days with answers of userID, ending today or, when there are none yet today,
+// yesterday.
+func (s *PracticeStore) streak(ctx context.Context, userID string) (int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT date(answered_at) AS day FROM practice_results WHERE user_id = ? ORDER BY day DESC`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", errReadPractice, err)
+	}
+	defer rows.Close()
+
+	today := s.now().UTC().Truncate(24 * time.Hour)
+	expected := today
+	streak := 0
+	for rows.Next() {
+		var day string
+		if err := rows.Scan(&day); err != nil {
+			return 0, fmt.Errorf("%s: %w", errReadPractice, err)
+		}
+		d, err := time.Parse(dateLayout, day)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", errReadPractice, err)
+		}
+		// A streak still counts on a day without answers yet.
+		if streak == 0 && d.Equal(today.AddDate(0, 0, -1)) {
+			expected = d
+		}
+		if !d.Equal(expected) {
+			break
+		}
+		streak++
+		expected = expected.AddDate(0, 0, -1)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", errReadPractice, err)
+	}
+	return streak, nil
+}
+
+// weakestTenses returns the tenses of userID with the lowest accuracy among those answered at least
+// weakTenseMinAnswers times. Ties go to the tense answered more often.
+func (s *PracticeStore) weakestTenses(ctx context.Context, userID string) ([]TenseStats, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT mood, tense, count(*) AS answered, sum(correct) AS correct_count
+		FROM practice_results
+		WHERE user_id = ?
+		GROUP BY mood, tense
+		HAVING answered >= ?
+		ORDER BY CAST(correct_count AS REAL) / answered, answered DESC, mood, tense
+		LIMIT ?`,
+		userID, weakTenseMinAnswers, weakTenseLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errReadPractice, err)
+	}
+	defer rows.Close()
+
+	var stats []TenseStats
+	for rows.Next() {
+		var t TenseStats
+		if err := rows.Scan(&t.Mood, &t.Tense, &t.Answered, &t.Correct); err != nil {
+			return nil, fmt.Errorf("%s: %w", errReadPractice, err)
+		}
+		stats = append(stats, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errReadPractice, err)
+	}
+	return stats, nil
+}
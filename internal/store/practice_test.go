@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestPracticeStore(t *testing.T, now time.Time) *PracticeStore {
+	t.Helper()
+	sqlDB, err := Open(context.Background(), filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("Open() returned an error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	s := NewPracticeStore(sqlDB)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestPracticeProfile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	day := func(daysAgo int) time.Time { return now.AddDate(0, 0, -daysAgo) }
+	s := newTestPracticeStore(t, now)
+
+	results := []PracticeResult{
+		// Yesterday and the two days before, then a gap: a streak of three not yet extended today.
+		{Mood: "Indicativo", Tense: "Presente", Correct: true, AnsweredAt: day(1)},
+		{Mood: "Indicativo", Tense: "Presente", Correct: true, AnsweredAt: day(1)},
+		{Mood: "Indicativo", Tense: "Presente", Correct: false, AnsweredAt: day(2)},
+		{Mood: "Subjuntivo", Tense: "Imperfecto", Correct: false, AnsweredAt: day(3)},
+		{Mood: "Subjuntivo", Tense: "Imperfecto", Correct: false, AnsweredAt: day(3)},
+		{Mood: "Subjuntivo", Tense: "Imperfecto", Correct: true, AnsweredAt: day(5)},
+		// Too few answers to count among the weakest.
+		{Mood: "Indicativo", Tense: "Futuro", Correct: false, AnsweredAt: day(5)},
+	}
+	for _, r := range results {
+		r.UserID, r.Infinitive, r.Person = "ana", "hablar", "1s"
+		if err := s.Record(ctx, r); err != nil {
+			t.Fatalf("Record() returned an error: %v", err)
+		}
+	}
+	if err := s.Record(ctx, PracticeResult{UserID: "ben", GuildID: "g1", Infinitive: "ser", Mood: "Indicativo", Tense: "Presente", Person: "1s"}); err != nil {
+		t.Fatalf("Record() returned an error: %v", err)
+	}
+
+	profile, err := s.Profile(ctx, "ana")
+	if err != nil {
+		t.Fatalf("Profile() returned an error: %v", err)
+	}
+	want := PracticeProfile{
+		Answered:      7,
+		Correct:       3,
+		Streak:        3,
+		LastPracticed: day(1).Truncate(time.Second),
+		WeakestTenses: []TenseStats{
+			{Mood: "Subjuntivo", Tense: "Imperfecto", Answered: 3, Correct: 1},
+			{Mood: "Indicativo", Tense: "Presente", Answered: 3, Correct: 2},
+		},
+	}
+	if !reflect.DeepEqual(profile, want) {
+		t.Errorf("Profile() = %+v, want %+v", profile, want)
+	}
+
+	// An answer today extends the streak; ben answered today only, without a time, so at now.
+	if profile, _ := s.Profile(ctx, "ben"); profile.Streak != 1 || profile.Answered != 1 || !profile.LastPracticed.Equal(now) {
+		t.Errorf("Profile() = %+v, want one answer today", profile)
+	}
+
+	s.now = func() time.Time { return now.AddDate(0, 0, 1) }
+	if profile, _ := s.Profile(ctx, "ana"); profile.Streak != 0 {
+		t.Errorf("Streak = %d two days after the last answer, want 0", profile.Streak)
+	}
+
+	if profile, err := s.Profile(ctx, "nobody"); err != nil || !reflect.DeepEqual(profile, PracticeProfile{}) {
+		t.Errorf("Profile() = %+v, %v, want an empty profile", profile, err)
+	}
+}
+
+func TestTenseStatsAccuracy(t *testing.T) {
+	if got := (TenseStats{Answered: 4, Correct: 3}).Accuracy(); got != 0.75 {
+		t.Errorf("Accuracy() = %v, want 0.75", got)
+	}
+	if got := (TenseStats{}).Accuracy(); got != 0 {
+		t.Errorf("Accuracy() = %v, want 0", got)
+	}
+}
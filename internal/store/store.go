@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// migrationsFS holds the schema of the app database.
+//
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+const (
+	errStoreDir     = "error creating app database directory"
+	errStoreOpen    = "error opening app database"
+	errStoreMigrate = "error migrating app database"
+)
+
+// Migrations returns the embedded migrations of the app database.
+func Migrations() ([]db.Migration, error) {
+	return db.LoadMigrations(migrationsFS, "migrations")
+}
+
+// Open opens the writable app database at path, creating the file and its directory if needed, and applies any
+// pending migrations. The app database holds user settings, practice progress and guild configuration, and is kept
+// apart from the read-only verbs.db.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("%s: %w", errStoreDir, err)
+		}
+	}
+
+	sqlDB, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errStoreOpen, err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("%s: %w", errStoreOpen, err)
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("%s: %w", errStoreMigrate, err)
+	}
+	if _, err := db.Migrate(ctx, sqlDB, migrations); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("%s: %w", errStoreMigrate, err)
+	}
+
+	return sqlDB, nil
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "app.db")
+
+	sqlDB, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() returned an error: %v", err)
+	}
+
+	for _, table := range []string{"user_settings", "guild_config", "practice_results", "verb_lists", "verb_list_entries"} {
+		var name string
+		err := sqlDB.QueryRowContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&name)
+		if err != nil {
+			t.Errorf("Expected table %q to exist: %v", table, err)
+		}
+	}
+
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() returned an error: %v", err)
+	}
+	version, err := db.SchemaVersion(ctx, sqlDB)
+	if err != nil {
+		t.Fatalf("SchemaVersion() returned an error: %v", err)
+	}
+	if want := migrations[len(migrations)-1].Version; version != want {
+		t.Errorf("Expected schema version %d, got %d", want, version)
+	}
+	sqlDB.Close()
+
+	// Reopening an up-to-date database applies nothing and keeps the data.
+	sqlDB, err = Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() returned an error on reopen: %v", err)
+	}
+	defer sqlDB.Close()
+	if v, _ := db.SchemaVersion(ctx, sqlDB); v != version {
+		t.Errorf("Expected schema version %d after reopen, got %d", version, v)
+	}
+}
@@ -0,0 +1,282 @@
+// Package telegram answers conjugation lookups on Telegram through the Bot API, https://core.telegram.org/bots/api.
+// Bot is the core.ChatAdapter of a core.ChatBot, which answers commands and button presses as on every chat platform;
+// inline queries are Telegram's own. Updates arrive by long polling (Poller) or on a webhook (WebhookHandler); either
+// way Bot.HandleUpdate answers them.
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+	"github.com/felipeantoniob/conjugador-bot/internal/metrics"
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+const (
+	msgHelp = "Send <code>/conjugate infinitive</code> and pick a tense, or add the tense yourself: " +
+		"<code>/conjugate tener present subjunctive</code>. <code>/imperative infinitive</code> shows the commands.\n\n" +
+		"In any chat, type <code>@%s infinitive</code> to share a conjugation."
+
+	errStaleButton = "This button no longer works, send /conjugate again."
+
+	// errNotModified is the Bot API's description of an edit that would not change the message, as when the tense
+	// already shown is picked again.
+	errNotModified = "message is not modified"
+
+	// maxCallbackData is the most bytes of callback data the Bot API accepts.
+	maxCallbackData = 64
+	// keyboardColumns is the number of buttons per row.
+	keyboardColumns = 2
+	// inlineCacheTime is how long, in seconds, Telegram may cache the results of an inline query.
+	inlineCacheTime = 300
+	// handleTimeout bounds the handling of one update.
+	handleTimeout = 10 * time.Second
+)
+
+// Commands are the commands the bot lists in its menu.
+var Commands = []BotCommand{
+	{Command: "conjugate", Description: "Conjugate a verb: /conjugate hablar [tense]"},
+	{Command: "imperative", Description: "Affirmative and negative commands: /imperative hablar"},
+	{Command: "help", Description: "How to use the bot"},
+}
+
+// Bot answers the updates of one Telegram bot.
+type Bot struct {
+	client   *Client
+	service  *core.Service
+	render   core.Renderer[string]
+	chat     *core.ChatBot
+	username string
+}
+
+var _ core.ChatAdapter = (*Bot)(nil)
+
+// NewBot creates a bot that answers through client from verb data read from verbs, resolving the infinitives users
+// type through infinitives. username is the bot's username, without "@"; commands addressed to other bots in groups are
+// ignored when it is set.
+func NewBot(client *Client, verbs db.VerbRepository, infinitives *db.InfinitiveIndex, username string) *Bot {
+	b := &Bot{client: client, service: core.NewService(verbs, infinitives), render: Renderer{}, username: username}
+	b.chat = core.NewChatBot(b.service, b)
+	return b
+}
+
+// HandleUpdate answers update. It returns an error only when the Bot API could not be reached or rejected an answer;
+// failed lookups are answered with an explanation.
+func (b *Bot) HandleUpdate(ctx context.Context, update Update) error {
+	return b.handleUpdate(ctx, update, b.chat.Handle)
+}
+
+// Receive answers updates by long polling until ctx is done, passing commands and button presses to handle, and then
+// waits for the updates being answered. As for a Poller, the webhook must be deleted first.
+func (b *Bot) Receive(ctx context.Context, handle func(ctx context.Context, event core.ChatEvent) error) error {
+	poller := newPoller(b.client, func(ctx context.Context, update Update) error {
+		return b.handleUpdate(ctx, update, handle)
+	})
+	poller.Run(ctx)
+	return poller.Wait(context.Background())
+}
+
+func (b *Bot) handleUpdate(ctx context.Context, update Update, handle func(ctx context.Context, event core.ChatEvent) error) error {
+	ctx, cancel := context.WithTimeout(ctx, handleTimeout)
+	defer cancel()
+	logger := slog.Default().With("update_id", update.UpdateID)
+	ctx = logging.WithLogger(logging.WithRequestID(ctx, logging.NewRequestID()), logger)
+
+	switch {
+	case update.Message != nil:
+		return b.handleMessage(ctx, update.Message, handle)
+	case update.CallbackQuery != nil:
+		return b.handleCallbackQuery(ctx, update.CallbackQuery, handle)
+	case update.InlineQuery != nil:
+		return b.handleInlineQuery(ctx, update.InlineQuery)
+	}
+	return nil
+}
+
+// parseCommand splits a message such as "/conjugate@bot tener present subjunctive" into its command, "conjugate", and
+// arguments. ok is false for messages that are no command, or a command addressed to another bot.
+func (b *Bot) parseCommand(text string) (command string, args []string, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return "", nil, false
+	}
+	command, to, addressed := strings.Cut(fields[0][1:], "@")
+	if addressed && b.username != "" && !strings.EqualFold(to, b.username) {
+		return "", nil, false
+	}
+	return strings.ToLower(command), fields[1:], true
+}
+
+// handleMessage answers /help itself, as it explains inline queries, and passes the lookup commands to handle.
+func (b *Bot) handleMessage(ctx context.Context, msg *Message, handle func(ctx context.Context, event core.ChatEvent) error) error {
+	command, args, ok := b.parseCommand(msg.Text)
+	if !ok {
+		return nil
+	}
+
+	switch command {
+	case "start", "help":
+		metrics.CommandInvoked("telegram_help")
+		return b.send(ctx, msg.Chat.ID, fmt.Sprintf(msgHelp, html.EscapeString(b.usernameOrDefault())), nil)
+	case "conjugate", "imperative":
+		metrics.CommandInvoked("telegram_" + command)
+		event := core.ChatEvent{Message: messageRef(*msg), Command: command, Args: args}
+		if msg.From != nil {
+			event.Sender = strconv.FormatInt(msg.From.ID, 10)
+		}
+		return handle(ctx, event)
+	}
+	return nil
+}
+
+func (b *Bot) usernameOrDefault() string {
+	if b.username == "" {
+		return "bot"
+	}
+	return b.username
+}
+
+// handleCallbackQuery passes a button press to handle, then answers the query so the client stops showing progress;
+// the edited message, or a reply explaining a failure, shows the outcome.
+func (b *Bot) handleCallbackQuery(ctx context.Context, query *CallbackQuery, handle func(ctx context.Context, event core.ChatEvent) error) error {
+	if query.Message == nil {
+		return b.client.AnswerCallbackQuery(ctx, AnswerCallbackQueryParams{CallbackQueryID: query.ID, Text: errStaleButton})
+	}
+	metrics.CommandInvoked("telegram_tense_button")
+
+	err := handle(ctx, core.ChatEvent{
+		Message: messageRef(*query.Message),
+		Sender:  strconv.FormatInt(query.From.ID, 10),
+		Button:  query.Data,
+	})
+	return errors.Join(err, b.client.AnswerCallbackQuery(ctx, AnswerCallbackQueryParams{CallbackQueryID: query.ID}))
+}
+
+// handleInlineQuery answers "infinitive [tense]" with one result per tense, or only the one named.
+func (b *Bot) handleInlineQuery(ctx context.Context, query *InlineQuery) error {
+	answer := AnswerInlineQueryParams{InlineQueryID: query.ID, Results: []InlineQueryResultArticle{}, CacheTime: inlineCacheTime}
+	fields := strings.Fields(query.Query)
+	if len(fields) == 0 {
+		return b.client.AnswerInlineQuery(ctx, answer)
+	}
+	metrics.CommandInvoked("telegram_inline")
+	input, tenseName := fields[0], strings.Join(fields[1:], " ")
+
+	var results []core.ConjugationResult
+	if tenseName != "" {
+		result, err := b.service.Conjugate(ctx, input, tenseName, 0)
+		if err == nil {
+			results = append(results, result)
+		} else {
+			logging.FromContext(ctx).DebugContext(ctx, "inline query without results", "query", query.Query, "error", err)
+		}
+	} else {
+		table, err := b.service.Table(ctx, input, nil)
+		if err != nil {
+			logging.FromContext(ctx).DebugContext(ctx, "inline query without results", "query", query.Query, "error", err)
+		}
+		for _, result := range table.Conjugations {
+			// Only the tenses /conjugate offers, in its order; Table lists them first.
+			if _, ok := spanish.LookupChoice(result.TenseName); ok {
+				results = append(results, result)
+			}
+		}
+	}
+
+	for i, result := range results {
+		answer.Results = append(answer.Results, InlineQueryResultArticle{
+			Type:                "article",
+			ID:                  strconv.Itoa(i),
+			Title:               result.Infinitive + " – " + result.TenseName,
+			Description:         summary(result),
+			InputMessageContent: InputTextMessageContent{MessageText: b.render.Conjugation(result), ParseMode: parseMode},
+		})
+	}
+	return b.client.AnswerInlineQuery(ctx, answer)
+}
+
+// Reply sends reply to the chat of event.
+func (b *Bot) Reply(ctx context.Context, event core.ChatEvent, reply core.ChatReply) (core.MessageRef, error) {
+	chatID, err := strconv.ParseInt(event.Message.Conversation, 10, 64)
+	if err != nil {
+		return core.MessageRef{}, err
+	}
+	msg, err := b.client.SendMessage(ctx, SendMessageParams{
+		ChatID:      chatID,
+		Text:        renderReply(reply),
+		ParseMode:   parseMode,
+		ReplyMarkup: keyboard(reply.Buttons),
+	})
+	if err != nil {
+		return core.MessageRef{}, err
+	}
+	return messageRef(msg), nil
+}
+
+// Edit replaces the text and buttons of msg, a message the bot sent. An edit that would not change it, as when the
+// tense shown is picked again, is no error.
+func (b *Bot) Edit(ctx context.Context, msg core.MessageRef, reply core.ChatReply) error {
+	chatID, err := strconv.ParseInt(msg.Conversation, 10, 64)
+	if err != nil {
+		return err
+	}
+	messageID, err := strconv.ParseInt(msg.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+	err = b.client.EditMessageText(ctx, EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        renderReply(reply),
+		ParseMode:   parseMode,
+		ReplyMarkup: keyboard(reply.Buttons),
+	})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && strings.Contains(apiErr.Description, errNotModified) {
+		return nil
+	}
+	return err
+}
+
+func (b *Bot) send(ctx context.Context, chatID int64, text string, keyboard *InlineKeyboardMarkup) error {
+	_, err := b.client.SendMessage(ctx, SendMessageParams{ChatID: chatID, Text: text, ParseMode: parseMode, ReplyMarkup: keyboard})
+	return err
+}
+
+// messageRef identifies msg to core.ChatBot.
+func messageRef(msg Message) core.MessageRef {
+	return core.MessageRef{Conversation: strconv.FormatInt(msg.Chat.ID, 10), ID: strconv.FormatInt(msg.MessageID, 10)}
+}
+
+// keyboard lays out buttons in rows of keyboardColumns. It returns nil when there are none, or when the data of one is
+// too long for callback data.
+func keyboard(buttons []core.Button) *InlineKeyboardMarkup {
+	if len(buttons) == 0 {
+		return nil
+	}
+	keyboard := &InlineKeyboardMarkup{}
+	var row []InlineKeyboardButton
+	for _, button := range buttons {
+		if len(button.Data) > maxCallbackData {
+			return nil
+		}
+		row = append(row, InlineKeyboardButton{Text: button.Label, CallbackData: button.Data})
+		if len(row) == keyboardColumns {
+			keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, row)
+	}
+	return keyboard
+}
@@ -0,0 +1,356 @@
+package telegram
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+	"github.com/felipeantoniob/conjugador-bot/internal/db"
+)
+
+const testToken = "7000000001:AAFakeTokenForTests"
+
+func ns(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// apiCall is a Bot API call the fake server received. Params holds the parameters of every method the bot calls.
+type apiCall struct {
+	Method string
+	Params struct {
+		ChatID          int64                      `json:"chat_id"`
+		MessageID       int64                      `json:"message_id"`
+		Text            string                     `json:"text"`
+		ParseMode       string                     `json:"parse_mode"`
+		ReplyMarkup     *InlineKeyboardMarkup      `json:"reply_markup"`
+		CallbackQueryID string                     `json:"callback_query_id"`
+		InlineQueryID   string                     `json:"inline_query_id"`
+		Results         []InlineQueryResultArticle `json:"results"`
+		Offset          int64                      `json:"offset"`
+	}
+}
+
+// fakeAPI is a Bot API server that records the calls it receives, serves queued updates to getUpdates and fails the
+// methods in failures with the given description.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	calls    []apiCall
+	updates  []Update
+	failures map[string]string
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	api := &fakeAPI{failures: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /{bot}/{method}", api.serve)
+	api.Server = httptest.NewServer(mux)
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (f *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("bot") != "bot"+testToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(response{ErrorCode: http.StatusUnauthorized, Description: "Unauthorized"})
+		return
+	}
+	call := apiCall{Method: r.PathValue("method")}
+	if err := json.NewDecoder(r.Body).Decode(&call.Params); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response{ErrorCode: http.StatusBadRequest, Description: err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	description, fail := f.failures[call.Method]
+	f.mu.Unlock()
+	if fail {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(response{ErrorCode: http.StatusBadRequest, Description: description})
+		return
+	}
+
+	var result any = true
+	switch call.Method {
+	case "getUpdates":
+		result = f.pendingUpdates(r.Context(), call.Params.Offset)
+	case "sendMessage":
+		result = Message{MessageID: 500, Chat: Chat{ID: call.Params.ChatID}, Text: call.Params.Text}
+	}
+	raw, _ := json.Marshal(result)
+	json.NewEncoder(w).Encode(response{OK: true, Result: raw})
+}
+
+// pendingUpdates confirms the updates before offset and returns the others, waiting a little for some to arrive as
+// long polling does.
+func (f *fakeAPI) pendingUpdates(ctx context.Context, offset int64) []Update {
+	for range 5 {
+		f.mu.Lock()
+		var pending []Update
+		for _, u := range f.updates {
+			if u.UpdateID >= offset {
+				pending = append(pending, u)
+			}
+		}
+		f.updates = pending
+		f.mu.Unlock()
+		if len(pending) > 0 {
+			return pending
+		}
+		select {
+		case <-ctx.Done():
+			return []Update{}
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return []Update{}
+}
+
+// recorded returns the calls received so far, leaving out getUpdates.
+func (f *fakeAPI) recorded() []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []apiCall
+	for _, c := range f.calls {
+		if c.Method != "getUpdates" {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// loadUpdate reads an update recorded from the Bot API in testdata.
+func loadUpdate(t *testing.T, name string) Update {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatalf("ReadFile() returned an error: %v", err)
+	}
+	var update Update
+	if err := json.Unmarshal(data, &update); err != nil {
+		t.Fatalf("Unmarshal(%s) returned an error: %v", name, err)
+	}
+	return update
+}
+
+func newTestBot(api *fakeAPI) *Bot {
+	infinitives := []db.Infinitive{{Infinitive: "hablar"}, {Infinitive: "tener"}, {Infinitive: "sonar"}, {Infinitive: "soñar"}}
+	verbs := db.NewMemoryRepository(db.MemoryData{
+		Infinitives: infinitives,
+		Verbs: []db.Verb{
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Presente", VerbEnglish: ns("I speak"),
+				Form1s: ns("hablo"), Form2s: ns("hablas"), Form3s: ns("habla"), Form1p: ns("hablamos"), Form2p: ns("habláis"), Form3p: ns("hablan")},
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Pretérito", VerbEnglish: ns("I spoke"),
+				Form1s: ns("hablé"), Form2s: ns("hablaste"), Form3s: ns("habló"), Form1p: ns("hablamos"), Form2p: ns("hablasteis"), Form3p: ns("hablaron")},
+			{Infinitive: "tener", Mood: "Subjuntivo", Tense: "Presente", VerbEnglish: ns("I have"),
+				Form1s: ns("tenga"), Form2s: ns("tengas"), Form3s: ns("tenga"), Form1p: ns("tengamos"), Form2p: ns("tengáis"), Form3p: ns("tengan")},
+		},
+		Examples: []db.Example{
+			{Infinitive: "hablar", Mood: "Indicativo", Tense: "Pretérito", Person: "1s", Sentence: "Hablé con <Ana>.", SentenceEnglish: ns("I spoke with Ana.")},
+		},
+	})
+	return NewBot(NewClient(api.URL, testToken), verbs, db.NewInfinitiveIndex(infinitives), "conjugador_bot")
+}
+
+func TestHandleUpdate(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		wantMethods []string
+		check       func(t *testing.T, calls []apiCall)
+	}{
+		{"conjugate_picker", []string{"sendMessage"}, func(t *testing.T, calls []apiCall) {
+			p := calls[0].Params
+			if p.ChatID != 111222333 || p.Text != "Choose a tense for hablar:" || p.ParseMode != "HTML" {
+				t.Errorf("sendMessage = %+v, want the tense picker for hablar", p)
+			}
+			if p.ReplyMarkup == nil || len(p.ReplyMarkup.InlineKeyboard) != 9 {
+				t.Fatalf("ReplyMarkup = %+v, want 9 rows of tenses", p.ReplyMarkup)
+			}
+			if got := p.ReplyMarkup.InlineKeyboard[0][1]; got.Text != "Preterite" || got.CallbackData != "t:1:hablar" {
+				t.Errorf("Second button = %+v, want Preterite with t:1:hablar", got)
+			}
+		}},
+		{"conjugate_tense", []string{"sendMessage"}, func(t *testing.T, calls []apiCall) {
+			p := calls[0].Params
+			for _, want := range []string{"<b>tener – I have</b>", "<i>Present subjunctive (Subjuntivo Presente)</i>", "yo: <b>tenga</b>"} {
+				if !strings.Contains(p.Text, want) {
+					t.Errorf("Text = %q, want it to contain %q", p.Text, want)
+				}
+			}
+			if got := p.ReplyMarkup.InlineKeyboard[5][0]; got.Text != "✓ Present subjunctive" {
+				t.Errorf("Button = %+v, want the current tense checked", got)
+			}
+		}},
+		{"conjugate_other_bot", nil, nil},
+		{"conjugate_unknown", []string{"sendMessage"}, func(t *testing.T, calls []apiCall) {
+			if got := calls[0].Params.Text; got != "Verb not found." {
+				t.Errorf("Text = %q, want the verb not found", got)
+			}
+		}},
+		{"conjugate_unknown_tense", []string{"sendMessage"}, func(t *testing.T, calls []apiCall) {
+			if got := calls[0].Params.Text; !strings.HasPrefix(got, "Unknown tense &#34;pasado&#34;.") {
+				t.Errorf("Text = %q, want the unknown tense named", got)
+			}
+		}},
+		{"conjugate_ambiguous", []string{"sendMessage"}, func(t *testing.T, calls []apiCall) {
+			if got := calls[0].Params.Text; got != "Did you mean sonar or soñar?" {
+				t.Errorf("Text = %q, want both candidates", got)
+			}
+		}},
+		{"help", []string{"sendMessage"}, func(t *testing.T, calls []apiCall) {
+			if got := calls[0].Params.Text; !strings.Contains(got, "@conjugador_bot infinitive") {
+				t.Errorf("Text = %q, want the inline usage", got)
+			}
+		}},
+		{"callback_tense", []string{"editMessageText", "answerCallbackQuery"}, func(t *testing.T, calls []apiCall) {
+			p := calls[0].Params
+			if p.ChatID != 111222333 || p.MessageID != 401 {
+				t.Errorf("editMessageText edits %d in %d, want 401 in 111222333", p.MessageID, p.ChatID)
+			}
+			for _, want := range []string{"yo: hablé", "• <i>Hablé con &lt;Ana&gt;. — I spoke with Ana.</i>"} {
+				if !strings.Contains(p.Text, want) {
+					t.Errorf("Text = %q, want it to contain %q", p.Text, want)
+				}
+			}
+			if got := calls[1].Params; got.CallbackQueryID != "47700033100000001" || got.Text != "" {
+				t.Errorf("answerCallbackQuery = %+v, want the query answered silently", got)
+			}
+		}},
+		{"callback_stale", []string{"sendMessage", "answerCallbackQuery"}, func(t *testing.T, calls []apiCall) {
+			if got := calls[0].Params.Text; !strings.HasPrefix(got, "This choice no longer works") {
+				t.Errorf("Text = %q, want the button explained as stale", got)
+			}
+		}},
+		{"inline_query", []string{"answerInlineQuery"}, func(t *testing.T, calls []apiCall) {
+			results := calls[0].Params.Results
+			if len(results) != 2 {
+				t.Fatalf("Got %d results, want the Present and the Preterite", len(results))
+			}
+			if got := results[1]; got.Title != "hablar – Preterite" || got.Description != "hablé, hablaste, habló, hablamos, hablasteis, hablaron" {
+				t.Errorf("Result = %+v, want the Preterite of hablar", got)
+			}
+		}},
+		{"inline_query_tense", []string{"answerInlineQuery"}, func(t *testing.T, calls []apiCall) {
+			results := calls[0].Params.Results
+			if len(results) != 1 || !strings.Contains(results[0].InputMessageContent.MessageText, "habló") {
+				t.Errorf("Results = %+v, want the Preterite only", results)
+			}
+		}},
+		{"inline_query_unknown", []string{"answerInlineQuery"}, func(t *testing.T, calls []apiCall) {
+			if got := calls[0].Params; got.InlineQueryID != "47700033100001003" || len(got.Results) != 0 {
+				t.Errorf("answerInlineQuery = %+v, want no results", got)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			api := newFakeAPI(t)
+			if err := newTestBot(api).HandleUpdate(context.Background(), loadUpdate(t, tt.fixture)); err != nil {
+				t.Fatalf("HandleUpdate() returned an error: %v", err)
+			}
+
+			calls := api.recorded()
+			var methods []string
+			for _, c := range calls {
+				methods = append(methods, c.Method)
+			}
+			if strings.Join(methods, ",") != strings.Join(tt.wantMethods, ",") {
+				t.Fatalf("Called %v, want %v", methods, tt.wantMethods)
+			}
+			if tt.check != nil {
+				tt.check(t, calls)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateEditNotModified(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failures["editMessageText"] = "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"
+
+	if err := newTestBot(api).HandleUpdate(context.Background(), loadUpdate(t, "callback_tense")); err != nil {
+		t.Fatalf("HandleUpdate() returned an error: %v", err)
+	}
+	if calls := api.recorded(); len(calls) != 2 || calls[1].Method != "answerCallbackQuery" {
+		t.Errorf("Calls = %+v, want the callback query answered", calls)
+	}
+
+	api.failures["editMessageText"] = "Bad Request: message to edit not found"
+	err := newTestBot(api).HandleUpdate(context.Background(), loadUpdate(t, "callback_tense"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Method != "editMessageText" || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("HandleUpdate() error = %v, want the editMessageText *APIError", err)
+	}
+}
+
+func TestClientRejectedToken(t *testing.T) {
+	api := newFakeAPI(t)
+	client := NewClient(api.URL, "wrong-token")
+
+	_, err := client.SendMessage(context.Background(), SendMessageParams{ChatID: 1, Text: "hola"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusUnauthorized {
+		t.Errorf("SendMessage() error = %v, want a 401 *APIError", err)
+	}
+}
+
+func TestCallbackData(t *testing.T) {
+	for _, row := range keyboard(core.TenseButtons("hablar", "")).InlineKeyboard {
+		for _, button := range row {
+			choice, infinitive, ok := core.ParseTenseButton(button.CallbackData)
+			if !ok || infinitive != "hablar" || choice.Name != button.Text {
+				t.Errorf("ParseTenseButton(%q) = %v, %q, %v, want %s for hablar", button.CallbackData, choice, infinitive, ok, button.Text)
+			}
+		}
+	}
+
+	if got := keyboard(core.TenseButtons(strings.Repeat("a", maxCallbackData), "")); got != nil {
+		t.Errorf("keyboard() = %+v for an over-long infinitive, want nil", got)
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	result := core.ConjugationResult{
+		Infinitive: "tener", English: "I have", TenseName: "Present", Mood: "Indicativo", Tense: "Presente",
+		Forms: []core.Form{{Label: "yo", Form: "tengo", Irregular: true}, {Label: "nosotros", Form: "tenemos"}},
+	}
+	want := "<b>tener</b> – I have\n<i>Present (Indicativo Presente)</i>\n\nyo: <b>tengo</b>\nnosotros: tenemos\n"
+	if got := (Renderer{}).Conjugation(result); got != want {
+		t.Errorf("Conjugation() = %q, want %q", got, want)
+	}
+
+	imperative := core.ImperativeResult{Infinitive: "ir", Rows: []core.ImperativeRow{{Person: "tú", Affirmative: "ve", Negative: "no vayas", Subjunctive: "vayas"}}}
+	if got := (Renderer{}).Imperative(imperative); !strings.Contains(got, "tú: ve · no vayas ↳ vayas\n") {
+		t.Errorf("Imperative() = %q, want the tú row", got)
+	}
+}
+
+func TestRenderReply(t *testing.T) {
+	imperative := core.ImperativeReply(core.ImperativeResult{
+		Infinitive: "ir", English: "to go",
+		Rows: []core.ImperativeRow{{Person: "tú", Affirmative: "ve", Negative: "no vayas", Subjunctive: "vayas"}},
+	})
+	want := "<b>ir – to go</b>\n\ntú: ve · no vayas · vayas\n\n<i>afirmativo · negativo · presente de subjuntivo</i>"
+	if got := renderReply(imperative); got != want {
+		t.Errorf("renderReply() = %q, want %q", got, want)
+	}
+
+	if got := renderReply(core.ChatReply{Text: "Unknown tense \"<b>\"."}); got != "Unknown tense &#34;&lt;b&gt;&#34;." {
+		t.Errorf("renderReply() = %q, want the text escaped", got)
+	}
+}
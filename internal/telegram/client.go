@@ -0,0 +1,158 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the Bot API server the bot talks to unless told otherwise, e.g. a local Bot API server or a fake in
+// tests.
+const DefaultAPIURL = "https://api.telegram.org"
+
+const (
+	errEncodeRequest  = "failed to encode request"
+	errCallMethod     = "failed to call %s"
+	errDecodeResponse = "failed to decode %s response"
+)
+
+// allowedUpdates are the update types the bot asks for; every other type is never delivered.
+var allowedUpdates = []string{"message", "callback_query", "inline_query"}
+
+// APIError is returned when the Bot API answers a call with ok set to false.
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %d %s", e.Method, e.Code, e.Description)
+}
+
+// Client calls the methods of the Bot API with a bot token.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient creates a client for the bot with token on the Bot API server at baseURL, or DefaultAPIURL when it is
+// empty.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		// Long polling holds requests open for pollTimeout, so the client allows a little more.
+		http: &http.Client{Timeout: pollTimeout + 10*time.Second},
+	}
+}
+
+// call posts params as JSON to method and decodes its result into result, which may be nil.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errEncodeRequest, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf(errCallMethod+": %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// The URL holds the token, which must not end up in logs.
+		return fmt.Errorf(errCallMethod+": %w", method, redactToken(err, c.token))
+	}
+	defer resp.Body.Close()
+
+	var envelope response
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf(errDecodeResponse+": %w", method, err)
+	}
+	if !envelope.OK {
+		return &APIError{Method: method, Code: envelope.ErrorCode, Description: envelope.Description}
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf(errDecodeResponse+": %w", method, err)
+	}
+	return nil
+}
+
+// redactToken replaces token in the message of err.
+func redactToken(err error, token string) error {
+	if token == "" || !strings.Contains(err.Error(), token) {
+		return err
+	}
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), token, "<token>"))
+}
+
+// GetMe returns the bot's own user, which holds its username.
+func (c *Client) GetMe(ctx context.Context) (User, error) {
+	var me User
+	err := c.call(ctx, "getMe", map[string]any{}, &me)
+	return me, err
+}
+
+// GetUpdates returns the updates from offset on, waiting up to timeout for one to arrive.
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	params := map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout / time.Second),
+		"allowed_updates": allowedUpdates,
+	}
+	var updates []Update
+	err := c.call(ctx, "getUpdates", params, &updates)
+	return updates, err
+}
+
+// SendMessage sends a message and returns it.
+func (c *Client) SendMessage(ctx context.Context, params SendMessageParams) (Message, error) {
+	var msg Message
+	err := c.call(ctx, "sendMessage", params, &msg)
+	return msg, err
+}
+
+// EditMessageText replaces the text and keyboard of a message the bot sent.
+func (c *Client) EditMessageText(ctx context.Context, params EditMessageTextParams) error {
+	return c.call(ctx, "editMessageText", params, nil)
+}
+
+// AnswerCallbackQuery stops the progress indicator of a pressed button, optionally showing text.
+func (c *Client) AnswerCallbackQuery(ctx context.Context, params AnswerCallbackQueryParams) error {
+	return c.call(ctx, "answerCallbackQuery", params, nil)
+}
+
+// AnswerInlineQuery sends the results of an inline query.
+func (c *Client) AnswerInlineQuery(ctx context.Context, params AnswerInlineQueryParams) error {
+	return c.call(ctx, "answerInlineQuery", params, nil)
+}
+
+// SetMyCommands replaces the commands listed in the menu of the bot.
+func (c *Client) SetMyCommands(ctx context.Context, commands []BotCommand) error {
+	return c.call(ctx, "setMyCommands", map[string]any{"commands": commands}, nil)
+}
+
+// SetWebhook has the Bot API post updates to params.URL instead of queueing them for GetUpdates.
+func (c *Client) SetWebhook(ctx context.Context, params SetWebhookParams) error {
+	if params.AllowedUpdates == nil {
+		params.AllowedUpdates = allowedUpdates
+	}
+	return c.call(ctx, "setWebhook", params, nil)
+}
+
+// DeleteWebhook removes the webhook, so that GetUpdates can be used.
+func (c *Client) DeleteWebhook(ctx context.Context) error {
+	return c.call(ctx, "deleteWebhook", map[string]any{}, nil)
+}
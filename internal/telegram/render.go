@@ -0,0 +1,132 @@
+package telegram
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+)
+
+// parseMode is how the bot formats its messages. Telegram's HTML supports only a few inline tags, so results are
+// rendered as lines rather than tables.
+const parseMode = "HTML"
+
+// Renderer renders results as messages in Telegram's HTML parse mode, marking irregular forms in bold.
+type Renderer struct{}
+
+var _ core.Renderer[string] = Renderer{}
+
+func writeTitle(b *strings.Builder, infinitive, english string) {
+	fmt.Fprintf(b, "<b>%s</b>", html.EscapeString(infinitive))
+	if english != "" {
+		b.WriteString(" – " + html.EscapeString(english))
+	}
+	b.WriteByte('\n')
+}
+
+func (Renderer) Conjugation(r core.ConjugationResult) string {
+	var b strings.Builder
+	writeTitle(&b, r.Infinitive, r.English)
+	fmt.Fprintf(&b, "<i>%s</i>\n\n", html.EscapeString(r.Heading()))
+	for _, f := range r.Forms {
+		form := html.EscapeString(f.Form)
+		if f.Irregular {
+			form = "<b>" + form + "</b>"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", html.EscapeString(f.Label), form)
+	}
+	if len(r.Examples) > 0 {
+		b.WriteString("\n<b>Ejemplos</b>\n")
+		writeExamples(&b, r.Examples)
+	}
+	return b.String()
+}
+
+func (Renderer) Imperative(r core.ImperativeResult) string {
+	var b strings.Builder
+	writeTitle(&b, r.Infinitive, r.English)
+	b.WriteByte('\n')
+	for _, row := range r.Rows {
+		fmt.Fprintf(&b, "%s: %s · %s ↳ %s\n", html.EscapeString(row.Person), html.EscapeString(row.Affirmative),
+			html.EscapeString(row.Negative), html.EscapeString(row.Subjunctive))
+	}
+	b.WriteString("\n<i>afirmativo · negativo ↳ presente de subjuntivo</i>\n")
+	return b.String()
+}
+
+func (Renderer) Examples(r core.ExamplesResult) string {
+	var b strings.Builder
+	writeTitle(&b, r.Infinitive, r.TenseName)
+	writeExamples(&b, r.Examples)
+	return b.String()
+}
+
+func writeExamples(b *strings.Builder, examples []core.Example) {
+	for _, ex := range examples {
+		fmt.Fprintf(b, "• <i>%s</i>", html.EscapeString(ex.Sentence))
+		if ex.English != "" {
+			b.WriteString(" — " + html.EscapeString(ex.English))
+		}
+		b.WriteByte('\n')
+	}
+}
+
+// renderReply renders a reply of core.ChatBot. Telegram's HTML has no tables, so a table becomes a line per row, its
+// first cell before a colon and the others joined by " · ", with the headers of those columns below when there are
+// several; strong cells are bold. Above a table, the first line of the text is bold, as the title of a result, and the
+// others italic. Notes are listed below.
+func renderReply(reply core.ChatReply) string {
+	var b strings.Builder
+	for i, line := range strings.Split(reply.Text, "\n") {
+		line = html.EscapeString(line)
+		switch {
+		case reply.Table == nil:
+		case i == 0:
+			line = "<b>" + line + "</b>"
+		default:
+			line = "<i>" + line + "</i>"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if t := reply.Table; t != nil {
+		b.WriteByte('\n')
+		for _, row := range t.Rows {
+			cells := make([]string, len(row))
+			for i, c := range row {
+				cells[i] = html.EscapeString(c.Text)
+				if c.Strong {
+					cells[i] = "<b>" + cells[i] + "</b>"
+				}
+			}
+			if len(cells) > 1 {
+				b.WriteString(cells[0] + ": " + strings.Join(cells[1:], " · ") + "\n")
+			} else {
+				b.WriteString(strings.Join(cells, "") + "\n")
+			}
+		}
+		if len(t.Header) > 2 {
+			fmt.Fprintf(&b, "\n<i>%s</i>\n", html.EscapeString(strings.Join(t.Header[1:], " · ")))
+		}
+	}
+
+	if len(reply.Notes) > 0 {
+		b.WriteByte('\n')
+		for _, note := range reply.Notes {
+			b.WriteString("• <i>" + html.EscapeString(note) + "</i>\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// summary lists the forms of r on one line, e.g. "hablo, hablas, habla, …", as the description of an inline result.
+func summary(r core.ConjugationResult) string {
+	forms := make([]string, 0, len(r.Forms))
+	for _, f := range r.Forms {
+		if f.Form != "" {
+			forms = append(forms, f.Form)
+		}
+	}
+	return strings.Join(forms, ", ")
+}
@@ -0,0 +1,125 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/logging"
+)
+
+const (
+	// pollTimeout is how long a getUpdates call waits for an update before returning none.
+	pollTimeout = 30 * time.Second
+	// retryDelay is how long polling waits after a failed getUpdates call.
+	retryDelay = 5 * time.Second
+	// secretHeader carries the secret token given to setWebhook in every webhook request.
+	secretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+	errGetUpdates   = "failed to get updates"
+	errHandleUpdate = "failed to answer update"
+	errDecodeUpdate = "failed to decode webhook update"
+)
+
+// Poller fetches updates by long polling and has a Bot answer them, each in its own goroutine.
+type Poller struct {
+	client   *Client
+	answer   func(ctx context.Context, update Update) error
+	inFlight sync.WaitGroup
+	// retryDelay is a field so tests need not wait for it.
+	retryDelay time.Duration
+}
+
+// NewPoller creates a poller that fetches the updates of client's bot for bot to answer.
+func NewPoller(client *Client, bot *Bot) *Poller {
+	return newPoller(client, bot.HandleUpdate)
+}
+
+// newPoller creates a poller that passes the updates of client's bot to answer.
+func newPoller(client *Client, answer func(ctx context.Context, update Update) error) *Poller {
+	return &Poller{client: client, answer: answer, retryDelay: retryDelay}
+}
+
+// Run polls for updates until ctx is cancelled. Updates being answered then are not cancelled; Wait waits for them.
+// The webhook must be deleted first, as the Bot API refuses getUpdates while one is set.
+func (p *Poller) Run(ctx context.Context) {
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := p.client.GetUpdates(ctx, offset, pollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logging.FromContext(ctx).WarnContext(ctx, errGetUpdates, "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.retryDelay):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			// Confirms the updates received so far on the next call, so each is answered once.
+			offset = max(offset, update.UpdateID+1)
+			p.inFlight.Add(1)
+			go func() {
+				defer p.inFlight.Done()
+				p.handle(context.WithoutCancel(ctx), update)
+			}()
+		}
+	}
+}
+
+func (p *Poller) handle(ctx context.Context, update Update) {
+	if err := p.answer(ctx, update); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, errHandleUpdate, "update_id", update.UpdateID, "error", err)
+	}
+}
+
+// Wait blocks until every update being answered is answered, or ctx is done.
+func (p *Poller) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		p.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WebhookHandler answers the updates the Bot API posts to the webhook set with secret, rejecting requests without it.
+// It responds once the update is answered, so the Bot API sends the next one only then.
+func WebhookHandler(bot *Bot, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(secretHeader)), []byte(secret)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := r.Context()
+		var update Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&update); err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, errDecodeUpdate, "error", err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		// A failed answer is not retried: the Bot API would redeliver the update, and answer it twice if only the
+		// last call failed.
+		if err := bot.HandleUpdate(context.WithoutCancel(ctx), update); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, errHandleUpdate, "update_id", update.UpdateID, "error", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/core"
+)
+
+func TestPoller(t *testing.T) {
+	api := newFakeAPI(t)
+	api.updates = []Update{loadUpdate(t, "conjugate_picker"), loadUpdate(t, "inline_query")}
+	poller := NewPoller(NewClient(api.URL, testToken), newTestBot(api))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		poller.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(api.recorded()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	if err := poller.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() returned an error: %v", err)
+	}
+
+	answered := map[string]bool{}
+	for _, c := range api.recorded() {
+		answered[c.Method] = true
+	}
+	if !answered["sendMessage"] || !answered["answerInlineQuery"] {
+		t.Errorf("Answered %v, want both updates answered", answered)
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	var offsets []int64
+	for _, c := range api.calls {
+		if c.Method == "getUpdates" {
+			offsets = append(offsets, c.Params.Offset)
+		}
+	}
+	if len(offsets) < 2 || offsets[0] != 0 || offsets[1] != 81500202 {
+		t.Errorf("getUpdates offsets = %v, want 0 and then one past the last update, 81500202", offsets)
+	}
+}
+
+func TestPollerRetries(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failures["getUpdates"] = "Conflict: can't use getUpdates method while webhook is active"
+	poller := NewPoller(NewClient(api.URL, testToken), newTestBot(api))
+	poller.retryDelay = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	poller.Run(ctx)
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(api.calls) < 2 {
+		t.Errorf("getUpdates called %d times, want it retried after failing", len(api.calls))
+	}
+}
+
+func TestBotReceive(t *testing.T) {
+	api := newFakeAPI(t)
+	api.updates = []Update{loadUpdate(t, "conjugate_tense"), loadUpdate(t, "help")}
+	bot := newTestBot(api)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan core.ChatEvent, 1)
+	done := make(chan error)
+	go func() {
+		done <- bot.Receive(ctx, func(ctx context.Context, event core.ChatEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+
+	select {
+	case event := <-events:
+		if event.Command != "conjugate" || strings.Join(event.Args, " ") != "tener present subjunctive" || event.Message.Conversation != "111222333" {
+			t.Errorf("Received %+v, want the /conjugate command of chat 111222333", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Receive() passed no command to handle")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Receive() returned an error: %v", err)
+	}
+	// /help is answered by the bot itself.
+	if calls := api.recorded(); len(calls) != 1 || calls[0].Method != "sendMessage" {
+		t.Errorf("Calls = %+v, want only the help sent", calls)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "s3cret"
+	body, err := os.ReadFile(filepath.Join("testdata", "conjugate_tense.json"))
+	if err != nil {
+		t.Fatalf("ReadFile() returned an error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		secret     string
+		body       []byte
+		wantStatus int
+		wantCalls  int
+	}{
+		{"update", http.MethodPost, secret, body, http.StatusOK, 1},
+		{"missing secret", http.MethodPost, "", body, http.StatusUnauthorized, 0},
+		{"wrong secret", http.MethodPost, "guess", body, http.StatusUnauthorized, 0},
+		{"not JSON", http.MethodPost, secret, []byte("hola"), http.StatusBadRequest, 0},
+		{"GET", http.MethodGet, secret, nil, http.StatusMethodNotAllowed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t)
+			handler := WebhookHandler(newTestBot(api), secret)
+
+			req := httptest.NewRequest(tt.method, "/telegram", bytes.NewReader(tt.body))
+			if tt.secret != "" {
+				req.Header.Set(secretHeader, tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := len(api.recorded()); got != tt.wantCalls {
+				t.Errorf("Made %d Bot API calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Package tenseinfo holds the guides to the tenses users pick from: how each is formed, when it is used, the words
+// that signal it and example sentences. The guides are YAML files in data, one per tense, embedded in the binary.
+package tenseinfo
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the format of the guide files this package reads. A file of another version is rejected, so a change to
+// the format cannot be half-read by an older binary.
+const Version = 1
+
+//go:embed data/*.yaml
+var dataFS embed.FS
+
+const (
+	errReadGuide      = "%s: %w"
+	errVersion        = "%s: version %d, want %d"
+	errUnknownTense   = "%s: tense %q is not a tense choice"
+	errDuplicateTense = "%s: tense %q is already described by %s"
+	errMissingField   = "%s: %s is required"
+	errMissingGuide   = "no guide for tense %q"
+	errNotConjugated  = "tense %q is %s %s, which verbs.db does not conjugate"
+)
+
+// Example is an example sentence and its translation.
+type Example struct {
+	Spanish string `yaml:"es"`
+	English string `yaml:"en"`
+}
+
+// Guide explains one tense.
+type Guide struct {
+	// Choice is the tense choice the guide describes.
+	Choice spanish.TenseMoodChoice `yaml:"-"`
+	// English is the English name verbs.db gives the mood and tense; empty when it has none.
+	English     string    `yaml:"-"`
+	Formation   string    `yaml:"formation"`
+	Usage       []string  `yaml:"usage"`
+	SignalWords []string  `yaml:"signal_words"`
+	Examples    []Example `yaml:"examples"`
+}
+
+// file is the layout of a guide file.
+type file struct {
+	Version int    `yaml:"version"`
+	Tense   string `yaml:"tense"`
+	Guide   `yaml:",inline"`
+}
+
+// Library holds a guide for every tense choice.
+type Library struct {
+	guides map[string]Guide
+}
+
+// Load reads the embedded guides and checks them against choices: every file must be of the current version, name a
+// choice and fill in every field, and every choice needs exactly one guide. The error lists every problem found.
+// moodTenses maps every mood and tense verbs.db conjugates to its English name, and every choice must be one of them;
+// a nil map skips that check.
+func Load(choices []spanish.TenseMoodChoice, moodTenses map[spanish.TenseMood]string) (*Library, error) {
+	return load(dataFS, "data", choices, moodTenses)
+}
+
+func load(fsys fs.FS, dir string, choices []spanish.TenseMoodChoice, moodTenses map[spanish.TenseMood]string) (*Library, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]spanish.TenseMoodChoice, len(choices))
+	var problems []error
+	for _, c := range choices {
+		byName[c.Name] = c
+		if _, ok := moodTenses[c.Value]; moodTenses != nil && !ok {
+			problems = append(problems, fmt.Errorf(errNotConjugated, c.Name, c.Value.Mood, c.Value.Tense))
+		}
+	}
+	lib := &Library{guides: make(map[string]Guide, len(choices))}
+	sources := make(map[string]string, len(choices))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || path.Ext(name) != ".yaml" {
+			continue
+		}
+		f, err := readFile(fsys, path.Join(dir, name))
+		if err != nil {
+			problems = append(problems, fmt.Errorf(errReadGuide, name, err))
+			continue
+		}
+		if f.Version != Version {
+			problems = append(problems, fmt.Errorf(errVersion, name, f.Version, Version))
+			continue
+		}
+		choice, ok := byName[f.Tense]
+		if !ok {
+			problems = append(problems, fmt.Errorf(errUnknownTense, name, f.Tense))
+			continue
+		}
+		if source, dup := sources[f.Tense]; dup {
+			problems = append(problems, fmt.Errorf(errDuplicateTense, name, f.Tense, source))
+			continue
+		}
+		if missing := f.missingFields(); len(missing) > 0 {
+			for _, field := range missing {
+				problems = append(problems, fmt.Errorf(errMissingField, name, field))
+			}
+			continue
+		}
+
+		guide := f.Guide
+		guide.Choice = choice
+		guide.English = moodTenses[choice.Value]
+		lib.guides[f.Tense] = guide
+		sources[f.Tense] = name
+	}
+	for _, c := range choices {
+		if _, ok := sources[c.Name]; !ok {
+			problems = append(problems, fmt.Errorf(errMissingGuide, c.Name))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return lib, nil
+}
+
+// readFile decodes the guide file at name, rejecting fields the format does not have.
+func readFile(fsys fs.FS, name string) (file, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return file{}, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var f file
+	if err := dec.Decode(&f); err != nil {
+		return file{}, err
+	}
+	return f, nil
+}
+
+// missingFields names the fields of f left empty.
+func (f file) missingFields() []string {
+	var missing []string
+	if strings.TrimSpace(f.Formation) == "" {
+		missing = append(missing, "formation")
+	}
+	if len(f.Usage) == 0 {
+		missing = append(missing, "usage")
+	}
+	if len(f.SignalWords) == 0 {
+		missing = append(missing, "signal_words")
+	}
+	if len(f.Examples) == 0 {
+		missing = append(missing, "examples")
+	}
+	for _, ex := range f.Examples {
+		if ex.Spanish == "" || ex.English == "" {
+			missing = append(missing, "es and en of every example")
+			break
+		}
+	}
+	return missing
+}
+
+// Lookup returns the guide to the tense choice named name, matched as by spanish.LookupChoice.
+func (l *Library) Lookup(name string) (Guide, bool) {
+	choice, ok := spanish.LookupChoice(name)
+	if !ok {
+		return Guide{}, false
+	}
+	guide, ok := l.guides[choice.Name]
+	return guide, ok
+}
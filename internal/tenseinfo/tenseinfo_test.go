@@ -0,0 +1,78 @@
+package tenseinfo
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/felipeantoniob/conjugador-bot/internal/spanish"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	moodTenses := make(map[spanish.TenseMood]string)
+	for _, c := range spanish.TenseMoodChoices {
+		moodTenses[c.Value] = ""
+	}
+	moodTenses[spanish.TenseMood{Mood: "Indicativo", Tense: "Pluscuamperfecto"}] = "Past Perfect"
+	lib, err := Load(spanish.TenseMoodChoices, moodTenses)
+	if err != nil {
+		t.Fatalf("Load() returned an error: %v", err)
+	}
+
+	guide, ok := lib.Lookup("pluperfect (past perfect)")
+	if !ok {
+		t.Fatal("Lookup() found no guide to the pluperfect")
+	}
+	if guide.Choice.Value != (spanish.TenseMood{Mood: "Indicativo", Tense: "Pluscuamperfecto"}) || guide.English != "Past Perfect" {
+		t.Errorf("Guide = %+v, want the Indicativo Pluscuamperfecto, Past Perfect in English", guide)
+	}
+	if !strings.HasPrefix(guide.Formation, "The imperfect of haber") || len(guide.Examples) == 0 {
+		t.Errorf("Guide = %+v, want its formation and examples", guide)
+	}
+	if _, ok := lib.Lookup("Aorist"); ok {
+		t.Error("Lookup() found a guide to a tense that is no choice")
+	}
+}
+
+func TestLoadProblems(t *testing.T) {
+	choices := []spanish.TenseMoodChoice{
+		{Name: "Present", Value: spanish.TenseMood{Mood: "Indicativo", Tense: "Presente"}},
+		{Name: "Future", Value: spanish.TenseMood{Mood: "Indicativo", Tense: "Futuro"}},
+		{Name: "Preterite", Value: spanish.TenseMood{Mood: "Indicativo", Tense: "Pretérito"}},
+	}
+	const valid = "formation: x\nusage: [x]\nsignal_words: [x]\nexamples: [{es: x, en: x}]\n"
+	fsys := fstest.MapFS{
+		"data/present.yaml":   {Data: []byte("version: 1\ntense: Present\n" + valid)},
+		"data/present-2.yaml": {Data: []byte("version: 1\ntense: Present\n" + valid)},
+		"data/future.yaml":    {Data: []byte("version: 2\ntense: Future\n" + valid)},
+		"data/aorist.yaml":    {Data: []byte("version: 1\ntense: Aorist\n" + valid)},
+		"data/typo.yaml":      {Data: []byte("version: 1\ntense: Future\nformaton: x\n")},
+		"data/empty.yaml":     {Data: []byte("version: 1\ntense: Preterite\nexamples: [{es: x}]\n")},
+		"data/README.md":      {Data: []byte("not a guide")},
+	}
+
+	// verbs.db conjugates every choice but the preterite.
+	moodTenses := map[spanish.TenseMood]string{choices[0].Value: "Present", choices[1].Value: "Future"}
+	_, err := load(fsys, "data", choices, moodTenses)
+	if err == nil {
+		t.Fatal("load() returned no error")
+	}
+	for _, want := range []string{
+		`present.yaml: tense "Present" is already described by present-2.yaml`,
+		"future.yaml: version 2, want 1",
+		`aorist.yaml: tense "Aorist" is not a tense choice`,
+		"typo.yaml: yaml: unmarshal errors",
+		"empty.yaml: formation is required",
+		"empty.yaml: es and en of every example is required",
+		`no guide for tense "Future"`,
+		`no guide for tense "Preterite"`,
+		`tense "Preterite" is Indicativo Pretérito, which verbs.db does not conjugate`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("load() error = %v, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "README") {
+		t.Errorf("load() error = %v, want only YAML files read", err)
+	}
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultHookTimeout bounds a hook's OnStart or OnStop when the hook does not set its own Timeout.
+const DefaultHookTimeout = 10 * time.Second
+
+var errHookTimeout = errors.New("timed out")
+
+// Hook is a component whose start and stop a Lifecycle orders. Both functions are optional: resources that are ready
+// once created, such as an open database, only need OnStop.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+	// Timeout bounds OnStart and OnStop each. Zero means DefaultHookTimeout.
+	Timeout time.Duration
+}
+
+// Lifecycle starts hooks in the order they were appended and stops them in reverse, so every component stops before
+// the components it depends on.
+type Lifecycle struct {
+	stopDeadline time.Duration
+	exit         func(code int)
+
+	mu    sync.Mutex
+	hooks []*lifecycleHook
+}
+
+type lifecycleHook struct {
+	Hook
+	started bool
+}
+
+// NewLifecycle creates a Lifecycle whose Stop gives up on hooks still running after stopDeadline.
+func NewLifecycle(stopDeadline time.Duration) *Lifecycle {
+	return &Lifecycle{stopDeadline: stopDeadline, exit: os.Exit}
+}
+
+// Append registers a hook. A hook without OnStart counts as started right away, since the resource it stops already
+// exists; the others are started by the next call to Start.
+func (l *Lifecycle) Append(h Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, &lifecycleHook{Hook: h, started: h.OnStart == nil})
+}
+
+// Start runs the OnStart of every hook not started yet, in order. If one fails, every started hook is stopped and the
+// errors of both are returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := append([]*lifecycleHook(nil), l.hooks...)
+	l.mu.Unlock()
+
+	for _, h := range hooks {
+		if h.started {
+			continue
+		}
+		if err := runHook(ctx, h.Timeout, h.OnStart); err != nil {
+			err = fmt.Errorf("starting %s: %w", h.Name, err)
+			return errors.Join(err, l.Stop(context.Background()))
+		}
+		l.mu.Lock()
+		h.started = true
+		l.mu.Unlock()
+	}
+	return nil
+}
+
+// Stop runs the OnStop of every started hook in reverse order, within the lifecycle's stop deadline, and forgets
+// them. A failing or timed out hook does not keep the others from stopping; every error is logged and all of them are
+// returned joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, l.stopDeadline)
+	defer cancel()
+
+	l.mu.Lock()
+	var started, remaining []*lifecycleHook
+	for _, h := range l.hooks {
+		if h.started {
+			started = append(started, h)
+		} else {
+			remaining = append(remaining, h)
+		}
+	}
+	l.hooks = remaining
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		h := started[i]
+		if h.OnStop == nil {
+			continue
+		}
+		if err := runHook(ctx, h.Timeout, h.OnStop); err != nil {
+			err = fmt.Errorf("stopping %s: %w", h.Name, err)
+			slog.Error("shutdown hook failed", "hook", h.Name, "error", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run starts every hook, blocks until SIGINT or SIGTERM arrives on sigCh or ctx is done, and stops every hook. A
+// second signal while stopping exits the process immediately. sigCh may already be subscribed to the signals, so that
+// one received during setup is acted on here.
+func (l *Lifecycle) Run(ctx context.Context, sigCh chan os.Signal) error {
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	if err := l.Start(ctx); err != nil {
+		return err
+	}
+
+	select {
+	case sig := <-sigCh:
+		slog.Info("received shutdown signal", "signal", sig)
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case sig := <-sigCh:
+			slog.Error("received second shutdown signal, exiting immediately", "signal", sig)
+			l.exit(1)
+		case <-stopped:
+		}
+	}()
+
+	return l.Stop(context.Background())
+}
+
+// runHook runs fn with a context bounded by timeout, returning once fn does or the context expires. A hook that
+// ignores its context is abandoned rather than allowed to hold up the others.
+func runHook(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- fn(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", errHookTimeout, ctx.Err())
+	}
+}
@@ -0,0 +1,219 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// recorder collects the order hooks run in.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) hook(name string, startErr, stopErr error) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			r.record("start " + name)
+			return startErr
+		},
+		OnStop: func(ctx context.Context) error {
+			r.record("stop " + name)
+			return stopErr
+		},
+	}
+}
+
+func (r *recorder) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func TestLifecycleOrder(t *testing.T) {
+	var r recorder
+	l := NewLifecycle(time.Second)
+	l.Append(r.hook("db", nil, nil))
+	l.Append(Hook{Name: "stop only", OnStop: func(ctx context.Context) error {
+		r.record("stop stop only")
+		return nil
+	}})
+	l.Append(r.hook("session", nil, nil))
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned an error: %v", err)
+	}
+	if err := l.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() returned an error: %v", err)
+	}
+
+	want := []string{"start db", "start session", "stop session", "stop stop only", "stop db"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Hooks ran as %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleStartFailure(t *testing.T) {
+	var r recorder
+	l := NewLifecycle(time.Second)
+	l.Append(r.hook("db", nil, nil))
+	l.Append(r.hook("session", errors.New("invalid token"), nil))
+	l.Append(r.hook("server", nil, nil))
+
+	err := l.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "starting session: invalid token") {
+		t.Fatalf("Start() error = %v, want the session failure", err)
+	}
+
+	want := []string{"start db", "start session", "stop db"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Hooks ran as %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleStopAggregatesErrors(t *testing.T) {
+	var r recorder
+	l := NewLifecycle(time.Second)
+	l.Append(r.hook("db", nil, errors.New("db busy")))
+	l.Append(r.hook("session", nil, errors.New("already closed")))
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned an error: %v", err)
+	}
+	err := l.Stop(context.Background())
+
+	for _, want := range []string{"stopping db: db busy", "stopping session: already closed"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Stop() error = %v, want it to contain %q", err, want)
+		}
+	}
+	if got := r.get(); len(got) != 4 {
+		t.Errorf("Expected both hooks to stop despite the errors, got %v", got)
+	}
+}
+
+func TestLifecycleStopTimeouts(t *testing.T) {
+	var r recorder
+	hang := func(ctx context.Context) error {
+		<-make(chan struct{})
+		return nil
+	}
+
+	l := NewLifecycle(200 * time.Millisecond)
+	l.Append(r.hook("db", nil, nil))
+	l.Append(Hook{Name: "hung", OnStop: hang, Timeout: 50 * time.Millisecond})
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned an error: %v", err)
+	}
+
+	start := time.Now()
+	err := l.Stop(context.Background())
+	if err == nil || !errors.Is(err, errHookTimeout) {
+		t.Errorf("Stop() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Stop() took %v, want the hook timeout to cut it short", elapsed)
+	}
+
+	// The global deadline applies even when a hook allows itself more time.
+	l = NewLifecycle(50 * time.Millisecond)
+	l.Append(Hook{Name: "hung", OnStop: hang, Timeout: time.Hour})
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned an error: %v", err)
+	}
+	start = time.Now()
+	if err := l.Stop(context.Background()); err == nil {
+		t.Error("Stop() returned no error for a hook past the deadline")
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Stop() took %v, want the global deadline to cut it short", elapsed)
+	}
+}
+
+func TestLifecycleStopOnlyHooksCountAsStarted(t *testing.T) {
+	var r recorder
+	l := NewLifecycle(time.Second)
+	l.Append(Hook{Name: "db", OnStop: func(ctx context.Context) error {
+		r.record("stop db")
+		return nil
+	}})
+	l.Append(r.hook("server", nil, nil))
+
+	// Setup failed before Start: the database is open and must be closed, the server never started.
+	if err := l.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() returned an error: %v", err)
+	}
+	want := []string{"stop db"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Hooks ran as %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleRun(t *testing.T) {
+	var r recorder
+	l := NewLifecycle(time.Second)
+	l.Append(r.hook("db", nil, nil))
+
+	sigCh := make(chan os.Signal, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		sigCh <- syscall.SIGTERM
+	}()
+
+	if err := l.Run(context.Background(), sigCh); err != nil {
+		t.Fatalf("Run() returned an error: %v", err)
+	}
+	want := []string{"start db", "stop db"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Hooks ran as %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleRunSecondSignal(t *testing.T) {
+	stopping := make(chan struct{})
+	exited := make(chan int, 1)
+
+	l := NewLifecycle(time.Second)
+	l.exit = func(code int) { exited <- code }
+	l.Append(Hook{Name: "slow", OnStop: func(ctx context.Context) error {
+		close(stopping)
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	sigCh := make(chan os.Signal, 1)
+	sigCh <- syscall.SIGINT
+	go func() {
+		<-stopping
+		sigCh <- syscall.SIGINT
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		l.Run(context.Background(), sigCh)
+		close(done)
+	}()
+
+	select {
+	case code := <-exited:
+		if code != 1 {
+			t.Errorf("Exit code = %d, want 1", code)
+		}
+	case <-done:
+		t.Fatal("Run() returned without a forced exit")
+	}
+}